@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"os/user"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+func approveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approve --step=<step-name> /path/to/spec.yaml",
+		Short: "Approve a step waiting for approval",
+		Long:  `dagu approve --step=<step-name> [--approver=<name>] [--comment=<comment>] /path/to/spec.yaml`,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(func(cmd *cobra.Command, args []string) error {
+			return runApproval(cmd, args, true)
+		}),
+	}
+
+	initApprovalFlags(cmd)
+
+	return cmd
+}
+
+func rejectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reject --step=<step-name> /path/to/spec.yaml",
+		Short: "Reject a step waiting for approval",
+		Long:  `dagu reject --step=<step-name> [--approver=<name>] [--comment=<comment>] /path/to/spec.yaml`,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(func(cmd *cobra.Command, args []string) error {
+			return runApproval(cmd, args, false)
+		}),
+	}
+
+	initApprovalFlags(cmd)
+
+	return cmd
+}
+
+func initApprovalFlags(cmd *cobra.Command) {
+	initCommonFlags(cmd, []commandLineFlag{withRequired(stepFlag), approverFlag, commentFlag})
+}
+
+func runApproval(cmd *cobra.Command, args []string, approved bool) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	opts, err := approvalOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	dag, err := digraph.Load(cmd.Context(), args[0], digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig))
+	if err != nil {
+		logger.Error(ctx, "Failed to load DAG", "err", err)
+		return fmt.Errorf("failed to load DAG from %s: %w", args[0], err)
+	}
+
+	cli, err := setup.client()
+	if err != nil {
+		logger.Error(ctx, "failed to initialize client", "err", err)
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	decide, decision := cli.Approve, "approved"
+	if !approved {
+		decide, decision = cli.Reject, "rejected"
+	}
+
+	if err := decide(cmd.Context(), dag, opts); err != nil {
+		logger.Error(ctx, "Failed to send the decision", "dag", dag.Name, "step", opts.Step, "err", err)
+		return fmt.Errorf("failed to send the decision: %w", err)
+	}
+
+	logger.Info(ctx, "Step "+decision, "dag", dag.Name, "step", opts.Step, "approver", opts.Approver)
+	return nil
+}
+
+func approvalOptions(cmd *cobra.Command) (client.ApprovalOptions, error) {
+	var opts client.ApprovalOptions
+	var err error
+	if opts.Step, err = cmd.Flags().GetString("step"); err != nil {
+		return opts, fmt.Errorf("failed to get step: %w", err)
+	}
+	if opts.Approver, err = cmd.Flags().GetString("approver"); err != nil {
+		return opts, fmt.Errorf("failed to get approver: %w", err)
+	}
+	if opts.Comment, err = cmd.Flags().GetString("comment"); err != nil {
+		return opts, fmt.Errorf("failed to get comment: %w", err)
+	}
+	if opts.Approver == "" {
+		if u, err := user.Current(); err == nil {
+			opts.Approver = u.Username
+		}
+	}
+	return opts, nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+)
+
+func TestApproveCommand(t *testing.T) {
+	t.Run("ApproveStep", func(t *testing.T) {
+		th := testSetup(t)
+
+		dagFile := th.DAG(t, "cmd/approve.yaml")
+
+		done := make(chan struct{})
+		go func() {
+			// Start the DAG waiting for approval.
+			args := []string{"start", dagFile.Location}
+			th.RunCommand(t, startCmd(), cmdTest{args: args})
+			close(done)
+		}()
+
+		time.Sleep(time.Millisecond * 100)
+
+		// Wait for the DAG running.
+		dagFile.AssertLatestStatus(t, scheduler.StatusRunning)
+
+		// Approve the step.
+		th.RunCommand(t, approveCmd(), cmdTest{
+			args:        []string{"approve", "--step=approve", "--approver=alice", dagFile.Location},
+			expectedOut: []string{"Step approved"}})
+
+		// Check the DAG is finished.
+		dagFile.AssertLatestStatus(t, scheduler.StatusSuccess)
+		<-done
+	})
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/scheduler"
+	"github.com/dagu-org/dagu/internal/scheduler/calendar"
+	"github.com/spf13/cobra"
+)
+
+// maxBackfillRuns is the maximum number of the runs of a backfill.
+const maxBackfillRuns = 1000
+
+func backfillCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill --from=<time> --to=<time> /path/to/spec.yaml",
+		Short: "Runs the DAG for the scheduled times in a range",
+		Long:  `dagu backfill --from=2025-01-01 --to=2025-01-31 /path/to/spec.yaml`,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runBackfill),
+	}
+
+	initBackfillFlags(cmd)
+	return cmd
+}
+
+func initBackfillFlags(cmd *cobra.Command) {
+	initCommonFlags(cmd, []commandLineFlag{withRequired(fromFlag), withRequired(toFlag), paramsFlag})
+}
+
+func runBackfill(cmd *cobra.Command, args []string) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	from, err := getTimeFlag(cmd, "from")
+	if err != nil {
+		return err
+	}
+	to, err := getTimeFlag(cmd, "to")
+	if err != nil {
+		return err
+	}
+	if to.Before(from) {
+		return fmt.Errorf("--to must not be before --from")
+	}
+
+	params, err := cmd.Flags().GetString("params")
+	if err != nil {
+		return fmt.Errorf("failed to get parameters: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	loadOpts := []digraph.LoadOption{
+		digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig),
+		digraph.WithParams(removeQuotes(params)),
+	}
+
+	dag, err := digraph.Load(ctx, args[0], append(loadOpts, digraph.OnlyMetadata(), digraph.WithoutEval())...)
+	if err != nil {
+		logger.Error(ctx, "Failed to load DAG", "path", args[0], "err", err)
+		return fmt.Errorf("failed to load DAG from %s: %w", args[0], err)
+	}
+
+	// Skip the times excluded by the calendars as the scheduler does.
+	registry, err := calendar.NewRegistry(setup.cfg.Calendars, setup.cfg.Location)
+	if err != nil {
+		return fmt.Errorf("failed to initialize calendars: %w", err)
+	}
+	calendars, err := registry.Resolve(dag.ExcludeCalendars)
+	if err != nil {
+		return fmt.Errorf("failed to resolve calendars of %s: %w", dag.Name, err)
+	}
+
+	// Include the scheduled time equal to --from.
+	slots := dag.ScheduledTimes(from.Add(-time.Nanosecond), to, maxBackfillRuns+1, calendars...)
+	if len(slots) == 0 {
+		logger.Info(ctx, "No scheduled times in the range", "DAG", dag.Name, "from", from, "to", to)
+		return nil
+	}
+	if len(slots) > maxBackfillRuns {
+		return fmt.Errorf("too many scheduled times in the range: the maximum is %d", maxBackfillRuns)
+	}
+
+	logger.Info(ctx, "Backfill started", "DAG", dag.Name, "runs", len(slots))
+
+	historyStore := setup.historyStore()
+	for _, slot := range slots {
+		requestID := scheduler.ScheduledRequestID(dag.Name, slot)
+		if _, err := historyStore.FindByRequestID(ctx, dag.Location, requestID); err == nil {
+			logger.Info(ctx, "Skipping the scheduled time already run", "logicalDate", slot, "requestID", requestID)
+			continue
+		}
+
+		if err := executeDag(ctx, setup, args[0], loadOpts, false, requestID, partialRun{}, nil, slot); err != nil {
+			return fmt.Errorf("backfill stopped at %s: %w", slot.Format(time.RFC3339), err)
+		}
+	}
+
+	logger.Info(ctx, "Backfill finished", "DAG", dag.Name, "runs", len(slots))
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	schedule "github.com/dagu-org/dagu/internal/scheduler"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillCommand(t *testing.T) {
+	t.Run("Backfill", func(t *testing.T) {
+		th := testSetup(t)
+
+		dagFile := th.DAG(t, "cmd/backfill.yaml")
+
+		args := []string{"backfill", "--from=2025-01-01", "--to=2025-01-03T12:00", dagFile.Location}
+		th.RunCommand(t, backfillCmd(), cmdTest{args: args, expectedOut: []string{"Backfill finished"}})
+
+		history := th.Client.GetRecentHistory(context.Background(), dagFile.DAG, 10)
+		require.Len(t, history, 3)
+
+		var logicalDates []string
+		for _, file := range history {
+			require.Equal(t, scheduler.StatusSuccess, file.Status.Status)
+			logicalDates = append(logicalDates, file.Status.LogicalDate)
+		}
+		sort.Strings(logicalDates)
+
+		var expected []string
+		for day := 1; day <= 3; day++ {
+			expected = append(expected, stringutil.FormatTime(time.Date(2025, 1, day, 2, 0, 0, 0, time.Local)))
+		}
+		require.Equal(t, expected, logicalDates)
+
+		// The scheduled times already run are skipped.
+		th.RunCommand(t, backfillCmd(), cmdTest{args: args, expectedOut: []string{"Skipping the scheduled time already run"}})
+		require.Len(t, th.Client.GetRecentHistory(context.Background(), dagFile.DAG, 10), 3)
+	})
+	t.Run("SkipScheduledRun", func(t *testing.T) {
+		th := testSetup(t)
+
+		dagFile := th.DAG(t, "cmd/backfill.yaml")
+
+		// The run started by the scheduler on time for the second day.
+		slot := time.Date(2025, 1, 2, 2, 0, 0, 0, time.Local)
+		requestID := schedule.ScheduledRequestID(dagFile.Name, slot)
+		th.RunCommand(t, startCmd(), cmdTest{args: []string{
+			"start", "--req=" + requestID, "--logical-date=" + stringutil.FormatTime(slot), dagFile.Location,
+		}})
+
+		args := []string{"backfill", "--from=2025-01-01", "--to=2025-01-03T12:00", dagFile.Location}
+		th.RunCommand(t, backfillCmd(), cmdTest{args: args, expectedOut: []string{"Skipping the scheduled time already run"}})
+
+		history := th.Client.GetRecentHistory(context.Background(), dagFile.DAG, 10)
+		require.Len(t, history, 3)
+		var requestIDs []string
+		for _, file := range history {
+			requestIDs = append(requestIDs, file.Status.RequestID)
+		}
+		require.Contains(t, requestIDs, requestID)
+	})
+}
@@ -51,6 +51,52 @@ var (
 		shorthand: "r",
 		usage:     "request ID",
 	}
+	stepsFlag = commandLineFlag{
+		name:  "steps",
+		usage: "comma-separated names of the steps to run; the other steps are not executed",
+	}
+	fromStepFlag = commandLineFlag{
+		name:  "from-step",
+		usage: "name of the step to run from; the step and all the steps downstream of it are executed",
+	}
+	upstreamFlag = commandLineFlag{
+		name:  "upstream",
+		usage: "name of the upstream DAG whose run triggered this run",
+	}
+	upstreamRequestIDFlag = commandLineFlag{
+		name:  "upstream-req",
+		usage: "request ID of the upstream run that triggered this run",
+	}
+	logicalDateFlag = commandLineFlag{
+		name:  "logical-date",
+		usage: "scheduled time the run is for (RFC3339); available to the steps as DAG_LOGICAL_DATE",
+	}
+	fromFlag = commandLineFlag{
+		name:  "from",
+		usage: "start of the time range (RFC3339, 2006-01-02T15:04 or 2006-01-02)",
+	}
+	toFlag = commandLineFlag{
+		name:  "to",
+		usage: "end of the time range (RFC3339, 2006-01-02T15:04 or 2006-01-02)",
+	}
+	stepFlag = commandLineFlag{
+		name:  "step",
+		usage: "name of the step waiting for approval",
+	}
+	approverFlag = commandLineFlag{
+		name:  "approver",
+		usage: "name of the approver (default is the current user)",
+	}
+	commentFlag = commandLineFlag{
+		name:  "comment",
+		usage: "comment recorded with the decision",
+	}
+	countFlag = commandLineFlag{
+		name:         "count",
+		shorthand:    "n",
+		defaultValue: "10",
+		usage:        "number of upcoming runs to show",
+	}
 )
 
 func withRequired(flag commandLineFlag) commandLineFlag {
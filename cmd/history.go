@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/spf13/cobra"
+)
+
+// runStatuses are the statuses of the runs selectable with --status.
+var runStatuses = []scheduler.Status{
+	scheduler.StatusNone,
+	scheduler.StatusRunning,
+	scheduler.StatusError,
+	scheduler.StatusCancel,
+	scheduler.StatusSuccess,
+	scheduler.StatusPaused,
+}
+
+func historyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history [--since=<duration>] [--status=<status>] [--name=<name>] [--tag=<tag>]",
+		Short: "Query the history of the runs of all the DAGs",
+		Long: `dagu history --since=24h --status=failed
+dagu history --from=2025-01-06 --to=2025-01-13 --tag=etl -o json
+
+Prints the runs of all the DAGs matching the filters, the latest first.`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runHistory),
+	}
+
+	initCommonFlags(cmd, []commandLineFlag{
+		withUsage(fromFlag, "start of the range of the start times (RFC3339, 2006-01-02T15:04 or 2006-01-02)"),
+		withUsage(toFlag, "end of the range of the start times, exclusive (RFC3339, 2006-01-02T15:04 or 2006-01-02)"),
+		withUsage(paramsFlag, "substring of the parameters of the runs"),
+	})
+	cmd.Flags().Duration("since", 0, "select the runs started within the duration, e.g. 24h")
+	cmd.Flags().StringSlice("status", nil, "comma-separated statuses of the runs ("+statusNames()+")")
+	cmd.Flags().String("name", "", "name of the DAG of the runs")
+	cmd.Flags().String("tag", "", "tag of the DAGs of the runs")
+	cmd.Flags().Int("page", 1, "page of the runs")
+	cmd.Flags().Int("limit", 20, "number of the runs per page")
+	cmd.Flags().StringP("output", "o", "table", "output format (table or json)")
+
+	return cmd
+}
+
+func runHistory(cmd *cobra.Command, _ []string) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	query, err := getHistoryQuery(cmd)
+	if err != nil {
+		return err
+	}
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("failed to get output flag: %w", err)
+	}
+	if output != "table" && output != "json" {
+		return fmt.Errorf("invalid --output %q: must be table or json", output)
+	}
+
+	cli, err := setup.client()
+	if err != nil {
+		logger.Error(ctx, "failed to initialize client", "err", err)
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	result, err := cli.QueryHistory(ctx, query)
+	if err != nil {
+		logger.Error(ctx, "Failed to query the history", "err", err)
+		return fmt.Errorf("failed to query the history: %w", err)
+	}
+
+	pageCount := (result.Total-1)/query.Limit + 1
+	if output == "json" {
+		return printHistoryJSON(cmd.OutOrStdout(), result, query.Page, pageCount)
+	}
+	return printHistoryTable(cmd.OutOrStdout(), result, query.Page, pageCount)
+}
+
+// getHistoryQuery reads the filters of the runs from the flags.
+func getHistoryQuery(cmd *cobra.Command) (client.HistoryQuery, error) {
+	var query client.HistoryQuery
+
+	from, err := getTimeFlag(cmd, "from")
+	if err != nil {
+		return query, err
+	}
+	to, err := getTimeFlag(cmd, "to")
+	if err != nil {
+		return query, err
+	}
+	since, err := cmd.Flags().GetDuration("since")
+	if err != nil {
+		return query, fmt.Errorf("failed to get since flag: %w", err)
+	}
+	if since < 0 {
+		return query, fmt.Errorf("--since must not be negative")
+	}
+	if since > 0 {
+		if !from.IsZero() {
+			return query, fmt.Errorf("--since and --from cannot be used together")
+		}
+		from = time.Now().Add(-since)
+	}
+	if !from.IsZero() && !to.IsZero() && !to.After(from) {
+		return query, fmt.Errorf("--to must be after --from")
+	}
+
+	statuses, err := cmd.Flags().GetStringSlice("status")
+	if err != nil {
+		return query, fmt.Errorf("failed to get status flag: %w", err)
+	}
+	for _, name := range statuses {
+		status, ok := parseStatus(name)
+		if !ok {
+			return query, fmt.Errorf("invalid --status %q: must be one of %s", name, statusNames())
+		}
+		query.Statuses = append(query.Statuses, status)
+	}
+
+	page, err := cmd.Flags().GetInt("page")
+	if err != nil {
+		return query, fmt.Errorf("failed to get page flag: %w", err)
+	}
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return query, fmt.Errorf("failed to get limit flag: %w", err)
+	}
+	if page < 1 || limit < 1 {
+		return query, fmt.Errorf("--page and --limit must be positive")
+	}
+
+	query.From, query.To = from, to
+	query.Page, query.Limit = page, limit
+	if query.Name, err = cmd.Flags().GetString("name"); err != nil {
+		return query, fmt.Errorf("failed to get name flag: %w", err)
+	}
+	if query.Tag, err = cmd.Flags().GetString("tag"); err != nil {
+		return query, fmt.Errorf("failed to get tag flag: %w", err)
+	}
+	params, err := cmd.Flags().GetString("params")
+	if err != nil {
+		return query, fmt.Errorf("failed to get params flag: %w", err)
+	}
+	query.Params = removeQuotes(params)
+	return query, nil
+}
+
+// parseStatus returns the status of the run with the name.
+func parseStatus(name string) (scheduler.Status, bool) {
+	for _, status := range runStatuses {
+		if strings.EqualFold(strings.TrimSpace(name), status.String()) {
+			return status, true
+		}
+	}
+	return scheduler.StatusNone, false
+}
+
+func statusNames() string {
+	names := make([]string, len(runStatuses))
+	for i, status := range runStatuses {
+		names[i] = status.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+func printHistoryTable(w io.Writer, result *persistence.HistoryQueryResult, page, pageCount int) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tREQUEST ID\tSTATUS\tSTARTED AT\tFINISHED AT\tPARAMS")
+	for _, run := range result.Runs {
+		s := run.Status
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.Name, s.RequestID, s.StatusText, s.StartedAt, s.FinishedAt, s.Params)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "Page %d of %d (%d runs)\n", page, pageCount, result.Total)
+	return err
+}
+
+func printHistoryJSON(w io.Writer, result *persistence.HistoryQueryResult, page, pageCount int) error {
+	out := struct {
+		Runs      []model.Status `json:"runs"`
+		Total     int            `json:"total"`
+		Page      int            `json:"page"`
+		PageCount int            `json:"pageCount"`
+	}{
+		Runs:      []model.Status{},
+		Total:     result.Total,
+		Page:      page,
+		PageCount: pageCount,
+	}
+	for _, run := range result.Runs {
+		out.Runs = append(out.Runs, run.Status)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
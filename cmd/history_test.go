@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryCommand(t *testing.T) {
+	th := testSetup(t)
+
+	dagFile := th.DAG(t, "cmd/start.yaml")
+	th.RunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile.Location}})
+
+	// runHistory runs the history command and returns the output.
+	runHistory := func(t *testing.T, args ...string) string {
+		t.Helper()
+
+		var out bytes.Buffer
+		cmdRoot := &cobra.Command{Use: "root"}
+		cmdRoot.AddCommand(historyCmd())
+		cmdRoot.SetOut(&out)
+		cmdRoot.SetArgs(append([]string{"history"}, args...))
+		require.NoError(t, cmdRoot.ExecuteContext(th.Context))
+		return out.String()
+	}
+
+	t.Run("Table", func(t *testing.T) {
+		out := runHistory(t, "--since=1h", "--status=finished")
+		require.Contains(t, out, "REQUEST ID")
+		require.Contains(t, out, "finished")
+		require.Contains(t, out, "Page 1 of 1 (1 runs)")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var result struct {
+			Runs  []json.RawMessage `json:"runs"`
+			Total int               `json:"total"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(runHistory(t, "-o", "json")), &result))
+		require.Equal(t, 1, result.Total)
+		require.Len(t, result.Runs, 1)
+
+		require.NoError(t, json.Unmarshal([]byte(runHistory(t, "-o", "json", "--status=failed")), &result))
+		require.Zero(t, result.Total)
+	})
+}
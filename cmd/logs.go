@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+func logsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs [-f] /path/to/spec.yaml [step-name]",
+		Short: "Print the logs of the steps of the running DAG",
+		Long: `dagu logs [-f] /path/to/spec.yaml [step-name]
+
+Prints the log of the step, or the logs of all the steps prefixed with the
+step names. With -f, the output of the steps is printed as it is written
+until the steps finish.`,
+		Args: cobra.RangeArgs(1, 2),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runLogs),
+	}
+
+	initCommonFlags(cmd, nil)
+	cmd.Flags().BoolP("follow", "f", false, "follow the output of the steps")
+
+	return cmd
+}
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), true)
+
+	follow, err := cmd.Flags().GetBool("follow")
+	if err != nil {
+		return fmt.Errorf("failed to get follow flag: %w", err)
+	}
+
+	var stepName string
+	if len(args) > 1 {
+		stepName = args[1]
+	}
+
+	dag, err := digraph.Load(cmd.Context(), args[0], digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig))
+	if err != nil {
+		logger.Error(ctx, "Failed to load DAG", "err", err)
+		return fmt.Errorf("failed to load DAG from %s: %w", args[0], err)
+	}
+
+	cli, err := setup.client()
+	if err != nil {
+		logger.Error(ctx, "failed to initialize client", "err", err)
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	stream, err := cli.StreamLog(cmd.Context(), dag, stepName, follow)
+	if err != nil {
+		if errors.Is(err, client.ErrDAGNotRunning) {
+			return fmt.Errorf("the DAG %s is not running", dag.Name)
+		}
+		logger.Error(ctx, "Failed to stream the logs", "dag", dag.Name, "step", stepName, "err", err)
+		return fmt.Errorf("failed to stream the logs: %w", err)
+	}
+	defer stream.Close()
+
+	if _, err := io.Copy(cmd.OutOrStdout(), stream); err != nil && cmd.Context().Err() == nil {
+		return fmt.Errorf("failed to read the logs: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogsCommand(t *testing.T) {
+	t.Run("FollowStep", func(t *testing.T) {
+		th := testSetup(t)
+
+		dagFile := th.DAG(t, "cmd/logs.yaml")
+
+		done := make(chan struct{})
+		go func() {
+			args := []string{"start", dagFile.Location}
+			th.RunCommand(t, startCmd(), cmdTest{args: args})
+			close(done)
+		}()
+
+		// Wait for the DAG running.
+		dagFile.AssertLatestStatus(t, scheduler.StatusRunning)
+
+		// Follow the log of the step until it finishes.
+		var out bytes.Buffer
+		cmdRoot := &cobra.Command{Use: "root"}
+		cmdRoot.AddCommand(logsCmd())
+		cmdRoot.SetOut(&out)
+		cmdRoot.SetArgs([]string{"logs", "-f", dagFile.Location, "print"})
+		require.NoError(t, cmdRoot.ExecuteContext(th.Context))
+		require.Equal(t, "first\nsecond\n", out.String())
+
+		<-done
+		dagFile.AssertLatestStatus(t, scheduler.StatusSuccess)
+	})
+}
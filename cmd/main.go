@@ -40,4 +40,13 @@ func registerCommands() {
 	rootCmd.AddCommand(schedulerCmd())
 	rootCmd.AddCommand(retryCmd())
 	rootCmd.AddCommand(startAllCmd())
+	rootCmd.AddCommand(approveCmd())
+	rootCmd.AddCommand(rejectCmd())
+	rootCmd.AddCommand(logsCmd())
+	rootCmd.AddCommand(pauseCmd())
+	rootCmd.AddCommand(resumeCmd())
+	rootCmd.AddCommand(backfillCmd())
+	rootCmd.AddCommand(scheduleCmd())
+	rootCmd.AddCommand(historyCmd())
+	rootCmd.AddCommand(migrateCmd())
 }
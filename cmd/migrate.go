@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/dagu-org/dagu/internal/config"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence/jsondb"
+	"github.com/dagu-org/dagu/internal/persistence/objstore"
+	"github.com/dagu-org/dagu/internal/persistence/sqlitedb"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/spf13/cobra"
+)
+
+func migrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the data to another storage",
+		Long:  `dagu migrate history [/path/to/spec.yaml ...]`,
+	}
+
+	cmd.AddCommand(migrateHistoryCmd())
+
+	return cmd
+}
+
+func migrateHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history [/path/to/spec.yaml ...]",
+		Short: "Import the JSON history into the SQLite history database",
+		Long: `dagu migrate history [/path/to/spec.yaml ...]
+
+Imports the history of the DAGs stored in the JSON files under the data
+directory into the SQLite database of the sqlite history backend. The
+history of all the DAGs in the DAGs directory is imported unless DAG files
+are given. The runs already in the database are skipped, so the import can
+be repeated.`,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runMigrateHistory),
+	}
+
+	initCommonFlags(cmd, nil)
+
+	return cmd
+}
+
+func runMigrateHistory(cmd *cobra.Command, args []string) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	var dags []*digraph.DAG
+	if len(args) > 0 {
+		for _, arg := range args {
+			dag, err := digraph.Load(cmd.Context(), arg, digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig))
+			if err != nil {
+				logger.Error(ctx, "Failed to load DAG", "err", err)
+				return fmt.Errorf("failed to load DAG from %s: %w", arg, err)
+			}
+			dags = append(dags, dag)
+		}
+	} else {
+		dagStore, err := setup.dagStore()
+		if err != nil {
+			return fmt.Errorf("failed to initialize DAG store: %w", err)
+		}
+		var errs []string
+		dags, errs, err = dagStore.List(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list DAGs: %w", err)
+		}
+		for _, e := range errs {
+			logger.Warn(ctx, "Failed to load DAG", "err", e)
+		}
+	}
+
+	db := sqlitedb.NewDB(setup.cfg.Paths.HistoryDB)
+	defer db.Close()
+
+	files := setup.remoteFiles()
+	src := jsondb.New(setup.cfg.Paths.DataDir, jsondb.WithFiles(files))
+	dst := sqlitedb.New(db)
+
+	var imported, skipped int
+	for _, dag := range dags {
+		n, m, err := migrateHistory(ctx, src, dst, files, dag.Location)
+		if err != nil {
+			logger.Error(ctx, "Failed to migrate the history", "dag", dag.Name, "err", err)
+			return fmt.Errorf("failed to migrate the history of %s: %w", dag.Name, err)
+		}
+		imported += n
+		skipped += m
+	}
+
+	logger.Info(ctx, "History migrated", "db", setup.cfg.Paths.HistoryDB, "imported", imported, "skipped", skipped)
+	if setup.cfg.HistoryBackend != config.HistoryBackendSQLite {
+		logger.Info(ctx, "Set historyBackend to sqlite to use the migrated history")
+	}
+	return nil
+}
+
+// migrateHistory imports the runs of the DAG from the JSON files into the
+// database. It returns the number of the runs imported and skipped.
+func migrateHistory(ctx context.Context, src *jsondb.JSONDB, dst *sqlitedb.SQLiteDB, files *objstore.Files, key string) (int, int, error) {
+	statuses := src.ReadStatusRecent(ctx, key, math.MaxInt)
+	// The oldest run is imported first so that the runs are in order.
+	slices.Reverse(statuses)
+
+	var imported, skipped int
+	for _, file := range statuses {
+		modTime, err := files.ModTime(ctx, file.File)
+		if err != nil {
+			return imported, skipped, err
+		}
+		startedAt, _ := stringutil.ParseTime(file.Status.StartedAt)
+		if startedAt.IsZero() {
+			// The run has not started, e.g. it was queued.
+			startedAt = modTime
+		}
+		ok, err := dst.Import(ctx, key, startedAt, modTime, file.Status)
+		if err != nil {
+			return imported, skipped, err
+		}
+		if ok {
+			imported++
+		} else {
+			skipped++
+		}
+	}
+	return imported, skipped, nil
+}
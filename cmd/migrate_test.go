@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence/sqlitedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateHistoryCommand(t *testing.T) {
+	t.Run("MigrateHistory", func(t *testing.T) {
+		th := testSetup(t)
+
+		dagFile := th.DAG(t, "cmd/start.yaml")
+		th.RunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile.Location}})
+
+		recent := th.HistoryStore.ReadStatusRecent(th.Context, dagFile.Location, 1)
+		require.Len(t, recent, 1)
+
+		th.RunCommand(t, migrateCmd(), cmdTest{
+			args:        []string{"migrate", "history", dagFile.Location},
+			expectedOut: []string{"History migrated", "imported=1"},
+		})
+
+		db := sqlitedb.NewDB(th.Config.Paths.HistoryDB)
+		defer db.Close()
+
+		statusFile, err := sqlitedb.New(db).FindByRequestID(th.Context, dagFile.Location, recent[0].Status.RequestID)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusSuccess, statusFile.Status.Status)
+
+		// The runs already imported are skipped.
+		th.RunCommand(t, migrateCmd(), cmdTest{
+			args:        []string{"migrate", "history", dagFile.Location},
+			expectedOut: []string{"skipped=1"},
+		})
+	})
+
+	t.Run("StartWithSQLiteBackend", func(t *testing.T) {
+		th := testSetup(t)
+		t.Setenv("DAGU_HISTORY_BACKEND", "sqlite")
+
+		dagFile := th.DAG(t, "cmd/start.yaml")
+		th.RunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile.Location}})
+
+		// The run is recorded in the database only.
+		require.Empty(t, th.HistoryStore.ReadStatusRecent(th.Context, dagFile.Location, 1))
+
+		db := sqlitedb.NewDB(th.Config.Paths.HistoryDB)
+		defer db.Close()
+
+		recent := sqlitedb.New(db).ReadStatusRecent(th.Context, dagFile.Location, 1)
+		require.Len(t, recent, 1)
+		require.Equal(t, scheduler.StatusSuccess, recent[0].Status.Status)
+	})
+}
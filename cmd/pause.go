@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+func pauseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pause [--suspend-steps] /path/to/spec.yaml",
+		Short: "Pause the running DAG",
+		Long: `dagu pause [--suspend-steps] /path/to/spec.yaml
+
+Stops starting new steps until the DAG is resumed. The running steps run to
+completion, or are suspended in place with --suspend-steps.`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(func(cmd *cobra.Command, args []string) error {
+			return runPauseResume(cmd, args, true)
+		}),
+	}
+
+	initCommonFlags(cmd, nil)
+	cmd.Flags().Bool("suspend-steps", false, "suspend the running steps in place")
+
+	return cmd
+}
+
+func resumeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume /path/to/spec.yaml",
+		Short: "Resume the paused DAG",
+		Long:  `dagu resume /path/to/spec.yaml`,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(func(cmd *cobra.Command, args []string) error {
+			return runPauseResume(cmd, args, false)
+		}),
+	}
+
+	initCommonFlags(cmd, nil)
+
+	return cmd
+}
+
+func runPauseResume(cmd *cobra.Command, args []string, pause bool) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	dag, err := digraph.Load(cmd.Context(), args[0], digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig))
+	if err != nil {
+		logger.Error(ctx, "Failed to load DAG", "err", err)
+		return fmt.Errorf("failed to load DAG from %s: %w", args[0], err)
+	}
+
+	cli, err := setup.client()
+	if err != nil {
+		logger.Error(ctx, "failed to initialize client", "err", err)
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	if pause {
+		suspendSteps, err := cmd.Flags().GetBool("suspend-steps")
+		if err != nil {
+			return fmt.Errorf("failed to get suspend-steps flag: %w", err)
+		}
+		err = cli.Pause(cmd.Context(), dag, suspendSteps)
+	} else {
+		err = cli.Resume(cmd.Context(), dag)
+	}
+	if err != nil {
+		if errors.Is(err, client.ErrDAGNotRunning) {
+			return fmt.Errorf("the DAG %s is not running", dag.Name)
+		}
+		logger.Error(ctx, "Failed to change the run", "dag", dag.Name, "pause", pause, "err", err)
+		return fmt.Errorf("failed to change the run: %w", err)
+	}
+
+	if pause {
+		logger.Info(ctx, "DAG paused", "dag", dag.Name)
+	} else {
+		logger.Info(ctx, "DAG resumed", "dag", dag.Name)
+	}
+	return nil
+}
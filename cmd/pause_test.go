@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+)
+
+func TestPauseCommand(t *testing.T) {
+	t.Run("PauseAndResume", func(t *testing.T) {
+		th := testSetup(t)
+
+		dagFile := th.DAG(t, "cmd/pause.yaml")
+
+		done := make(chan struct{})
+		go func() {
+			args := []string{"start", dagFile.Location}
+			th.RunCommand(t, startCmd(), cmdTest{args: args})
+			close(done)
+		}()
+
+		// Wait for the DAG running.
+		dagFile.AssertLatestStatus(t, scheduler.StatusRunning)
+
+		// Pause the DAG.
+		th.RunCommand(t, pauseCmd(), cmdTest{
+			args:        []string{"pause", dagFile.Location},
+			expectedOut: []string{"DAG paused"}})
+		dagFile.AssertLatestStatus(t, scheduler.StatusPaused)
+
+		// The second step does not start after the first step finished.
+		time.Sleep(time.Millisecond * 1500)
+		dagFile.AssertLatestStatus(t, scheduler.StatusPaused)
+
+		// Resume the DAG.
+		th.RunCommand(t, resumeCmd(), cmdTest{
+			args:        []string{"resume", dagFile.Location},
+			expectedOut: []string{"DAG resumed"}})
+
+		// Check the DAG is finished.
+		dagFile.AssertLatestStatus(t, scheduler.StatusSuccess)
+		<-done
+	})
+}
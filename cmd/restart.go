@@ -10,7 +10,6 @@ import (
 	"github.com/dagu-org/dagu/internal/agent"
 	"github.com/dagu-org/dagu/internal/client"
 	"github.com/dagu-org/dagu/internal/digraph"
-	"github.com/dagu-org/dagu/internal/digraph/scheduler"
 	"github.com/dagu-org/dagu/internal/logger"
 	"github.com/dagu-org/dagu/internal/persistence/model"
 	"github.com/spf13/cobra"
@@ -82,12 +81,7 @@ func handleRestartProcess(ctx context.Context, setup *setup, dag *digraph.DAG, q
 
 	loadOpts := []digraph.LoadOption{
 		digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig),
-	}
-	if status.Params != "" {
-		// backward compatibility
-		loadOpts = append(loadOpts, digraph.WithParams(status.Params))
-	} else {
-		loadOpts = append(loadOpts, digraph.WithParams(status.ParamsList))
+		paramsOf(status),
 	}
 
 	// Reload DAG with parameters
@@ -112,7 +106,7 @@ func executeDAG(ctx context.Context, cli client.Client, setup *setup,
 	if err != nil {
 		return fmt.Errorf("failed to initialize log file: %w", err)
 	}
-	defer logFile.Close()
+	defer setup.closeLogFile(ctx, logFile)
 
 	ctx = setup.loggerContextWithFile(ctx, quiet, logFile)
 
@@ -132,11 +126,13 @@ func executeDAG(ctx context.Context, cli client.Client, setup *setup,
 		cli,
 		dagStore,
 		setup.historyStore(),
-		agent.Options{Dry: false})
+		agent.Options{Pools: setup.pools(), StepCache: setup.stepCache(), WorkspaceDir: setup.workspaceDir(), Files: setup.remoteFiles()})
 
 	listenSignals(ctx, agentInstance)
 	if err := agentInstance.Run(ctx); err != nil {
 		if quiet {
+			// The deferred functions are not run on exit.
+			setup.closeLogFile(ctx, logFile)
 			os.Exit(1)
 		} else {
 			agentInstance.PrintSummary(ctx)
@@ -153,7 +149,7 @@ func stopDAGIfRunning(ctx context.Context, cli client.Client, dag *digraph.DAG)
 		return fmt.Errorf("failed to get current status: %w", err)
 	}
 
-	if status.Status == scheduler.StatusRunning {
+	if status.Status.IsActive() {
 		logger.Infof(ctx, "Stopping: %s", dag.Name)
 		if err := stopRunningDAG(ctx, cli, dag); err != nil {
 			return fmt.Errorf("failed to stop running DAG: %w", err)
@@ -170,7 +166,7 @@ func stopRunningDAG(ctx context.Context, cli client.Client, dag *digraph.DAG) er
 			return fmt.Errorf("failed to get current status: %w", err)
 		}
 
-		if status.Status != scheduler.StatusRunning {
+		if !status.Status.IsActive() {
 			return nil
 		}
 
@@ -31,8 +31,9 @@ func retryCmd() *cobra.Command {
 }
 
 func initRetryFlags(cmd *cobra.Command) {
-	initCommonFlags(cmd, []commandLineFlag{withRequired(requestIDFlag)})
+	initCommonFlags(cmd, []commandLineFlag{withRequired(requestIDFlag), stepsFlag, fromStepFlag})
 	cmd.Flags().BoolP("quiet", "q", false, "suppress output")
+	cmd.Flags().Bool("downstream", false, "re-run the steps downstream of --steps as well")
 }
 
 func runRetry(cmd *cobra.Command, args []string) error {
@@ -52,6 +53,11 @@ func runRetry(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get request ID: %w", err)
 	}
 
+	partial, err := getPartialRun(cmd)
+	if err != nil {
+		return err
+	}
+
 	ctx := setup.loggerContext(cmd.Context(), quiet)
 
 	specFilePath := args[0]
@@ -70,13 +76,7 @@ func runRetry(cmd *cobra.Command, args []string) error {
 
 	loadOpts := []digraph.LoadOption{
 		digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig),
-	}
-
-	if status.Status.Params != "" {
-		// backward compatibility
-		loadOpts = append(loadOpts, digraph.WithParams(status.Status.Params))
-	} else {
-		loadOpts = append(loadOpts, digraph.WithParams(status.Status.ParamsList))
+		paramsOf(status.Status),
 	}
 
 	dag, err := digraph.Load(ctx, absolutePath, loadOpts...)
@@ -88,7 +88,7 @@ func runRetry(cmd *cobra.Command, args []string) error {
 	}
 
 	// Execute DAG retry
-	if err := executeRetry(ctx, dag, setup, status, quiet); err != nil {
+	if err := executeRetry(ctx, dag, setup, status, quiet, partial); err != nil {
 		logger.Error(ctx, "Failed to execute retry", "path", specFilePath, "err", err)
 		return fmt.Errorf("failed to execute retry: %w", err)
 	}
@@ -96,7 +96,9 @@ func runRetry(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func executeRetry(ctx context.Context, dag *digraph.DAG, setup *setup, originalStatus *model.StatusFile, quiet bool) error {
+func executeRetry(
+	ctx context.Context, dag *digraph.DAG, setup *setup, originalStatus *model.StatusFile, quiet bool, partial partialRun,
+) error {
 	newRequestID, err := generateRequestID()
 	if err != nil {
 		return fmt.Errorf("failed to generate new request ID: %w", err)
@@ -107,7 +109,7 @@ func executeRetry(ctx context.Context, dag *digraph.DAG, setup *setup, originalS
 	if err != nil {
 		return fmt.Errorf("failed to initialize log file for DAG %s: %w", dag.Name, err)
 	}
-	defer logFile.Close()
+	defer setup.closeLogFile(ctx, logFile)
 
 	logger.Info(ctx, "DAG retry initiated", "DAG", dag.Name, "originalRequestID", originalStatus.Status.RequestID, "newRequestID", newRequestID, "logFile", logFile.Name())
 
@@ -133,13 +135,23 @@ func executeRetry(ctx context.Context, dag *digraph.DAG, setup *setup, originalS
 		cli,
 		dagStore,
 		setup.historyStore(),
-		agent.Options{RetryTarget: &originalStatus.Status},
+		agent.Options{
+			RetryTarget:  &originalStatus.Status,
+			Steps:        partial.steps,
+			Downstream:   partial.downstream,
+			Pools:        setup.pools(),
+			StepCache:    setup.stepCache(),
+			WorkspaceDir: setup.workspaceDir(),
+			Files:        setup.remoteFiles(),
+		},
 	)
 
 	listenSignals(ctx, agentInstance)
 
 	if err := agentInstance.Run(ctx); err != nil {
 		if quiet {
+			// The deferred functions are not run on exit.
+			setup.closeLogFile(ctx, logFile)
 			os.Exit(1)
 		} else {
 			agentInstance.PrintSummary(ctx)
@@ -36,4 +36,37 @@ func TestRetryCommand(t *testing.T) {
 			expectedOut: []string{`params=[foo]`},
 		})
 	})
+	t.Run("RetryFromStep", func(t *testing.T) {
+		th := testSetup(t)
+
+		dagFile := th.DAG(t, "cmd/retry_partial.yaml")
+
+		// Run a DAG.
+		th.RunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile.Location}})
+
+		cli := th.Client
+		ctx := context.Background()
+		original, err := cli.GetLatestStatus(ctx, dagFile.DAG)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusSuccess, original.Status)
+
+		// Re-run from step 2.
+		args := []string{"retry", fmt.Sprintf("--req=%s", original.RequestID), "--from-step=2", dagFile.Location}
+		th.RunCommand(t, retryCmd(), cmdTest{args: args})
+
+		status, err := cli.GetLatestStatus(ctx, dagFile.DAG)
+		require.NoError(t, err)
+		require.NotEqual(t, original.RequestID, status.RequestID)
+		require.Equal(t, scheduler.StatusSuccess, status.Status)
+
+		// Only step 2 is executed; steps 1 and 3 are reused.
+		require.Equal(t, original.Nodes[0].Log, status.Nodes[0].Log)
+		require.NotEqual(t, original.Nodes[1].Log, status.Nodes[1].Log)
+		require.Equal(t, original.Nodes[2].Log, status.Nodes[2].Log)
+
+		// Step 2 uses the output of step 1 from the original run.
+		output, ok := status.Nodes[1].Step.OutputVariables.Load("RESULT")
+		require.True(t, ok)
+		require.Equal(t, "RESULT=first", output)
+	})
 }
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+
+	"github.com/dagu-org/dagu/internal/agent"
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+)
+
+var _ client.Runner = (*inProcessRunner)(nil)
+
+// inProcessRunner runs the DAGs as goroutines of the current process instead
+// of starting a dagu process for each run. Each run has its own context,
+// which outlives the request that started it, and logs only to its log file.
+// The runs are interrupted when the process receives a termination signal.
+//
+// The params and the env of a run are passed to its steps through its
+// context and are not set to the environment variables of the process, so
+// the runs do not wait for each other.
+type inProcessRunner struct {
+	setup *setup
+	wg    sync.WaitGroup
+}
+
+func newInProcessRunner(setup *setup) *inProcessRunner {
+	return &inProcessRunner{setup: setup}
+}
+
+func (r *inProcessRunner) Start(ctx context.Context, dag *digraph.DAG, opts client.StartOptions) error {
+	ctx = context.WithoutCancel(ctx)
+
+	loadOpts := []digraph.LoadOption{
+		digraph.WithBaseConfig(r.setup.cfg.Paths.BaseConfig),
+		digraph.WithParams(opts.Params),
+	}
+	dag, err := digraph.Load(ctx, dag.Location, loadOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to load DAG: %w", err)
+	}
+
+	requestID := opts.RequestID
+	if requestID == "" {
+		requestID, err = generateRequestID()
+		if err != nil {
+			return fmt.Errorf("failed to generate request ID: %w", err)
+		}
+	}
+
+	agentOpts := agent.Options{
+		Steps:       opts.Steps,
+		Downstream:  opts.Downstream,
+		Upstream:    opts.Upstream,
+		LogicalDate: opts.LogicalDate,
+	}
+	if len(opts.Steps) > 0 {
+		// Reuse the results of the latest run for the steps not selected.
+		if recent := r.setup.historyStore().ReadStatusRecent(ctx, dag.Location, 1); len(recent) > 0 {
+			agentOpts.RetryTarget = &recent[0].Status
+		}
+	}
+	return r.run(ctx, "start_", dag, requestID, agentOpts)
+}
+
+func (r *inProcessRunner) Restart(ctx context.Context, dag *digraph.DAG, _ client.RestartOptions) error {
+	ctx = context.WithoutCancel(ctx)
+	cli, err := r.setup.client()
+	if err != nil {
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+	if err := stopDAGIfRunning(ctx, cli, dag); err != nil {
+		return fmt.Errorf("failed to stop DAG: %w", err)
+	}
+	waitForRestart(ctx, dag.RestartWait)
+
+	status, err := getPreviousExecutionStatus(ctx, cli, dag)
+	if err != nil {
+		return fmt.Errorf("failed to get previous execution parameters: %w", err)
+	}
+	dag, err = digraph.Load(ctx, dag.Location, digraph.WithBaseConfig(r.setup.cfg.Paths.BaseConfig), paramsOf(status))
+	if err != nil {
+		return fmt.Errorf("failed to reload DAG with params: %w", err)
+	}
+
+	requestID, err := generateRequestID()
+	if err != nil {
+		return fmt.Errorf("failed to generate request ID: %w", err)
+	}
+	return r.run(ctx, "restart_", dag, requestID, agent.Options{})
+}
+
+func (r *inProcessRunner) Retry(ctx context.Context, dag *digraph.DAG, requestID string, opts client.RetryOptions) error {
+	ctx = context.WithoutCancel(ctx)
+
+	original, err := r.setup.historyStore().FindByRequestID(ctx, dag.Location, requestID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve historical execution for request ID %s: %w", requestID, err)
+	}
+	dag, err = digraph.Load(ctx, dag.Location, digraph.WithBaseConfig(r.setup.cfg.Paths.BaseConfig), paramsOf(original.Status))
+	if err != nil {
+		return fmt.Errorf("failed to load DAG: %w", err)
+	}
+
+	newRequestID, err := generateRequestID()
+	if err != nil {
+		return fmt.Errorf("failed to generate new request ID: %w", err)
+	}
+	return r.run(ctx, "retry_", dag, newRequestID, agent.Options{
+		RetryTarget: &original.Status,
+		Steps:       opts.Steps,
+		Downstream:  opts.Downstream,
+	})
+}
+
+// run runs the DAG with an agent and waits until it finishes. The context
+// is not cancelled with the request that started the run.
+func (r *inProcessRunner) run(
+	ctx context.Context, logPrefix string, dag *digraph.DAG, requestID string, opts agent.Options,
+) error {
+	r.wg.Add(1)
+	defer r.wg.Done()
+
+	logFile, err := r.setup.openLogFile(ctx, logPrefix, dag, requestID)
+	if err != nil {
+		return fmt.Errorf("failed to initialize log file for DAG %s: %w", dag.Name, err)
+	}
+	defer r.setup.closeLogFile(ctx, logFile)
+
+	ctx = r.setup.loggerContextWithFile(ctx, true, logFile)
+
+	logger.Info(ctx, "DAG execution initiated", "DAG", dag.Name, "requestID", requestID, "logFile", logFile.Name())
+
+	dagStore, err := r.setup.dagStore()
+	if err != nil {
+		return fmt.Errorf("failed to initialize DAG store: %w", err)
+	}
+	cli, err := r.setup.client()
+	if err != nil {
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	opts.Pools = r.setup.pools()
+	opts.StepCache = r.setup.stepCache()
+	opts.WorkspaceDir = r.setup.workspaceDir()
+	opts.Files = r.setup.remoteFiles()
+	agentInstance := agent.New(
+		requestID,
+		dag,
+		filepath.Dir(logFile.Name()),
+		logFile.Name(),
+		cli,
+		dagStore,
+		r.setup.historyStore(),
+		opts,
+	)
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sig)
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case s := <-sig:
+			agentInstance.Signal(ctx, s)
+		case <-finished:
+		}
+	}()
+
+	if err := agentInstance.Run(ctx); err != nil {
+		logger.Error(ctx, "Failed to execute DAG", "DAG", dag.Name, "requestID", requestID, "err", err)
+		return fmt.Errorf("failed to execute DAG %s (requestID: %s): %w", dag.Name, requestID, err)
+	}
+	return nil
+}
+
+// wait waits until the runs finish.
+func (r *inProcessRunner) wait() {
+	r.wg.Wait()
+}
+
+// paramsOf returns the load option of the parameters of the previous run.
+func paramsOf(status model.Status) digraph.LoadOption {
+	if status.Params != "" {
+		// backward compatibility
+		return digraph.WithParams(status.Params)
+	}
+	return digraph.WithParams(status.ParamsList)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/config"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInProcessRunner(t *testing.T) {
+	th := testSetup(t)
+	th.Config.ExecutionMode = config.ExecutionModeInProcess
+
+	setup := setupWithConfig(th.Config)
+	require.NotNil(t, setup.runner)
+	cli, err := setup.client()
+	require.NoError(t, err)
+
+	dagFile := th.DAG(t, "cmd/retry.yaml")
+
+	t.Run("Start", func(t *testing.T) {
+		// The run is not cancelled with the context that started it.
+		ctx, cancel := context.WithCancel(th.Context)
+		cancel()
+
+		require.NoError(t, cli.Start(ctx, dagFile.DAG, client.StartOptions{Params: "foo", RequestID: "in-process"}))
+
+		status, err := cli.GetLatestStatus(th.Context, dagFile.DAG)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusSuccess, status.Status)
+		require.Equal(t, "in-process", status.RequestID)
+		require.Equal(t, "foo", status.Params)
+	})
+	t.Run("Retry", func(t *testing.T) {
+		require.NoError(t, cli.Retry(th.Context, dagFile.DAG, "in-process", client.RetryOptions{}))
+
+		status, err := cli.GetLatestStatus(th.Context, dagFile.DAG)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusSuccess, status.Status)
+		require.NotEqual(t, "in-process", status.RequestID)
+		require.Equal(t, "foo", status.Params)
+	})
+	t.Run("Restart", func(t *testing.T) {
+		require.NoError(t, cli.Restart(th.Context, dagFile.DAG, client.RestartOptions{}))
+
+		status, err := cli.GetLatestStatus(th.Context, dagFile.DAG)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusSuccess, status.Status)
+		require.Equal(t, "foo", status.Params)
+	})
+	t.Run("ProcessEnv", func(t *testing.T) {
+		// The params of the run are not set to the environment.
+		t.Setenv("1", "before")
+
+		require.NoError(t, cli.Start(th.Context, dagFile.DAG, client.StartOptions{Params: "bar"}))
+
+		status, err := cli.GetLatestStatus(th.Context, dagFile.DAG)
+		require.NoError(t, err)
+		require.Equal(t, "bar", status.Params)
+		require.Equal(t, "before", os.Getenv("1"))
+	})
+	t.Run("Overlap", func(t *testing.T) {
+		envFile := th.DAG(t, "cmd/in_process_env.yaml")
+
+		done := make(chan error, 1)
+		go func() {
+			done <- cli.Start(th.Context, envFile.DAG, client.StartOptions{Params: "P=overlap"})
+		}()
+		envFile.AssertCurrentStatus(t, scheduler.StatusRunning)
+
+		// Another run starts and finishes while the first one is running.
+		require.NoError(t, cli.Start(th.Context, dagFile.DAG, client.StartOptions{Params: "baz"}))
+		status, err := cli.GetLatestStatus(th.Context, dagFile.DAG)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusSuccess, status.Status)
+		require.Equal(t, "baz", status.Params)
+
+		current, err := cli.GetCurrentStatus(th.Context, envFile.DAG)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusRunning, current.Status)
+
+		require.NoError(t, <-done)
+		envFile.AssertLatestStatus(t, scheduler.StatusSuccess)
+		envFile.AssertOutputs(t, map[string]any{"OUT": "overlap"})
+		_, ok := os.LookupEnv("P")
+		require.False(t, ok)
+	})
+
+	setup.waitForRuns(th.Context)
+}
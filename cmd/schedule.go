@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/scheduler"
+	"github.com/dagu-org/dagu/internal/scheduler/calendar"
+	"github.com/spf13/cobra"
+)
+
+func scheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Inspect the schedule of the DAG",
+		Long:  `dagu schedule next /path/to/spec.yaml`,
+	}
+
+	cmd.AddCommand(scheduleNextCmd())
+	return cmd
+}
+
+func scheduleNextCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "next [--count=<n>] /path/to/spec.yaml",
+		Short: "Shows the upcoming starts, stops and restarts of the DAG",
+		Long:  `dagu schedule next --count=10 /path/to/spec.yaml`,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runScheduleNext),
+	}
+
+	initCommonFlags(cmd, []commandLineFlag{countFlag})
+	return cmd
+}
+
+func runScheduleNext(cmd *cobra.Command, args []string) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	countStr, err := cmd.Flags().GetString("count")
+	if err != nil {
+		return fmt.Errorf("failed to get count: %w", err)
+	}
+	count, err := strconv.Atoi(countStr)
+	if err != nil || count < 1 || count > scheduler.MaxUpcomingRuns {
+		return fmt.Errorf("--count must be a number from 1 to %d", scheduler.MaxUpcomingRuns)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	dag, err := digraph.Load(ctx, args[0], digraph.OnlyMetadata(), digraph.WithoutEval())
+	if err != nil {
+		logger.Error(ctx, "Failed to load DAG", "path", args[0], "err", err)
+		return fmt.Errorf("failed to load DAG from %s: %w", args[0], err)
+	}
+
+	registry, err := calendar.NewRegistry(setup.cfg.Calendars, setup.cfg.Location)
+	if err != nil {
+		return fmt.Errorf("failed to initialize calendars: %w", err)
+	}
+	calendars, err := registry.Resolve(dag.ExcludeCalendars)
+	if err != nil {
+		return fmt.Errorf("failed to resolve calendars of %s: %w", dag.Name, err)
+	}
+
+	cli, err := setup.client()
+	if err != nil {
+		logger.Error(ctx, "failed to initialize client", "err", err)
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	upcoming := scheduler.PreviewRuns(ctx, cli, dag, calendars, time.Now().In(setup.cfg.Location), count)
+	if upcoming.Suspended {
+		logger.Info(ctx, "The DAG is suspended and has no upcoming runs", "DAG", dag.Name)
+		return nil
+	}
+	if len(upcoming.Runs) == 0 {
+		logger.Info(ctx, "The DAG has no upcoming runs", "DAG", dag.Name)
+		return nil
+	}
+
+	for _, run := range upcoming.Runs {
+		if run.Overlaps {
+			logger.Warn(ctx, "Upcoming run", "time", run.Time.Format(time.RFC3339), "type", strings.ToLower(run.Type.String()),
+				"overlaps", true, "duration", upcoming.Duration)
+			continue
+		}
+		logger.Info(ctx, "Upcoming run", "time", run.Time.Format(time.RFC3339), "type", strings.ToLower(run.Type.String()))
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleNextCommand(t *testing.T) {
+	t.Run("Next", func(t *testing.T) {
+		th := testSetup(t)
+
+		dagFile := th.DAG(t, "cmd/schedule_next.yaml")
+		args := []string{"schedule", "next", "--count=3", dagFile.Location}
+		th.RunCommand(t, scheduleCmd(), cmdTest{args: args, expectedOut: []string{
+			"Upcoming run", "type=start", "type=stop", "T01:00:00+09:00",
+		}})
+	})
+	t.Run("Suspended", func(t *testing.T) {
+		th := testSetup(t)
+
+		dagFile := th.DAG(t, "cmd/schedule_next.yaml")
+		require.NoError(t, th.Client.ToggleSuspend(context.Background(), "schedule_next", true))
+
+		args := []string{"schedule", "next", dagFile.Location}
+		th.RunCommand(t, scheduleCmd(), cmdTest{args: args, expectedOut: []string{"The DAG is suspended"}})
+	})
+}
@@ -30,6 +30,7 @@ func runScheduler(cmd *cobra.Command, _ []string) error {
 	}
 
 	ctx := setup.loggerContext(cmd.Context(), false)
+	defer setup.waitForRuns(ctx)
 
 	if dagsDir, _ := cmd.Flags().GetString("dags"); dagsDir != "" {
 		setup.cfg.Paths.DAGsDir = dagsDir
@@ -32,6 +32,7 @@ func runServer(cmd *cobra.Command, _ []string) error {
 	}
 
 	ctx := setup.loggerContext(cmd.Context(), false)
+	defer setup.waitForRuns(ctx)
 
 	logger.Info(ctx, "Server initialization", "host", setup.cfg.Host, "port", setup.cfg.Port)
 
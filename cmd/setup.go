@@ -1,11 +1,13 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"sync"
 	"syscall"
 	"time"
 
@@ -23,7 +25,14 @@ import (
 	"github.com/dagu-org/dagu/internal/persistence/local"
 	"github.com/dagu-org/dagu/internal/persistence/local/storage"
 	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/persistence/objstore"
+	"github.com/dagu-org/dagu/internal/persistence/sqlitedb"
+	"github.com/dagu-org/dagu/internal/pool"
+	"github.com/dagu-org/dagu/internal/queue"
 	"github.com/dagu-org/dagu/internal/scheduler"
+	"github.com/dagu-org/dagu/internal/scheduler/calendar"
+	"github.com/dagu-org/dagu/internal/scheduler/leader"
+	"github.com/dagu-org/dagu/internal/stepcache"
 	"github.com/dagu-org/dagu/internal/stringutil"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
@@ -42,6 +51,14 @@ func wrapRunE(f func(cmd *cobra.Command, args []string) error) func(cmd *cobra.C
 
 type setup struct {
 	cfg *config.Config
+
+	// runner runs the DAGs in this process in the in-process execution mode.
+	runner *inProcessRunner
+
+	// historyDB is the history database shared by the stores of the process
+	// with the sqlite history backend.
+	historyDBOnce sync.Once
+	historyDB     *sqlitedb.DB
 }
 
 func createSetup() (*setup, error) {
@@ -55,11 +72,19 @@ func createSetup() (*setup, error) {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	return &setup{cfg: cfg}, nil
+	return newSetup(cfg), nil
 }
 
 func setupWithConfig(cfg *config.Config) *setup {
-	return &setup{cfg: cfg}
+	return newSetup(cfg)
+}
+
+func newSetup(cfg *config.Config) *setup {
+	s := &setup{cfg: cfg}
+	if cfg.ExecutionMode == config.ExecutionModeInProcess {
+		s.runner = newInProcessRunner(s)
+	}
+	return s
 }
 
 func (s *setup) loggerContext(ctx context.Context, quiet bool) context.Context {
@@ -127,15 +152,31 @@ func (s *setup) client(opts ...clientOption) (client.Client, error) {
 		s.cfg.Paths.SuspendFlagsDir,
 	))
 
+	var clientOpts []client.Option
+	if s.runner != nil {
+		clientOpts = append(clientOpts, client.WithRunner(s.runner))
+	}
+
 	return client.New(
 		dagStore,
 		historyStore,
 		flagStore,
 		s.cfg.Paths.Executable,
 		s.cfg.WorkDir,
+		clientOpts...,
 	), nil
 }
 
+// waitForRuns waits until the DAGs running in this process finish, which are
+// interrupted when the process is stopped.
+func (s *setup) waitForRuns(ctx context.Context) {
+	if s.runner == nil {
+		return
+	}
+	logger.Info(ctx, "Waiting for the running DAGs to finish")
+	s.runner.wait()
+}
+
 func (s *setup) server(ctx context.Context) (*server.Server, error) {
 	dagCache := filecache.New[*digraph.DAG](0, time.Hour*12)
 	dagCache.StartEviction(ctx)
@@ -149,7 +190,10 @@ func (s *setup) server(ctx context.Context) (*server.Server, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize client: %w", err)
 	}
-	return frontend.New(s.cfg, cli), nil
+	// The lost runs of the DAGs to retry are left for the scheduler.
+	go client.WatchLostRuns(ctx, cli, client.RecoverOptions{})
+
+	return frontend.New(s.cfg, cli, s.queue(), s.remoteFiles(), s.historyDatabase())
 }
 
 func (s *setup) scheduler() (*scheduler.Scheduler, error) {
@@ -158,8 +202,25 @@ func (s *setup) scheduler() (*scheduler.Scheduler, error) {
 		return nil, fmt.Errorf("failed to initialize client: %w", err)
 	}
 
-	manager := scheduler.NewDAGJobManager(s.cfg.Paths.DAGsDir, cli, s.cfg.Paths.Executable, s.cfg.WorkDir)
-	return scheduler.New(s.cfg, manager), nil
+	calendars, err := calendar.NewRegistry(s.cfg.Calendars, s.cfg.Location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize calendars: %w", err)
+	}
+
+	manager := scheduler.NewDAGJobManager(s.cfg.Paths.DAGsDir, cli, s.cfg.Paths.Executable, s.cfg.WorkDir,
+		scheduler.WithCalendars(calendars),
+		scheduler.WithBaseConfig(s.cfg.Paths.BaseConfig),
+		scheduler.WithStatusFile(s.cfg.Scheduler.StatusFile),
+		scheduler.WithQueue(s.queue()),
+		scheduler.WithSLAMissHandler(&slaMissHandler{setup: s}),
+	)
+
+	var opts []scheduler.Option
+	if le := s.cfg.Scheduler.LeaderElection; le.Enabled {
+		elector := leader.NewElector(leader.NewFileBackend(le.LeaseFile), le.ID, le.LeaseTime)
+		opts = append(opts, scheduler.WithElector(elector))
+	}
+	return scheduler.New(s.cfg, manager, opts...), nil
 }
 
 func (s *setup) dagStore() (persistence.DAGStore, error) {
@@ -179,18 +240,98 @@ func (s *setup) dagStoreWithCache(cache *filecache.Cache[*digraph.DAG]) persiste
 }
 
 func (s *setup) historyStore() persistence.HistoryStore {
-	return jsondb.New(s.cfg.Paths.DataDir, jsondb.WithLatestStatusToday(
-		s.cfg.LatestStatusToday,
-	))
+	if s.cfg.HistoryBackend == config.HistoryBackendSQLite {
+		return s.sqliteHistoryStore()
+	}
+	return jsondb.New(s.cfg.Paths.DataDir,
+		jsondb.WithLatestStatusToday(s.cfg.LatestStatusToday),
+		jsondb.WithFiles(s.remoteFiles()),
+	)
 }
 
 func (s *setup) historyStoreWithCache(cache *filecache.Cache[*model.Status]) persistence.HistoryStore {
+	if s.cfg.HistoryBackend == config.HistoryBackendSQLite {
+		// The queries of the database are fast enough without the cache.
+		return s.sqliteHistoryStore()
+	}
 	return jsondb.New(s.cfg.Paths.DataDir,
 		jsondb.WithLatestStatusToday(s.cfg.LatestStatusToday),
 		jsondb.WithFileCache(cache),
+		jsondb.WithFiles(s.remoteFiles()),
+	)
+}
+
+func (s *setup) sqliteHistoryStore() *sqlitedb.SQLiteDB {
+	return sqlitedb.New(s.sqliteDB(), sqlitedb.WithLatestStatusToday(
+		s.cfg.LatestStatusToday,
+	))
+}
+
+func (s *setup) sqliteDB() *sqlitedb.DB {
+	s.historyDBOnce.Do(func() {
+		s.historyDB = sqlitedb.NewDB(s.cfg.Paths.HistoryDB)
+	})
+	return s.historyDB
+}
+
+// historyDatabase returns the database of the history shared with the
+// history stores, or nil if the history is stored in the JSON files.
+func (s *setup) historyDatabase() *sqlitedb.DB {
+	if s.cfg.HistoryBackend != config.HistoryBackendSQLite {
+		return nil
+	}
+	return s.sqliteDB()
+}
+
+// remoteFiles returns the files moved to the remote storage when the runs
+// finish. It returns nil if the files are kept local.
+func (s *setup) remoteFiles() *objstore.Files {
+	if s.cfg.RemoteStorage.Type != config.RemoteStorageS3 {
+		return nil
+	}
+	cfg := s.cfg.RemoteStorage.S3
+	return objstore.NewFiles(objstore.NewS3(objstore.S3Config{
+		Endpoint:        cfg.Endpoint,
+		Region:          cmp.Or(cfg.Region, os.Getenv("AWS_REGION")),
+		Bucket:          cfg.Bucket,
+		Prefix:          cfg.Prefix,
+		AccessKeyID:     cmp.Or(cfg.AccessKeyID, os.Getenv("AWS_ACCESS_KEY_ID")),
+		SecretAccessKey: cmp.Or(cfg.SecretAccessKey, os.Getenv("AWS_SECRET_ACCESS_KEY")),
+		SessionToken:    cmp.Or(cfg.SessionToken, os.Getenv("AWS_SESSION_TOKEN")),
+		UsePathStyle:    cfg.UsePathStyle,
+	}))
+}
+
+// closeLogFile closes the log file of the run and moves it to the remote
+// storage.
+func (s *setup) closeLogFile(ctx context.Context, f *os.File) {
+	_ = f.Close()
+	if err := s.remoteFiles().Upload(ctx, f.Name()); err != nil {
+		logger.Error(ctx, "Failed to upload the log file", "file", f.Name(), "err", err)
+	}
+}
+
+func (s *setup) pools() *pool.Pools {
+	return pool.New(filepath.Join(s.cfg.Paths.DataDir, "pools"), s.cfg.Pools)
+}
+
+// queue returns the queue of the starts of the DAGs while they are running.
+func (s *setup) queue() *queue.Store {
+	return queue.New(filepath.Join(s.cfg.Paths.DataDir, "queue"))
+}
+
+func (s *setup) stepCache() *stepcache.Store {
+	return stepcache.New(filepath.Join(s.cfg.Paths.DataDir, "cache"),
+		stepcache.WithMaxAge(s.cfg.StepCache.MaxAge),
+		stepcache.WithMaxSize(int64(s.cfg.StepCache.MaxSizeMB)*1024*1024),
 	)
 }
 
+// workspaceDir returns the base directory of the workspaces of the runs.
+func (s *setup) workspaceDir() string {
+	return filepath.Join(s.cfg.Paths.DataDir, "workspaces")
+}
+
 func (s *setup) openLogFile(
 	ctx context.Context,
 	prefix string,
@@ -4,11 +4,16 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/dagu-org/dagu/internal/config"
 	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence/jsondb"
+	"github.com/dagu-org/dagu/internal/persistence/objstore"
+	"github.com/dagu-org/dagu/internal/persistence/objstore/s3test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -179,3 +184,34 @@ func TestValidateSettings(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoteStorage(t *testing.T) {
+	th := testSetup(t)
+
+	server := s3test.NewServer(t)
+	t.Setenv("DAGU_REMOTE_STORAGE_TYPE", "s3")
+	t.Setenv("DAGU_REMOTE_STORAGE_S3_ENDPOINT", server.URL)
+	t.Setenv("DAGU_REMOTE_STORAGE_S3_BUCKET", s3test.Bucket)
+	t.Setenv("DAGU_REMOTE_STORAGE_S3_ACCESS_KEY_ID", s3test.AccessKeyID)
+	t.Setenv("DAGU_REMOTE_STORAGE_S3_SECRET_ACCESS_KEY", s3test.SecretAccessKey)
+	t.Setenv("DAGU_REMOTE_STORAGE_S3_USE_PATH_STYLE", "true")
+
+	dagFile := th.DAG(t, "cmd/start.yaml")
+	th.RunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile.Location}})
+
+	// The history is read back from the remote storage.
+	files := objstore.NewFiles(objstore.NewS3(server.Config()))
+	recent := jsondb.New(th.Config.Paths.DataDir, jsondb.WithFiles(files)).
+		ReadStatusRecent(th.Context, dagFile.Location, 1)
+	require.Len(t, recent, 1)
+	status := recent[0].Status
+	require.Equal(t, scheduler.StatusSuccess, status.Status)
+	require.Len(t, status.Nodes, 1)
+
+	// The status file and the logs are moved to the remote storage.
+	keys := server.Keys()
+	for _, file := range []string{recent[0].File, status.Log, status.Nodes[0].Log} {
+		assert.Contains(t, keys, strings.TrimPrefix(filepath.ToSlash(file), "/"))
+		assert.NoFileExists(t, file)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dagu-org/dagu/internal/agent"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/scheduler"
+)
+
+var _ scheduler.SLAMissHandler = (*slaMissHandler)(nil)
+
+// slaMissHandler fires the notification handlers of the DAGs missing their
+// SLAs. The handler step logs to its own log file in the log directory of
+// the DAG.
+type slaMissHandler struct {
+	setup *setup
+}
+
+func (h *slaMissHandler) HandleSLAMiss(ctx context.Context, dag *digraph.DAG, miss scheduler.SLAMiss) error {
+	// The scheduler loads only the metadata of the DAGs.
+	dag, err := digraph.Load(ctx, dag.Location, digraph.WithBaseConfig(h.setup.cfg.Paths.BaseConfig))
+	if err != nil {
+		return fmt.Errorf("failed to load DAG: %w", err)
+	}
+	if dag.HandlerOn.SLAMiss == nil && (dag.MailOn == nil || !dag.MailOn.SLAMiss) {
+		return nil
+	}
+
+	requestID := miss.RequestID
+	if requestID == "" {
+		requestID, err = generateRequestID()
+		if err != nil {
+			return fmt.Errorf("failed to generate request ID: %w", err)
+		}
+	}
+
+	logFile, err := h.setup.openLogFile(ctx, "sla_miss_", dag, requestID)
+	if err != nil {
+		return fmt.Errorf("failed to initialize log file for DAG %s: %w", dag.Name, err)
+	}
+	defer logFile.Close()
+
+	dagStore, err := h.setup.dagStore()
+	if err != nil {
+		return fmt.Errorf("failed to initialize DAG store: %w", err)
+	}
+
+	ctx = h.setup.loggerContextWithFile(ctx, true, logFile)
+	return agent.NotifySLAMiss(ctx, dag, requestID, miss.Reason, logFile.Name(), dagStore, h.setup.historyStore())
+}
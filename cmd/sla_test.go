@@ -0,0 +1,28 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLAMissHandler(t *testing.T) {
+	th := testSetup(t)
+	out := filepath.Join(t.TempDir(), "out")
+	t.Setenv("SLA_MISS_OUT", out)
+
+	handler := &slaMissHandler{setup: setupWithConfig(th.Config)}
+	dagFile := th.DAG(t, "cmd/sla_miss.yaml")
+	require.NoError(t, handler.HandleSLAMiss(th.Context, dagFile.DAG, scheduler.SLAMiss{Reason: "late"}))
+
+	// The handler reads the env of the DAG, which is not set to the
+	// environment of the scheduler.
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "sla\n", string(data))
+	_, ok := os.LookupEnv("SLA_MISS_VAR")
+	require.False(t, ok)
+}
@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/agent"
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence/model"
 	"github.com/spf13/cobra"
 )
 
@@ -29,8 +32,12 @@ func startCmd() *cobra.Command {
 }
 
 func initStartFlags(cmd *cobra.Command) {
-	initCommonFlags(cmd, []commandLineFlag{paramsFlag, withUsage(requestIDFlag, "request ID for the DAG execution")})
+	initCommonFlags(cmd, []commandLineFlag{
+		paramsFlag, withUsage(requestIDFlag, "request ID for the DAG execution"), stepsFlag, fromStepFlag,
+		upstreamFlag, upstreamRequestIDFlag, logicalDateFlag,
+	})
 	cmd.Flags().BoolP("quiet", "q", false, "suppress output")
+	cmd.Flags().Bool("downstream", false, "run the steps downstream of --steps as well")
 }
 
 func runStart(cmd *cobra.Command, args []string) error {
@@ -49,6 +56,21 @@ func runStart(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get request ID: %w", err)
 	}
 
+	partial, err := getPartialRun(cmd)
+	if err != nil {
+		return err
+	}
+
+	upstream, err := getUpstream(cmd)
+	if err != nil {
+		return err
+	}
+
+	logicalDate, err := getTimeFlag(cmd, "logical-date")
+	if err != nil {
+		return err
+	}
+
 	ctx := setup.loggerContext(cmd.Context(), quiet)
 
 	loadOpts := []digraph.LoadOption{
@@ -68,10 +90,13 @@ func runStart(cmd *cobra.Command, args []string) error {
 		loadOpts = append(loadOpts, digraph.WithParams(removeQuotes(params)))
 	}
 
-	return executeDag(ctx, setup, args[0], loadOpts, quiet, requestID)
+	return executeDag(ctx, setup, args[0], loadOpts, quiet, requestID, partial, upstream, logicalDate)
 }
 
-func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []digraph.LoadOption, quiet bool, requestID string) error {
+func executeDag(
+	ctx context.Context, setup *setup, specPath string, loadOpts []digraph.LoadOption, quiet bool, requestID string, partial partialRun,
+	upstream *model.RunRef, logicalDate time.Time,
+) error {
 	dag, err := digraph.Load(ctx, specPath, loadOpts...)
 	if err != nil {
 		logger.Error(ctx, "Failed to load DAG", "path", specPath, "err", err)
@@ -93,7 +118,7 @@ func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []d
 		logger.Error(ctx, "failed to initialize log file", "DAG", dag.Name, "err", err)
 		return fmt.Errorf("failed to initialize log file for DAG %s: %w", dag.Name, err)
 	}
-	defer logFile.Close()
+	defer setup.closeLogFile(ctx, logFile)
 
 	ctx = setup.loggerContextWithFile(ctx, quiet, logFile)
 
@@ -111,6 +136,24 @@ func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []d
 		return fmt.Errorf("failed to initialize client: %w", err)
 	}
 
+	opts := agent.Options{
+		Steps:        partial.steps,
+		Downstream:   partial.downstream,
+		Pools:        setup.pools(),
+		StepCache:    setup.stepCache(),
+		Upstream:     upstream,
+		LogicalDate:  logicalDate,
+		WorkspaceDir: setup.workspaceDir(),
+		Files:        setup.remoteFiles(),
+	}
+	if len(partial.steps) > 0 {
+		// Reuse the results of the latest run for the steps not selected.
+		if recent := setup.historyStore().ReadStatusRecent(ctx, dag.Location, 1); len(recent) > 0 {
+			logger.Info(ctx, "Reusing the results of the latest run", "requestID", recent[0].Status.RequestID)
+			opts.RetryTarget = &recent[0].Status
+		}
+	}
+
 	agentInstance := agent.New(
 		requestID,
 		dag,
@@ -119,7 +162,7 @@ func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []d
 		cli,
 		dagStore,
 		setup.historyStore(),
-		agent.Options{},
+		opts,
 	)
 
 	listenSignals(ctx, agentInstance)
@@ -128,6 +171,8 @@ func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []d
 		logger.Error(ctx, "Failed to execute DAG", "DAG", dag.Name, "requestID", requestID, "err", err)
 
 		if quiet {
+			// The deferred functions are not run on exit.
+			setup.closeLogFile(ctx, logFile)
 			os.Exit(1)
 		} else {
 			agentInstance.PrintSummary(ctx)
@@ -142,6 +187,48 @@ func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []d
 	return nil
 }
 
+// getUpstream reads the --upstream and --upstream-req flags. It returns nil
+// if the run is not triggered by another DAG.
+func getUpstream(cmd *cobra.Command) (*model.RunRef, error) {
+	name, err := cmd.Flags().GetString("upstream")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upstream flag: %w", err)
+	}
+	requestID, err := cmd.Flags().GetString("upstream-req")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upstream-req flag: %w", err)
+	}
+	if name == "" && requestID == "" {
+		return nil, nil
+	}
+	if name == "" || requestID == "" {
+		return nil, fmt.Errorf("--upstream and --upstream-req must be specified together")
+	}
+	return &model.RunRef{Name: name, RequestID: requestID}, nil
+}
+
+// timeFlagLayouts are the layouts accepted by the flags of times, which are
+// interpreted in the local time zone unless the offset is given.
+var timeFlagLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"}
+
+// getTimeFlag reads the flag of a time. It returns the zero time if the flag
+// is not set.
+func getTimeFlag(cmd *cobra.Command, name string) (time.Time, error) {
+	value, err := cmd.Flags().GetString(name)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get %s flag: %w", name, err)
+	}
+	if value == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range timeFlagLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid --%s %q: must be RFC3339, 2006-01-02T15:04 or 2006-01-02", name, value)
+}
+
 // removeQuotes removes the surrounding quotes from the string.
 func removeQuotes(s string) string {
 	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
@@ -149,3 +236,49 @@ func removeQuotes(s string) string {
 	}
 	return s
 }
+
+// partialRun is the selection of the steps to run in a partial run.
+type partialRun struct {
+	steps      []string
+	downstream bool
+}
+
+// getPartialRun reads the --steps, --from-step and --downstream flags.
+func getPartialRun(cmd *cobra.Command) (partialRun, error) {
+	steps, err := cmd.Flags().GetString("steps")
+	if err != nil {
+		return partialRun{}, fmt.Errorf("failed to get steps flag: %w", err)
+	}
+	fromStep, err := cmd.Flags().GetString("from-step")
+	if err != nil {
+		return partialRun{}, fmt.Errorf("failed to get from-step flag: %w", err)
+	}
+	downstream, err := cmd.Flags().GetBool("downstream")
+	if err != nil {
+		return partialRun{}, fmt.Errorf("failed to get downstream flag: %w", err)
+	}
+
+	switch {
+	case steps != "" && fromStep != "":
+		return partialRun{}, fmt.Errorf("--steps and --from-step cannot be used together")
+
+	case fromStep != "":
+		return partialRun{steps: []string{fromStep}, downstream: true}, nil
+
+	case steps != "":
+		var ret partialRun
+		for _, name := range strings.Split(steps, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				ret.steps = append(ret.steps, name)
+			}
+		}
+		ret.downstream = downstream
+		return ret, nil
+
+	case downstream:
+		return partialRun{}, fmt.Errorf("--downstream requires --steps")
+
+	default:
+		return partialRun{}, nil
+	}
+}
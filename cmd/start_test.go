@@ -2,6 +2,9 @@ package main
 
 import (
 	"testing"
+
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStartCommand(t *testing.T) {
@@ -30,6 +33,11 @@ func TestStartCommand(t *testing.T) {
 			args:        []string{"start", th.DAG(t, "cmd/start_with_params.yaml").Location, "--", "p5", "p6"},
 			expectedOut: []string{`params="[p5 p6]"`},
 		},
+		{
+			name:        "StartDAGWithSteps",
+			args:        []string{"start", "--steps=1", th.DAG(t, "cmd/start.yaml").Location},
+			expectedOut: []string{"Partial execution"},
+		},
 	}
 
 	for _, tc := range tests {
@@ -37,4 +45,17 @@ func TestStartCommand(t *testing.T) {
 			th.RunCommand(t, startCmd(), tc)
 		})
 	}
+
+	t.Run("StartDAGWithUpstream", func(t *testing.T) {
+		dagFile := th.DAG(t, "cmd/start.yaml")
+		th.RunCommand(t, startCmd(), cmdTest{
+			args: []string{
+				"start", "--req=downstream-req", "--upstream=extract", "--upstream-req=upstream-req", dagFile.Location,
+			},
+		})
+
+		status, err := th.Client.GetStatusByRequestID(th.Context, dagFile.DAG, "downstream-req")
+		require.NoError(t, err)
+		require.Equal(t, &model.RunRef{Name: "extract", RequestID: "upstream-req"}, status.Upstream)
+	})
 }
@@ -33,6 +33,7 @@ func runStartAll(cmd *cobra.Command, _ []string) error {
 	}
 
 	ctx := setup.loggerContext(cmd.Context(), false)
+	defer setup.waitForRuns(ctx)
 
 	scheduler, err := setup.scheduler()
 	if err != nil {
@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 	"runtime/debug"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -22,7 +24,10 @@ import (
 	"github.com/dagu-org/dagu/internal/mailer"
 	"github.com/dagu-org/dagu/internal/persistence"
 	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/persistence/objstore"
+	"github.com/dagu-org/dagu/internal/procutil"
 	"github.com/dagu-org/dagu/internal/sock"
+	"github.com/dagu-org/dagu/internal/stringutil"
 )
 
 // Agent is responsible for running the DAG and handling communication
@@ -35,13 +40,22 @@ type Agent struct {
 	dag          *digraph.DAG
 	dry          bool
 	retryTarget  *model.Status
+	steps        []string
+	downstream   bool
+	pools        scheduler.ResourcePools
+	stepCache    scheduler.StepCache
+	upstream     *model.RunRef
+	workspaceDir string
+	logicalDate  time.Time
 	dagStore     persistence.DAGStore
 	client       client.Client
 	scheduler    *scheduler.Scheduler
 	graph        *scheduler.ExecutionGraph
 	reporter     *reporter
 	historyStore persistence.HistoryStore
+	files        *objstore.Files
 	socketServer *sock.Server
+	sockAddr     string
 	logDir       string
 	logFile      string
 
@@ -50,6 +64,11 @@ type Agent struct {
 	requestID string
 	finished  atomic.Bool
 
+	// statusMu orders the writes of the status after the run is paused or
+	// resumed before the write of the finished status.
+	statusMu     sync.Mutex
+	statusClosed bool
+
 	lock    sync.RWMutex
 	lastErr error
 }
@@ -63,6 +82,29 @@ type Options struct {
 	// If it's specified the agent will execute the DAG with the same
 	// configuration as the specified history.
 	RetryTarget *model.Status
+	// Steps is the names of the steps to run in a partial run. The other
+	// steps are not executed and their results are taken from RetryTarget
+	// if it's specified.
+	Steps []string
+	// Downstream runs the steps that depend on Steps as well.
+	Downstream bool
+	// Pools is the resource pools shared with the other DAG runs.
+	Pools scheduler.ResourcePools
+	// StepCache is the store of the results of the steps with caching
+	// enabled.
+	StepCache scheduler.StepCache
+	// Upstream is the run of another DAG that triggered this run.
+	Upstream *model.RunRef
+	// LogicalDate is the scheduled time the run is for. It is available to
+	// the steps as DAG_LOGICAL_DATE.
+	LogicalDate time.Time
+	// WorkspaceDir is the base directory of the workspaces of the runs of
+	// the DAGs with a workspace configured. Defaults to the temporary
+	// directory of the system.
+	WorkspaceDir string
+	// Files moves the logs of the steps to the remote storage when the run
+	// finishes. The logs are kept local if it's nil.
+	Files *objstore.Files
 }
 
 // New creates a new Agent.
@@ -81,6 +123,14 @@ func New(
 		dag:          dag,
 		dry:          opts.Dry,
 		retryTarget:  opts.RetryTarget,
+		steps:        opts.Steps,
+		downstream:   opts.Downstream,
+		pools:        opts.Pools,
+		stepCache:    opts.StepCache,
+		upstream:     opts.Upstream,
+		workspaceDir: opts.WorkspaceDir,
+		logicalDate:  opts.LogicalDate,
+		files:        opts.Files,
 		logDir:       logDir,
 		logFile:      logFile,
 		client:       cli,
@@ -99,6 +149,16 @@ func (a *Agent) Run(ctx context.Context) error {
 	dbClient := newDBClient(a.historyStore, a.dagStore)
 	ctx = digraph.NewContext(ctx, a.dag, dbClient, a.requestID, a.logFile)
 
+	if logicalDate := a.runLogicalDate(); !logicalDate.IsZero() {
+		ctx = digraph.WithContext(ctx, digraph.GetContext(ctx).WithEnv(digraph.EnvKeyDAGLogicalDate, stringutil.FormatTime(logicalDate)))
+	}
+
+	var ws *workspace
+	if a.dag.Workspace != nil {
+		ws = a.newWorkspace()
+		ctx = digraph.WithContext(ctx, digraph.GetContext(ctx).WithEnv(digraph.EnvKeyDAGRunWorkspace, ws.dir))
+	}
+
 	// It should not run the DAG if the condition is unmet.
 	if err := a.checkPreconditions(ctx); err != nil {
 		logger.Info(ctx, "Preconditions are not met", "err", err)
@@ -110,12 +170,25 @@ func (a *Agent) Run(ctx context.Context) error {
 		return a.dryRun(ctx)
 	}
 
-	// Check if the DAG is already running.
-	if err := a.checkIsAlreadyRunning(ctx); err != nil {
+	// Check if the DAG is already running as many runs as it allows.
+	if err := a.claimSockAddr(); err != nil {
 		a.scheduler.Cancel(ctx, a.graph)
 		return err
 	}
 
+	// Create the workspace shared by the steps, and remove it according to
+	// the retention when the DAG execution is finished.
+	if ws != nil {
+		if err := ws.create(); err != nil {
+			return err
+		}
+		defer ws.cleanup(ctx)
+	}
+
+	// Move the logs of the steps to the remote storage after the socket
+	// server, which streams the logs, is shut down.
+	defer a.uploadLogs(ctx)
+
 	// Make a connection to the database.
 	// It should close the connection to the history database when the DAG
 	// execution is finished.
@@ -161,8 +234,13 @@ func (a *Agent) Run(ctx context.Context) error {
 	// It should receive node instance when the node status changes, for
 	// example, when started, stopped, or cancelled, etc.
 	done := make(chan *scheduler.Node)
-	defer close(done)
+	// writers are the goroutines writing the status, which finish before the
+	// finished status is written and the history store is closed.
+	var writers sync.WaitGroup
+	scheduled := make(chan struct{})
+	writers.Add(2)
 	go execWithRecovery(ctx, func() {
+		defer writers.Done()
 		for node := range done {
 			status := a.Status()
 			if err := a.historyStore.Write(ctx, status); err != nil {
@@ -177,8 +255,10 @@ func (a *Agent) Run(ctx context.Context) error {
 	// Write the first status just after the start to store the running status.
 	// If the DAG is already finished, skip it.
 	go execWithRecovery(ctx, func() {
-		time.Sleep(waitForRunning)
-		if a.finished.Load() {
+		defer writers.Done()
+		select {
+		case <-time.After(waitForRunning):
+		case <-scheduled:
 			return
 		}
 		if err := a.historyStore.Write(ctx, a.Status()); err != nil {
@@ -189,8 +269,14 @@ func (a *Agent) Run(ctx context.Context) error {
 	// Start the DAG execution.
 	logger.Info(ctx, "DAG execution started", "reqId", a.requestID, "name", a.dag.Name, "params", a.dag.Params)
 	lastErr := a.scheduler.Schedule(ctx, a.graph, done)
+	close(scheduled)
+	close(done)
+	writers.Wait()
 
 	// Update the finished status to the history database.
+	a.statusMu.Lock()
+	a.statusClosed = true
+	a.statusMu.Unlock()
 	finishedStatus := a.Status()
 	logger.Info(ctx, "DAG execution finished", "status", finishedStatus.Status)
 	if err := a.historyStore.Write(ctx, a.Status()); err != nil {
@@ -228,6 +314,16 @@ func (a *Agent) Status() model.Status {
 		schedulerStatus = scheduler.StatusRunning
 	}
 
+	// A retry keeps the links to the other DAGs of the original run.
+	upstream := a.upstream
+	var downstreams []model.RunRef
+	if a.retryTarget != nil && a.retryTarget.RequestID == a.requestID {
+		if upstream == nil {
+			upstream = a.retryTarget.Upstream
+		}
+		downstreams = a.retryTarget.Downstreams
+	}
+
 	// Create the status object to record the current status.
 	return model.NewStatusFactory(a.dag).
 		Create(
@@ -238,6 +334,10 @@ func (a *Agent) Status() model.Status {
 			model.WithFinishedAt(a.graph.FinishAt()),
 			model.WithNodes(a.graph.NodeData()),
 			model.WithLogFilePath(a.logFile),
+			model.WithUpstream(upstream),
+			model.WithDownstreams(downstreams),
+			model.WithLogicalDate(a.runLogicalDate()),
+			model.WithAgentProcess(procutil.Hostname(), procutil.StartTime(os.Getpid())),
 			model.WithOnExitNode(a.scheduler.HandlerNode(digraph.HandlerOnExit)),
 			model.WithOnSuccessNode(a.scheduler.HandlerNode(digraph.HandlerOnSuccess)),
 			model.WithOnFailureNode(a.scheduler.HandlerNode(digraph.HandlerOnFailure)),
@@ -255,8 +355,20 @@ const waitForRunning = time.Millisecond * 100
 
 // Simple regular expressions for request routing
 var (
-	statusRe = regexp.MustCompile(`^/status[/]?$`)
-	stopRe   = regexp.MustCompile(`^/stop[/]?$`)
+	statusRe  = regexp.MustCompile(`^/status[/]?$`)
+	stopRe    = regexp.MustCompile(`^/stop[/]?$`)
+	approveRe = regexp.MustCompile(`^/approve[/]?$`)
+	rejectRe  = regexp.MustCompile(`^/reject[/]?$`)
+
+	cancelStepRe    = regexp.MustCompile(`^/cancel-step[/]?$`)
+	skipStepRe      = regexp.MustCompile(`^/skip-step[/]?$`)
+	retryStepRe     = regexp.MustCompile(`^/retry-step[/]?$`)
+	maxActiveRunsRe = regexp.MustCompile(`^/max-active-runs[/]?$`)
+	pauseRe         = regexp.MustCompile(`^/pause[/]?$`)
+	resumeRe        = regexp.MustCompile(`^/resume[/]?$`)
+
+	// logsRe matches the escaped path with the optional step name.
+	logsRe = regexp.MustCompile(`^/logs(?:/([^/]+))?[/]?$`)
 )
 
 // HandleHTTP handles HTTP requests via unix socket.
@@ -267,7 +379,9 @@ func (a *Agent) HandleHTTP(ctx context.Context) sock.HTTPHandlerFunc {
 		case r.Method == http.MethodGet && statusRe.MatchString(r.URL.Path):
 			// Return the current status of the execution.
 			status := a.Status()
-			status.Status = scheduler.StatusRunning
+			if !status.Status.IsActive() {
+				status.Status = scheduler.StatusRunning
+			}
 			statusJSON, err := json.Marshal(status)
 			if err != nil {
 				encodeError(w, err)
@@ -283,6 +397,33 @@ func (a *Agent) HandleHTTP(ctx context.Context) sock.HTTPHandlerFunc {
 				logger.Info(ctx, "Stop request received")
 				a.signal(ctx, syscall.SIGTERM, true)
 			}()
+		case r.Method == http.MethodPost && approveRe.MatchString(r.URL.Path):
+			// Approve the step waiting for a manual approval.
+			a.handleApproval(ctx, w, r, true)
+		case r.Method == http.MethodPost && rejectRe.MatchString(r.URL.Path):
+			// Reject the step waiting for a manual approval.
+			a.handleApproval(ctx, w, r, false)
+		case r.Method == http.MethodPost && cancelStepRe.MatchString(r.URL.Path):
+			// Cancel the running step.
+			a.handleStepControl(ctx, w, r, a.scheduler.CancelStep)
+		case r.Method == http.MethodPost && skipStepRe.MatchString(r.URL.Path):
+			// Skip the step that has not started.
+			a.handleStepControl(ctx, w, r, a.scheduler.SkipStep)
+		case r.Method == http.MethodPost && retryStepRe.MatchString(r.URL.Path):
+			// Run the failed or canceled step again.
+			a.handleStepControl(ctx, w, r, a.scheduler.RetryStep)
+		case r.Method == http.MethodPost && maxActiveRunsRe.MatchString(r.URL.Path):
+			// Change the maximum number of the steps running at the same time.
+			a.handleMaxActiveRuns(ctx, w, r)
+		case r.Method == http.MethodPost && pauseRe.MatchString(r.URL.Path):
+			// Stop starting new steps until the run is resumed.
+			a.handlePause(ctx, w, r)
+		case r.Method == http.MethodPost && resumeRe.MatchString(r.URL.Path):
+			// Resume the paused run.
+			a.handleResume(ctx, w)
+		case r.Method == http.MethodGet && logsRe.MatchString(r.URL.EscapedPath()):
+			// Stream the log of the step, or the logs of all the steps.
+			a.handleLogs(ctx, w, r, logsRe.FindStringSubmatch(r.URL.EscapedPath())[1])
 		default:
 			// Unknown request
 			encodeError(
@@ -292,6 +433,116 @@ func (a *Agent) HandleHTTP(ctx context.Context) sock.HTTPHandlerFunc {
 	}
 }
 
+// handleApproval approves or rejects the step given by the "step" query
+// parameter. The "approver" and "comment" parameters are recorded in the
+// status of the step.
+func (a *Agent) handleApproval(ctx context.Context, w http.ResponseWriter, r *http.Request, approved bool) {
+	query := r.URL.Query()
+	stepName := query.Get("step")
+	if stepName == "" {
+		encodeError(w, &httpError{Code: http.StatusBadRequest, Message: "step is required"})
+		return
+	}
+	approver, comment := query.Get("approver"), query.Get("comment")
+
+	var err error
+	if approved {
+		logger.Info(ctx, "Approve request received", "step", stepName, "approver", approver)
+		err = a.scheduler.Approve(a.graph, stepName, approver, comment)
+	} else {
+		logger.Info(ctx, "Reject request received", "step", stepName, "approver", approver)
+		err = a.scheduler.Reject(a.graph, stepName, approver, comment)
+	}
+	if err != nil {
+		encodeError(w, &httpError{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+}
+
+// handleStepControl applies the control to the step given by the "step"
+// query parameter.
+func (a *Agent) handleStepControl(
+	ctx context.Context, w http.ResponseWriter, r *http.Request,
+	control func(graph *scheduler.ExecutionGraph, stepName string) error,
+) {
+	stepName := r.URL.Query().Get("step")
+	if stepName == "" {
+		encodeError(w, &httpError{Code: http.StatusBadRequest, Message: "step is required"})
+		return
+	}
+	logger.Info(ctx, "Step control request received", "path", r.URL.Path, "step", stepName)
+	if err := control(a.graph, stepName); err != nil {
+		encodeError(w, &httpError{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+}
+
+// handleMaxActiveRuns changes the maximum number of the steps running at
+// the same time to the "value" query parameter.
+func (a *Agent) handleMaxActiveRuns(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	value, err := strconv.Atoi(r.URL.Query().Get("value"))
+	if err != nil {
+		encodeError(w, &httpError{Code: http.StatusBadRequest, Message: "value must be a number"})
+		return
+	}
+	logger.Info(ctx, "Max active runs request received", "value", value)
+	if err := a.scheduler.SetMaxActiveRuns(value); err != nil {
+		encodeError(w, &httpError{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+}
+
+// handlePause pauses the run. If the "suspend" query parameter is true, the
+// running steps are suspended as well.
+func (a *Agent) handlePause(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	suspend, _ := strconv.ParseBool(r.URL.Query().Get("suspend"))
+	logger.Info(ctx, "Pause request received", "suspend", suspend)
+	if err := a.scheduler.Pause(suspend); err != nil {
+		encodeError(w, &httpError{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+	a.writeActiveStatus(ctx)
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+}
+
+// handleResume resumes the paused run.
+func (a *Agent) handleResume(ctx context.Context, w http.ResponseWriter) {
+	logger.Info(ctx, "Resume request received")
+	if err := a.scheduler.Resume(); err != nil {
+		encodeError(w, &httpError{Code: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+	a.writeActiveStatus(ctx)
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+}
+
+// writeActiveStatus writes the status of the run to the history database
+// unless the finished status is being written.
+func (a *Agent) writeActiveStatus(ctx context.Context) {
+	a.statusMu.Lock()
+	defer a.statusMu.Unlock()
+
+	if a.statusClosed {
+		return
+	}
+	if err := a.historyStore.Write(ctx, a.Status()); err != nil {
+		logger.Error(ctx, "Status write failed", "err", err)
+	}
+}
+
 // setup the agent instance for DAG execution.
 func (a *Agent) setup(ctx context.Context) error {
 	// Lock to prevent race condition.
@@ -310,15 +561,44 @@ func (a *Agent) setup(ctx context.Context) error {
 	return a.setupGraph(ctx)
 }
 
+// newWorkspace returns the workspace of the run. A retry uses the workspace
+// of the original run.
+func (a *Agent) newWorkspace() *workspace {
+	baseDir := a.workspaceDir
+	if baseDir == "" {
+		baseDir = filepath.Join(os.TempDir(), "dagu", "workspaces")
+	}
+	requestID := a.requestID
+	if a.retryTarget != nil {
+		requestID = a.retryTarget.RequestID
+	}
+	return newWorkspace(baseDir, a.dag.Name, requestID, a.dag.Workspace.RetentionDays)
+}
+
+// runLogicalDate returns the scheduled time the run is for. A retry keeps
+// the logical date of the original run.
+func (a *Agent) runLogicalDate() time.Time {
+	if a.logicalDate.IsZero() && a.retryTarget != nil && a.retryTarget.RequestID == a.requestID {
+		if t, err := stringutil.ParseTime(a.retryTarget.LogicalDate); err == nil {
+			return t
+		}
+	}
+	return a.logicalDate
+}
+
 // newScheduler creates a scheduler instance for the DAG execution.
 func (a *Agent) newScheduler() *scheduler.Scheduler {
 	cfg := &scheduler.Config{
-		LogDir:        a.logDir,
-		MaxActiveRuns: a.dag.MaxActiveRuns,
-		Timeout:       a.dag.Timeout,
-		Delay:         a.dag.Delay,
-		Dry:           a.dry,
-		ReqID:         a.requestID,
+		LogDir:          a.logDir,
+		MaxActiveRuns:   a.dag.MaxActiveRuns,
+		MaxActiveWeight: a.dag.MaxActiveWeight,
+		Pools:           a.pools,
+		StepCache:       a.stepCache,
+		Timeout:         a.dag.Timeout,
+		RetryWindow:     a.dag.RetryWindow,
+		Delay:           a.dag.Delay,
+		Dry:             a.dry,
+		ReqID:           a.requestID,
 	}
 
 	if a.dag.HandlerOn.Exit != nil {
@@ -410,6 +690,10 @@ func (a *Agent) signal(ctx context.Context, sig os.Signal, allowOverride bool) {
 // setupGraph setups the DAG graph. If is retry execution, it loads nodes
 // from the retry node so that it runs the same DAG as the previous run.
 func (a *Agent) setupGraph(ctx context.Context) error {
+	if len(a.steps) > 0 {
+		logger.Info(ctx, "Partial execution", "reqId", a.requestID, "steps", a.steps, "downstream", a.downstream)
+		return a.setupGraphForPartialRun(ctx)
+	}
 	if a.retryTarget != nil {
 		logger.Info(ctx, "Retry execution", "reqId", a.requestID)
 		return a.setupGraphForRetry(ctx)
@@ -436,6 +720,22 @@ func (a *Agent) setupGraphForRetry(ctx context.Context) error {
 	return nil
 }
 
+// setupGraphForPartialRun sets up the graph to run only the selected steps.
+func (a *Agent) setupGraphForPartialRun(ctx context.Context) error {
+	var reference []*scheduler.Node
+	if a.retryTarget != nil {
+		for _, n := range a.retryTarget.Nodes {
+			reference = append(reference, n.ToNode())
+		}
+	}
+	graph, err := scheduler.CreatePartialExecutionGraph(ctx, a.dag.Steps, reference, a.steps, a.downstream)
+	if err != nil {
+		return err
+	}
+	a.graph = graph
+	return nil
+}
+
 // setup database prepare database connection and remove old history data.
 func (a *Agent) setupDatabase(ctx context.Context) error {
 	location, retentionDays := a.dag.Location, a.dag.HistRetentionDays
@@ -446,9 +746,35 @@ func (a *Agent) setupDatabase(ctx context.Context) error {
 	return a.historyStore.Open(ctx, a.dag.Location, time.Now(), a.requestID)
 }
 
+// uploadLogs moves the logs of the steps to the remote storage.
+func (a *Agent) uploadLogs(ctx context.Context) {
+	if !a.files.Remote() {
+		return
+	}
+
+	nodes := a.graph.Nodes()
+	for _, handler := range []digraph.HandlerType{
+		digraph.HandlerOnExit, digraph.HandlerOnSuccess, digraph.HandlerOnFailure, digraph.HandlerOnCancel,
+	} {
+		if node := a.scheduler.HandlerNode(handler); node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	for _, node := range nodes {
+		logFile := node.LogFile()
+		if logFile == "" {
+			// The step did not run.
+			continue
+		}
+		if err := a.files.Upload(ctx, logFile); err != nil {
+			logger.Error(ctx, "Failed to upload the log of the step", "step", node.Data().Step.Name, "err", err)
+		}
+	}
+}
+
 // setupSocketServer create socket server instance.
 func (a *Agent) setupSocketServer(ctx context.Context) error {
-	socketServer, err := sock.NewServer(a.dag.SockAddr(), a.HandleHTTP(ctx))
+	socketServer, err := sock.NewServer(a.sockAddr, a.HandleHTTP(ctx))
 	if err != nil {
 		return err
 	}
@@ -471,16 +797,21 @@ func (a *Agent) checkPreconditions(ctx context.Context) error {
 	return nil
 }
 
-// checkIsAlreadyRunning returns error if the DAG is already running.
-func (a *Agent) checkIsAlreadyRunning(ctx context.Context) error {
-	status, err := a.client.GetCurrentStatus(ctx, a.dag)
-	if err != nil {
-		return err
-	}
-	if status.Status != scheduler.StatusNone {
-		return fmt.Errorf("the DAG is already running. status=%s, socket=%s", status.Status, a.dag.SockAddr())
+// claimSockAddr picks the socket address the run is served on, which is
+// the first address of the DAG no other run is serving. It returns error if
+// the DAG is already running Concurrency.Max runs.
+func (a *Agent) claimSockAddr() error {
+	addrs := a.dag.SockAddrs()
+	for _, addr := range addrs {
+		_, err := sock.NewClient(addr).Request("GET", "/status")
+		if err == nil || errors.Is(err, sock.ErrTimeout) {
+			// Another run is serving the address.
+			continue
+		}
+		a.sockAddr = addr
+		return nil
 	}
-	return nil
+	return fmt.Errorf("the DAG is already running. runs=%d, socket=%s", len(addrs), addrs[0])
 }
 
 func execWithRecovery(ctx context.Context, fn func()) {
@@ -540,9 +871,41 @@ func (o *dbClient) GetStatus(ctx context.Context, name string, requestID string)
 	if err != nil {
 		return nil, err
 	}
+	return toDigraphStatus(status.Status), nil
+}
+
+// latestStatusLimit is the number of the recent runs searched for the
+// latest run on a date.
+const latestStatusLimit = 100
 
+// GetLatestStatus implements digraph.DBClient.
+func (o *dbClient) GetLatestStatus(ctx context.Context, name string, date time.Time) (*digraph.Status, error) {
+	dag, err := o.dagStore.GetMetadata(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	year, month, day := date.Date()
+	for _, file := range o.historyStore.ReadStatusRecent(ctx, dag.Location, latestStatusLimit) {
+		runDate := file.Status.LogicalDate
+		if runDate == "" {
+			runDate = file.Status.StartedAt
+		}
+		t, err := stringutil.ParseTime(runDate)
+		if err != nil || t.IsZero() {
+			continue
+		}
+		y, m, d := t.In(date.Location()).Date()
+		if y == year && m == month && d == day {
+			return toDigraphStatus(file.Status), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s on %s", digraph.ErrRunNotFound, name, date.Format(time.DateOnly))
+}
+
+func toDigraphStatus(status model.Status) *digraph.Status {
 	outputVariables := map[string]string{}
-	for _, node := range status.Status.Nodes {
+	for _, node := range status.Nodes {
 		if node.Step.OutputVariables != nil {
 			node.Step.OutputVariables.Range(func(_, value any) bool {
 				// split the value by '=' to get the key and value
@@ -557,7 +920,8 @@ func (o *dbClient) GetStatus(ctx context.Context, name string, requestID string)
 
 	return &digraph.Status{
 		Outputs: outputVariables,
-		Name:    status.Status.Name,
-		Params:  status.Status.Params,
-	}, nil
+		Name:    status.Name,
+		Params:  status.Params,
+		Status:  status.StatusText,
+	}
 }
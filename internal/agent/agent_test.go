@@ -1,16 +1,24 @@
 package agent_test
 
 import (
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/agent"
 	"github.com/dagu-org/dagu/internal/test"
 
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence/jsondb"
 	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/sock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -70,6 +78,38 @@ func TestAgent_Run(t *testing.T) {
 		// Wait for the DAG to finish
 		<-done
 	})
+	t.Run("MaxRuns", func(t *testing.T) {
+		th := test.Setup(t)
+		dag := th.DAG(t, "agent/max_runs.yaml")
+
+		// Each run writes its status with its own history store like the
+		// runs in process.
+		newAgent := func(requestID string) *agent.Agent {
+			logFile := filepath.Join(th.Config.Paths.LogDir, requestID+".log")
+			return agent.New(requestID, dag.DAG, th.Config.Paths.LogDir, logFile,
+				th.Client, th.DAGStore, jsondb.New(th.Config.Paths.DataDir), agent.Options{})
+		}
+
+		// Run the DAG twice at the same time as it allows.
+		var wg sync.WaitGroup
+		for i := 1; i <= 2; i++ {
+			dagAgent := newAgent(fmt.Sprintf("max-runs-%d", i))
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, dagAgent.Run(th.Context))
+			}()
+			require.Eventually(t, func() bool {
+				active, err := th.Client.GetActiveRuns(th.Context, dag.DAG)
+				return err == nil && len(active) == i
+			}, time.Second*3, time.Millisecond*50)
+		}
+
+		// A third run is not allowed while they are running.
+		require.ErrorContains(t, newAgent("max-runs-3").Run(th.Context), "is already running")
+
+		wg.Wait()
+	})
 	t.Run("PreConditionNotMet", func(t *testing.T) {
 		th := test.Setup(t)
 		dag := th.DAG(t, "agent/multiple_steps.yaml")
@@ -124,6 +164,59 @@ func TestAgent_Run(t *testing.T) {
 		// wait for the DAG to be canceled
 		dag.AssertLatestStatus(t, scheduler.StatusCancel)
 	})
+	t.Run("DAGSensor", func(t *testing.T) {
+		th := test.Setup(t)
+
+		// Create and run the upstream DAG the sensor waits for.
+		_, err := th.DAGStore.Create(th.Context, "sensor_upstream", []byte("steps:\n  - name: \"1\"\n    command: \"true\"\n"))
+		require.NoError(t, err)
+		upstreamDAG, err := th.DAGStore.GetDetails(th.Context, "sensor_upstream")
+		require.NoError(t, err)
+		upstream := test.DAG{Helper: &th, DAG: upstreamDAG}
+		upstream.Agent().RunSuccess(t)
+
+		dag := th.DAG(t, "agent/sensor_dag.yaml")
+		dag.Agent().RunSuccess(t)
+
+		dag.AssertLatestStatus(t, scheduler.StatusSuccess)
+	})
+	t.Run("DAGSensorLogicalDate", func(t *testing.T) {
+		th := test.Setup(t)
+
+		// The upstream DAG is backfilled for the date 10 days ago.
+		_, err := th.DAGStore.Create(th.Context, "sensor_upstream", []byte("steps:\n  - name: \"1\"\n    command: \"true\"\n"))
+		require.NoError(t, err)
+		upstreamDAG, err := th.DAGStore.GetDetails(th.Context, "sensor_upstream")
+		require.NoError(t, err)
+		upstream := test.DAG{Helper: &th, DAG: upstreamDAG}
+		logicalDate := time.Now().AddDate(0, 0, -10)
+		upstream.Agent(test.WithAgentOptions(agent.Options{LogicalDate: logicalDate})).RunSuccess(t)
+
+		// The run for the same date waits for the upstream run of the date.
+		dag := th.DAG(t, "agent/sensor_dag_logical_date.yaml")
+		dag.Agent(test.WithAgentOptions(agent.Options{LogicalDate: logicalDate})).RunSuccess(t)
+
+		// The run for today does not take the upstream run started today.
+		dag.Agent().RunError(t)
+	})
+	t.Run("Workspace", func(t *testing.T) {
+		th := test.Setup(t)
+		dag := th.DAG(t, "agent/workspace.yaml")
+		workspaceDir := t.TempDir()
+		dagAgent := dag.Agent(test.WithAgentOptions(agent.Options{WorkspaceDir: workspaceDir}))
+		dagAgent.RunSuccess(t)
+
+		status := dagAgent.Status()
+		workDir := filepath.Join(workspaceDir, dag.Name, status.RequestID)
+		dag.AssertOutputs(t, map[string]any{
+			"CONTENT":  "hello",
+			"WORKDIR":  workDir,
+			"LOCATION": "ws:" + workDir,
+		})
+
+		// The workspace is removed after the run without retention.
+		require.NoDirExists(t, workDir)
+	})
 	t.Run("ExitHandler", func(t *testing.T) {
 		th := test.Setup(t)
 		dag := th.DAG(t, "agent/on_exit.yaml")
@@ -279,6 +372,100 @@ func TestAgent_HandleHTTP(t *testing.T) {
 		<-done
 		dag.AssertLatestStatus(t, scheduler.StatusCancel)
 	})
+	t.Run("HTTP_HandleApproval", func(t *testing.T) {
+		th := test.Setup(t)
+
+		// Start a DAG waiting for an approval
+		dag := th.DAG(t, "agent/handle_http_approval.yaml")
+		dagAgent := dag.Agent()
+
+		done := make(chan struct{})
+		go func() {
+			dagAgent.RunSuccess(t)
+			close(done)
+		}()
+
+		// Wait for the step to wait for the approval
+		require.Eventually(t, func() bool {
+			latest, err := th.Client.GetLatestStatus(th.Context, dag.DAG)
+			return err == nil && len(latest.Nodes) > 0 && latest.Nodes[0].Status == scheduler.NodeStatusWaiting
+		}, time.Second*3, time.Millisecond*50)
+
+		// The step name is required
+		var w = mockResponseWriter{}
+		dagAgent.HandleHTTP(th.Context)(&w, &http.Request{
+			Method: "POST",
+			URL:    &url.URL{Path: "/approve"},
+		})
+		require.Equal(t, http.StatusBadRequest, w.status)
+
+		// Only the waiting step can be approved
+		w = mockResponseWriter{}
+		dagAgent.HandleHTTP(th.Context)(&w, &http.Request{
+			Method: "POST",
+			URL:    &url.URL{Path: "/approve", RawQuery: "step=deploy"},
+		})
+		require.Equal(t, http.StatusBadRequest, w.status)
+
+		// Approve the step
+		w = mockResponseWriter{}
+		dagAgent.HandleHTTP(th.Context)(&w, &http.Request{
+			Method: "POST",
+			URL:    &url.URL{Path: "/approve", RawQuery: "step=approve&approver=alice&comment=ship+it"},
+		})
+		require.Equal(t, http.StatusOK, w.status)
+
+		// Wait for the DAG to finish
+		<-done
+		dag.AssertLatestStatus(t, scheduler.StatusSuccess)
+
+		latest, err := th.Client.GetLatestStatus(th.Context, dag.DAG)
+		require.NoError(t, err)
+		require.Equal(t, "alice", latest.Nodes[0].Approver)
+		require.Equal(t, "ship it", latest.Nodes[0].ApprovalComment)
+	})
+	t.Run("HTTP_HandleLogs", func(t *testing.T) {
+		th := test.Setup(t)
+
+		dag := th.DAG(t, "agent/handle_http_logs.yaml")
+		dagAgent := dag.Agent()
+
+		done := make(chan struct{})
+		go func() {
+			dagAgent.RunSuccess(t)
+			close(done)
+		}()
+
+		// Wait for the step to start
+		require.Eventually(t, func() bool {
+			latest, err := th.Client.GetLatestStatus(th.Context, dag.DAG)
+			return err == nil && len(latest.Nodes) > 0 && latest.Nodes[0].Status == scheduler.NodeStatusRunning
+		}, time.Second*3, time.Millisecond*50)
+
+		// The unknown step is not found
+		_, err := th.Client.StreamLog(th.Context, dag.DAG, "unknown", true)
+		require.ErrorIs(t, err, sock.ErrRequestFailed)
+
+		// Follow the log of the running step
+		stream, err := th.Client.StreamLog(th.Context, dag.DAG, "print", true)
+		require.NoError(t, err)
+		data, err := io.ReadAll(stream)
+		require.NoError(t, err)
+		require.NoError(t, stream.Close())
+		require.Equal(t, "first\nsecond\n", string(data))
+
+		// Follow the logs of all the steps
+		stream, err = th.Client.StreamLog(th.Context, dag.DAG, "", true)
+		require.NoError(t, err)
+		data, err = io.ReadAll(stream)
+		require.NoError(t, err)
+		require.NoError(t, stream.Close())
+		require.Contains(t, string(data), "[print] first\n[print] second\n")
+		require.Contains(t, string(data), "[done] done\n")
+
+		<-done
+		dag.AssertLatestStatus(t, scheduler.StatusSuccess)
+	})
 }
 
 // Assert that mockResponseWriter implements http.ResponseWriter
@@ -0,0 +1,112 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/logger"
+)
+
+// handleLogs streams the log of the step. If the step is empty, the logs of
+// all the steps are streamed line by line, each line prefixed with the step
+// name. If the "follow" query parameter is true, the output of the steps is
+// streamed until the steps finish.
+func (a *Agent) handleLogs(ctx context.Context, w http.ResponseWriter, r *http.Request, escapedStep string) {
+	stepName, err := url.PathUnescape(escapedStep)
+	if err != nil {
+		encodeError(w, &httpError{Code: http.StatusBadRequest, Message: "invalid step name"})
+		return
+	}
+	follow, _ := strconv.ParseBool(r.URL.Query().Get("follow"))
+
+	var nodes []*scheduler.Node
+	for _, node := range a.graph.Nodes() {
+		if stepName == "" || node.Data().Step.Name == stepName {
+			nodes = append(nodes, node)
+		}
+	}
+	if stepName != "" && len(nodes) == 0 {
+		encodeError(w, &httpError{Code: http.StatusNotFound, Message: "step not found: " + stepName})
+		return
+	}
+
+	w.Header().Set("content-type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	if stepName != "" {
+		if err := nodes[0].StreamLog(ctx, w, follow); err != nil {
+			logger.Debug(ctx, "Log stream stopped", "step", stepName, "err", err)
+		}
+		return
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, node := range nodes {
+		wg.Add(1)
+		go execWithRecovery(ctx, func() {
+			defer wg.Done()
+			sw := &stepLogWriter{mu: &mu, w: w, prefix: []byte("[" + node.Data().Step.Name + "] ")}
+			if err := node.StreamLog(ctx, sw, follow); err != nil {
+				logger.Debug(ctx, "Log stream stopped", "step", node.Data().Step.Name, "err", err)
+				return
+			}
+			_ = sw.flush()
+		})
+	}
+	wg.Wait()
+}
+
+// stepLogWriter writes the log of a step line by line with the prefix of
+// the step. The lines of the steps are not mixed as the writers share the
+// lock.
+type stepLogWriter struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+func (s *stepLogWriter) Write(p []byte) (int, error) {
+	s.buf = append(s.buf, p...)
+	for {
+		i := bytes.IndexByte(s.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := s.writeLine(s.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		s.buf = s.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// flush writes the last line not terminated by a newline.
+func (s *stepLogWriter) flush() error {
+	if len(s.buf) == 0 {
+		return nil
+	}
+	line := append(s.buf, '\n')
+	s.buf = nil
+	return s.writeLine(line)
+}
+
+func (s *stepLogWriter) writeLine(line []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.w.Write(append(s.prefix[:len(s.prefix):len(s.prefix)], line...))
+	return err
+}
@@ -0,0 +1,57 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"path/filepath"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/mailer"
+	"github.com/dagu-org/dagu/internal/persistence"
+)
+
+// NotifySLAMiss fires the notification handlers of the DAG for a miss of its
+// SLA: it sends the error mail if mailOn.slaMiss is set and runs the
+// handlerOn.slaMiss step with the reason in DAG_SLA_MISS. The log of the
+// step is written next to the log file.
+func NotifySLAMiss(
+	ctx context.Context,
+	dag *digraph.DAG,
+	requestID string,
+	reason string,
+	logFile string,
+	dagStore persistence.DAGStore,
+	historyStore persistence.HistoryStore,
+) error {
+	var errs []error
+
+	if dag.MailOn != nil && dag.MailOn.SLAMiss && dag.ErrorMail != nil {
+		sender := mailer.New(mailer.Config{
+			Host:     dag.SMTP.Host,
+			Port:     dag.SMTP.Port,
+			Username: dag.SMTP.Username,
+			Password: dag.SMTP.Password,
+		})
+		subject := fmt.Sprintf("%s %s (SLA missed)", dag.ErrorMail.Prefix, dag.Name)
+		body := fmt.Sprintf("<p>%s</p>", html.EscapeString(reason))
+		if err := sender.Send(ctx, dag.ErrorMail.From, []string{dag.ErrorMail.To}, subject, body, nil); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send the SLA miss mail: %w", err))
+		}
+	}
+
+	if step := dag.HandlerOn.SLAMiss; step != nil {
+		ctx = digraph.NewContext(ctx, dag, newDBClient(historyStore, dagStore), requestID, logFile)
+		ctx = digraph.WithContext(ctx, digraph.GetContext(ctx).WithEnv(digraph.EnvKeyDAGSLAMiss, reason))
+
+		logger.Info(ctx, "Handler execution started", "handler", step.Name)
+		if err := scheduler.RunHandler(ctx, *step, filepath.Dir(logFile), requestID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to run the SLA miss handler: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,34 @@
+package agent_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/agent"
+	"github.com/dagu-org/dagu/internal/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifySLAMiss(t *testing.T) {
+	th := test.Setup(t)
+	dag := th.DAG(t, "agent/sla_miss.yaml")
+
+	logDir := t.TempDir()
+	logFile := filepath.Join(logDir, "sla_miss.log")
+	err := agent.NotifySLAMiss(th.Context, dag.DAG, "request-id", "the run took 2h", logFile, th.DAGStore, th.HistoryStore)
+	require.NoError(t, err)
+
+	// The handler logs the reason to its log file in the log directory.
+	entries, err := os.ReadDir(logDir)
+	require.NoError(t, err)
+	var found bool
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(logDir, entry.Name()))
+		require.NoError(t, err)
+		if string(data) == "sla missed, the run took 2h\n" {
+			found = true
+		}
+	}
+	require.True(t, found, "the handler should log the reason")
+}
@@ -0,0 +1,65 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/fileutil"
+	"github.com/dagu-org/dagu/internal/logger"
+)
+
+// workspace is the directory created for a run of a DAG with a workspace
+// configured. The directory is <baseDir>/<DAG name>/<request ID>.
+type workspace struct {
+	dir       string
+	retention time.Duration
+}
+
+// newWorkspace returns the workspace of the run identified by requestID.
+func newWorkspace(baseDir, dagName, requestID string, retentionDays int) *workspace {
+	return &workspace{
+		dir:       filepath.Join(baseDir, fileutil.SafeName(dagName), requestID),
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+	}
+}
+
+// create creates the directory of the workspace. An existing directory is
+// reused so that a retry of the run sees the files of the original run.
+func (w *workspace) create() error {
+	if err := os.MkdirAll(w.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create workspace %q: %w", w.dir, err)
+	}
+	return nil
+}
+
+// cleanup removes the workspace if it is not retained, and the workspaces
+// of the previous runs of the DAG that are older than the retention.
+func (w *workspace) cleanup(ctx context.Context) {
+	if w.retention == 0 {
+		if err := os.RemoveAll(w.dir); err != nil {
+			logger.Error(ctx, "Failed to remove workspace", "dir", w.dir, "err", err)
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(w.dir))
+	if err != nil {
+		return
+	}
+	expiredAt := time.Now().Add(-w.retention)
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !entry.IsDir() || info.ModTime().After(expiredAt) {
+			continue
+		}
+		dir := filepath.Join(filepath.Dir(w.dir), entry.Name())
+		if dir == w.dir {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			logger.Error(ctx, "Failed to remove expired workspace", "dir", dir, "err", err)
+		}
+	}
+}
@@ -4,11 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"os/exec"
+	"io"
+	"net/url"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
-	"syscall"
 
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
@@ -18,6 +19,7 @@ import (
 	"github.com/dagu-org/dagu/internal/persistence"
 	"github.com/dagu-org/dagu/internal/persistence/model"
 	"github.com/dagu-org/dagu/internal/sock"
+	"github.com/dagu-org/dagu/internal/stringutil"
 )
 
 // New creates a new Client instance.
@@ -28,14 +30,20 @@ func New(
 	flagStore persistence.FlagStore,
 	executable string,
 	workDir string,
+	opts ...Option,
 ) Client {
-	return &client{
+	c := &client{
 		dagStore:     dagStore,
 		historyStore: historyStore,
 		flagStore:    flagStore,
-		executable:   executable,
-		workDir:      workDir,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.runner == nil {
+		c.runner = NewProcessRunner(executable, workDir)
+	}
+	return c
 }
 
 var _ Client = (*client)(nil)
@@ -44,10 +52,13 @@ type client struct {
 	dagStore     persistence.DAGStore
 	historyStore persistence.HistoryStore
 	flagStore    persistence.FlagStore
-	executable   string
-	workDir      string
+	runner       Runner
 }
 
+var (
+	ErrDAGNotRunning = errors.New("the DAG is not running")
+)
+
 var (
 	dagTemplate = []byte(`steps:
   - name: step1
@@ -91,15 +102,109 @@ func (e *client) Rename(ctx context.Context, oldID, newID string) error {
 	return nil
 }
 
+// Stop stops all the running runs of the DAG.
 func (e *client) Stop(ctx context.Context, dag *digraph.DAG) error {
 	logger.Info(ctx, "Stopping", "name", dag.Name)
-	addr := dag.SockAddr()
-	if !fileutil.FileExists(addr) {
+	var (
+		running bool
+		errs    []error
+	)
+	for _, addr := range dag.SockAddrs() {
+		if !fileutil.FileExists(addr) {
+			continue
+		}
+		running = true
+		if _, err := sock.NewClient(addr).Request("POST", "/stop"); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if !running {
 		logger.Info(ctx, "The DAG is not running", "name", dag.Name)
-		return nil
+	}
+	return errors.Join(errs...)
+}
+
+func (e *client) Approve(ctx context.Context, dag *digraph.DAG, opts ApprovalOptions) error {
+	logger.Info(ctx, "Approving", "name", dag.Name, "step", opts.Step, "approver", opts.Approver)
+	return e.decide(dag, "/approve", opts)
+}
+
+func (e *client) Reject(ctx context.Context, dag *digraph.DAG, opts ApprovalOptions) error {
+	logger.Info(ctx, "Rejecting", "name", dag.Name, "step", opts.Step, "approver", opts.Approver)
+	return e.decide(dag, "/reject", opts)
+}
+
+func (e *client) decide(dag *digraph.DAG, path string, opts ApprovalOptions) error {
+	query := url.Values{}
+	query.Set("step", opts.Step)
+	query.Set("approver", opts.Approver)
+	query.Set("comment", opts.Comment)
+	return e.control(dag, path, query)
+}
+
+func (e *client) CancelStep(ctx context.Context, dag *digraph.DAG, step string) error {
+	logger.Info(ctx, "Canceling step", "name", dag.Name, "step", step)
+	return e.control(dag, "/cancel-step", url.Values{"step": {step}})
+}
+
+func (e *client) SkipStep(ctx context.Context, dag *digraph.DAG, step string) error {
+	logger.Info(ctx, "Skipping step", "name", dag.Name, "step", step)
+	return e.control(dag, "/skip-step", url.Values{"step": {step}})
+}
+
+func (e *client) RetryStep(ctx context.Context, dag *digraph.DAG, step string) error {
+	logger.Info(ctx, "Retrying step", "name", dag.Name, "step", step)
+	return e.control(dag, "/retry-step", url.Values{"step": {step}})
+}
+
+func (e *client) SetMaxActiveRuns(ctx context.Context, dag *digraph.DAG, maxActiveRuns int) error {
+	logger.Info(ctx, "Changing max active runs", "name", dag.Name, "maxActiveRuns", maxActiveRuns)
+	return e.control(dag, "/max-active-runs", url.Values{"value": {strconv.Itoa(maxActiveRuns)}})
+}
+
+func (e *client) Pause(ctx context.Context, dag *digraph.DAG, suspendSteps bool) error {
+	logger.Info(ctx, "Pausing", "name", dag.Name, "suspendSteps", suspendSteps)
+	return e.control(dag, "/pause", url.Values{"suspend": {strconv.FormatBool(suspendSteps)}})
+}
+
+func (e *client) Resume(ctx context.Context, dag *digraph.DAG) error {
+	logger.Info(ctx, "Resuming", "name", dag.Name)
+	return e.control(dag, "/resume", nil)
+}
+
+// StreamLog streams the log of the step from the agent of the latest run of
+// the running DAG. If the step is empty, the logs of all the steps are
+// streamed with the step names. The caller must close the stream.
+func (e *client) StreamLog(ctx context.Context, dag *digraph.DAG, step string, follow bool) (io.ReadCloser, error) {
+	addr := latestSockAddr(dag)
+	if !fileutil.FileExists(addr) {
+		return nil, fmt.Errorf("%w: %s", ErrDAGNotRunning, dag.Name)
+	}
+	path := "/logs"
+	if step != "" {
+		path += "/" + url.PathEscape(step)
+	}
+	query := url.Values{"follow": {strconv.FormatBool(follow)}}
+	stream, err := sock.NewClient(addr).Stream("GET", path+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+	// Stop streaming when ctx is canceled.
+	context.AfterFunc(ctx, func() {
+		_ = stream.Close()
+	})
+	return stream, nil
+}
+
+// control sends the request to change the running DAG to the agent of its
+// latest run.
+func (e *client) control(dag *digraph.DAG, path string, query url.Values) error {
+	addr := latestSockAddr(dag)
+	if !fileutil.FileExists(addr) {
+		return fmt.Errorf("%w: %s", ErrDAGNotRunning, dag.Name)
 	}
 	client := sock.NewClient(addr)
-	_, err := client.Request("POST", "/stop")
+	_, err := client.Request("POST", path+"?"+query.Encode())
 	return err
 }
 
@@ -111,77 +216,47 @@ func (e *client) StartAsync(ctx context.Context, dag *digraph.DAG, opts StartOpt
 	}()
 }
 
-func (e *client) Start(_ context.Context, dag *digraph.DAG, opts StartOptions) error {
-	args := []string{"start"}
-	if opts.Params != "" {
-		args = append(args, "-p")
-		args = append(args, fmt.Sprintf(`"%s"`, escapeArg(opts.Params)))
-	}
-	if opts.Quiet {
-		args = append(args, "-q")
-	}
-	args = append(args, dag.Location)
-	// nolint:gosec
-	cmd := exec.Command(e.executable, args...)
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Pgid: 0}
-	cmd.Dir = e.workDir
-	cmd.Env = os.Environ()
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	err := cmd.Start()
-	if err != nil {
-		return err
-	}
-	return cmd.Wait()
+func (e *client) Start(ctx context.Context, dag *digraph.DAG, opts StartOptions) error {
+	return e.runner.Start(ctx, dag, opts)
 }
 
-func (e *client) Restart(_ context.Context, dag *digraph.DAG, opts RestartOptions) error {
-	args := []string{"restart"}
-	if opts.Quiet {
-		args = append(args, "-q")
-	}
-	args = append(args, dag.Location)
-	// nolint:gosec
-	cmd := exec.Command(e.executable, args...)
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Pgid: 0}
-	cmd.Dir = e.workDir
-	cmd.Env = os.Environ()
-	err := cmd.Start()
-	if err != nil {
-		return err
-	}
-	return cmd.Wait()
+func (e *client) Restart(ctx context.Context, dag *digraph.DAG, opts RestartOptions) error {
+	return e.runner.Restart(ctx, dag, opts)
 }
 
-func (e *client) Retry(_ context.Context, dag *digraph.DAG, requestID string) error {
-	args := []string{"retry"}
-	args = append(args, fmt.Sprintf("--req=%s", requestID))
-	args = append(args, dag.Location)
-	// nolint:gosec
-	cmd := exec.Command(e.executable, args...)
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Pgid: 0}
-	cmd.Dir = e.workDir
-	cmd.Env = os.Environ()
-	err := cmd.Start()
-	if err != nil {
-		return err
-	}
-	return cmd.Wait()
+func (e *client) Retry(ctx context.Context, dag *digraph.DAG, requestID string, opts RetryOptions) error {
+	return e.runner.Retry(ctx, dag, requestID, opts)
 }
 
+// GetCurrentStatus returns the status of the latest run of the DAG served
+// by its agent, or the default status if the DAG is not running.
 func (*client) GetCurrentStatus(_ context.Context, dag *digraph.DAG) (*model.Status, error) {
-	client := sock.NewClient(dag.SockAddr())
-	ret, err := client.Request("GET", "/status")
+	runs, err := servedRuns(dag)
 	if err != nil {
-		if errors.Is(err, sock.ErrTimeout) {
-			return nil, err
-		}
+		return nil, err
+	}
+	if len(runs) == 0 {
 		// The DAG is not running so return the default status
 		status := model.NewStatusFactory(dag).CreateDefault()
 		return &status, nil
 	}
-	return model.StatusFromJSON(ret)
+	return &runs[len(runs)-1].status, nil
+}
+
+// GetActiveRuns returns the statuses of the running runs of the DAG in the
+// order they started.
+func (*client) GetActiveRuns(_ context.Context, dag *digraph.DAG) ([]model.Status, error) {
+	runs, err := servedRuns(dag)
+	if err != nil {
+		return nil, err
+	}
+	var ret []model.Status
+	for _, run := range runs {
+		if run.status.Status.IsActive() {
+			ret = append(ret, run.status)
+		}
+	}
+	return ret, nil
 }
 
 func (e *client) GetStatusByRequestID(ctx context.Context, dag *digraph.DAG, requestID string) (
@@ -191,8 +266,8 @@ func (e *client) GetStatusByRequestID(ctx context.Context, dag *digraph.DAG, req
 	if err != nil {
 		return nil, err
 	}
-	status, _ := e.GetCurrentStatus(ctx, dag)
-	if status != nil && status.RequestID != requestID {
+	runs, _ := servedRuns(dag)
+	if !slices.ContainsFunc(runs, func(run servedRun) bool { return run.status.RequestID == requestID }) {
 		// if the request id is not matched then correct the status
 		ret.Status.CorrectRunningStatus()
 	}
@@ -200,12 +275,59 @@ func (e *client) GetStatusByRequestID(ctx context.Context, dag *digraph.DAG, req
 }
 
 func (*client) currentStatus(_ context.Context, dag *digraph.DAG) (*model.Status, error) {
-	client := sock.NewClient(dag.SockAddr())
-	ret, err := client.Request("GET", "/status")
+	runs, err := servedRuns(dag)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get status: %w", err)
 	}
-	return model.StatusFromJSON(ret)
+	if len(runs) == 0 {
+		return nil, fmt.Errorf("failed to get status: %w", ErrDAGNotRunning)
+	}
+	return &runs[len(runs)-1].status, nil
+}
+
+// servedRun is a run of the DAG served by its agent on a socket of the DAG.
+type servedRun struct {
+	addr   string
+	status model.Status
+}
+
+// servedRuns returns the runs of the DAG served by their agents in the
+// order they started. It returns error if an agent does not answer in time.
+func servedRuns(dag *digraph.DAG) ([]servedRun, error) {
+	var runs []servedRun
+	for _, addr := range dag.SockAddrs() {
+		ret, err := sock.NewClient(addr).Request("GET", "/status")
+		if err != nil {
+			if errors.Is(err, sock.ErrTimeout) {
+				return nil, err
+			}
+			continue
+		}
+		status, err := model.StatusFromJSON(ret)
+		if err != nil {
+			continue
+		}
+		runs = append(runs, servedRun{addr: addr, status: *status})
+	}
+	slices.SortStableFunc(runs, func(a, b servedRun) int {
+		startedA, _ := stringutil.ParseTime(a.status.StartedAt)
+		startedB, _ := stringutil.ParseTime(b.status.StartedAt)
+		return startedA.Compare(startedB)
+	})
+	return runs, nil
+}
+
+// latestSockAddr returns the socket address of the latest run of the DAG,
+// or the socket address of the DAG if it is not running.
+func latestSockAddr(dag *digraph.DAG) string {
+	if dag.Concurrency.Limit() == 1 {
+		return dag.SockAddr()
+	}
+	runs, _ := servedRuns(dag)
+	if len(runs) == 0 {
+		return dag.SockAddr()
+	}
+	return runs[len(runs)-1].addr
 }
 
 func (e *client) GetLatestStatus(ctx context.Context, dag *digraph.DAG) (model.Status, error) {
@@ -231,19 +353,34 @@ func (e *client) GetRecentHistory(ctx context.Context, dag *digraph.DAG, n int)
 	return e.historyStore.ReadStatusRecent(ctx, dag.Location, n)
 }
 
+func (e *client) QueryHistory(ctx context.Context, query HistoryQuery) (*persistence.HistoryQueryResult, error) {
+	if query.Tag != "" {
+		dags, _, err := e.dagStore.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list DAGs: %w", err)
+		}
+		keys := []string{}
+		for _, dag := range dags {
+			if slices.ContainsFunc(dag.Tags, func(tag string) bool {
+				return strings.EqualFold(tag, query.Tag)
+			}) {
+				keys = append(keys, dag.Location)
+			}
+		}
+		query.Keys = keys
+	}
+	return e.historyStore.Query(ctx, query.HistoryQuery)
+}
+
 var errDAGIsRunning = errors.New("the DAG is running")
 
 func (e *client) UpdateStatus(ctx context.Context, dag *digraph.DAG, status model.Status) error {
-	client := sock.NewClient(dag.SockAddr())
-	res, err := client.Request("GET", "/status")
+	runs, err := servedRuns(dag)
 	if err != nil {
-		if errors.Is(err, sock.ErrTimeout) {
-			return err
-		}
-	} else {
-		unmarshalled, _ := model.StatusFromJSON(res)
-		if unmarshalled != nil && unmarshalled.RequestID == status.RequestID &&
-			unmarshalled.Status == scheduler.StatusRunning {
+		return err
+	}
+	for _, run := range runs {
+		if run.status.RequestID == status.RequestID && run.status.Status.IsActive() {
 			return errDAGIsRunning
 		}
 	}
@@ -400,3 +537,16 @@ func fromPtr[T any](p *T) T {
 	}
 	return *p
 }
+
+// partialRunArgs returns the command line flags to select the steps of a
+// partial run.
+func partialRunArgs(steps []string, downstream bool) []string {
+	if len(steps) == 0 {
+		return nil
+	}
+	args := []string{fmt.Sprintf("--steps=%s", strings.Join(steps, ","))}
+	if downstream {
+		args = append(args, "--downstream")
+	}
+	return args
+}
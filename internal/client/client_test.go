@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os/exec"
 	"path/filepath"
 	"testing"
 	"time"
@@ -13,7 +14,9 @@ import (
 	"github.com/dagu-org/dagu/internal/client"
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence"
 	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/procutil"
 	"github.com/dagu-org/dagu/internal/sock"
 	"github.com/dagu-org/dagu/internal/test"
 )
@@ -173,7 +176,7 @@ func TestClient_RunDAG(t *testing.T) {
 		previousRequestID := status.RequestID
 		previousParams := status.Params
 
-		err = cli.Retry(ctx, dag.DAG, previousRequestID)
+		err = cli.Retry(ctx, dag.DAG, previousRequestID, client.RetryOptions{})
 		require.NoError(t, err)
 
 		// Wait for the DAG to finish
@@ -308,6 +311,164 @@ func TestClient_ReadHistory(t *testing.T) {
 	})
 }
 
+func TestClient_QueryHistory(t *testing.T) {
+	th := test.Setup(t)
+
+	ctx := th.Context
+	cli := th.Client
+
+	// newRun creates the DAG with the tags and records a run of it.
+	newRun := func(t *testing.T, name, tags string, status scheduler.Status) {
+		t.Helper()
+
+		id, err := cli.CreateDAG(ctx, name)
+		require.NoError(t, err)
+		spec := "tags: " + tags + "\nsteps:\n  - name: step1\n    command: echo hello\n"
+		require.NoError(t, cli.UpdateDAG(ctx, id, spec))
+		dagStatus, err := cli.GetStatus(ctx, id)
+		require.NoError(t, err)
+
+		requestID := name + "-request-id"
+		require.NoError(t, th.HistoryStore.Open(ctx, dagStatus.DAG.Location, time.Now(), requestID))
+		require.NoError(t, th.HistoryStore.Write(ctx, testNewStatus(dagStatus.DAG, requestID, status, scheduler.NodeStatusSuccess)))
+		require.NoError(t, th.HistoryStore.Close(ctx))
+	}
+	newRun(t, "query-etl", "ETL,daily", scheduler.StatusSuccess)
+	newRun(t, "query-report", "report", scheduler.StatusError)
+
+	t.Run("Tag", func(t *testing.T) {
+		result, err := cli.QueryHistory(ctx, client.HistoryQuery{Tag: "etl"})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Total)
+		require.Equal(t, "query-etl-request-id", result.Runs[0].Status.RequestID)
+	})
+
+	t.Run("UnknownTag", func(t *testing.T) {
+		result, err := cli.QueryHistory(ctx, client.HistoryQuery{Tag: "unknown"})
+		require.NoError(t, err)
+		require.Zero(t, result.Total)
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		result, err := cli.QueryHistory(ctx, client.HistoryQuery{
+			HistoryQuery: persistence.HistoryQuery{Statuses: []scheduler.Status{scheduler.StatusError}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Total)
+		require.Equal(t, "query-report-request-id", result.Runs[0].Status.RequestID)
+	})
+}
+
+func TestClient_RecoverLostRuns(t *testing.T) {
+	th := test.Setup(t)
+
+	ctx := th.Context
+	cli := th.Client
+
+	// newRun creates the DAG with the running run recorded.
+	newRun := func(t *testing.T, name, spec string, opts ...model.StatusOption) (*digraph.DAG, string) {
+		t.Helper()
+
+		id, err := cli.CreateDAG(ctx, name)
+		require.NoError(t, err)
+		require.NoError(t, cli.UpdateDAG(ctx, id, spec))
+		dagStatus, err := cli.GetStatus(ctx, id)
+		require.NoError(t, err)
+
+		requestID := name + "-request-id"
+		require.NoError(t, th.HistoryStore.Open(ctx, dagStatus.DAG.Location, time.Now(), requestID))
+		status := testNewStatus(dagStatus.DAG, requestID, scheduler.StatusRunning, scheduler.NodeStatusRunning)
+		for _, opt := range opts {
+			opt(&status)
+		}
+		require.NoError(t, th.HistoryStore.Write(ctx, status))
+		require.NoError(t, th.HistoryStore.Close(ctx))
+		return dagStatus.DAG, requestID
+	}
+
+	spec := "steps:\n  - name: \"1\"\n    command: \"true\"\n"
+	lost, lostReqID := newRun(t, "lost", spec)
+	retried, retriedReqID := newRun(t, "retried", "onAgentLost: retry\n"+spec)
+	alive, aliveReqID := newRun(t, "alive", spec)
+
+	// A process stands for the agents of the runs recorded with its PID.
+	proc := exec.Command("sleep", "60")
+	require.NoError(t, proc.Start())
+	t.Cleanup(func() {
+		_ = proc.Process.Kill()
+		_ = proc.Wait()
+	})
+	withAgent := func(hostname, startTime string) model.StatusOption {
+		return func(s *model.Status) {
+			s.PID = model.PID(proc.Process.Pid)
+			s.Hostname = hostname
+			s.ProcStartTime = startTime
+		}
+	}
+	hostname := procutil.Hostname()
+	running, runningReqID := newRun(t, "running", spec, withAgent(hostname, procutil.StartTime(proc.Process.Pid)))
+	// The PID was reused by another process after a reboot.
+	reused, reusedReqID := newRun(t, "reused", spec, withAgent(hostname, "another-boot:1"))
+	// The run on another host is not checked even if the PID does not
+	// exist on this host.
+	remote, remoteReqID := newRun(t, "remote", spec, model.WithAgentProcess("another-"+hostname, ""))
+
+	// The agent of the alive run answers on the socket.
+	socketServer, err := sock.NewServer(alive.SockAddr(), func(w http.ResponseWriter, _ *http.Request) {
+		status := model.NewStatusFactory(alive).Create(aliveReqID, scheduler.StatusRunning, 0, time.Now())
+		jsonData, _ := json.Marshal(status)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(jsonData)
+	})
+	require.NoError(t, err)
+	go func() {
+		_ = socketServer.Serve(ctx, nil)
+	}()
+	t.Cleanup(func() {
+		_ = socketServer.Shutdown(ctx)
+	})
+	require.Eventually(t, func() bool {
+		status, err := cli.GetCurrentStatus(ctx, alive)
+		return err == nil && status.RequestID == aliveReqID
+	}, time.Second, 10*time.Millisecond)
+
+	// The lost runs of the DAGs to retry are left for the scheduler.
+	runs, err := cli.RecoverLostRuns(ctx, client.RecoverOptions{})
+	require.NoError(t, err)
+	var lostReqIDs []string
+	for _, run := range runs {
+		require.False(t, run.Retried)
+		lostReqIDs = append(lostReqIDs, run.RequestID)
+	}
+	require.ElementsMatch(t, []string{lostReqID, reusedReqID}, lostReqIDs)
+
+	for _, run := range []struct {
+		dag       *digraph.DAG
+		requestID string
+	}{{lost, lostReqID}, {reused, reusedReqID}} {
+		status, err := cli.GetStatusByRequestID(ctx, run.dag, run.requestID)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusError, status.Status)
+		require.Equal(t, scheduler.NodeStatusError, status.Nodes[0].Status)
+		require.Equal(t, client.ErrAgentLost.Error(), status.Nodes[0].Error)
+		require.NotEmpty(t, status.FinishedAt)
+	}
+
+	for _, run := range []struct {
+		dag       *digraph.DAG
+		requestID string
+	}{{retried, retriedReqID}, {alive, aliveReqID}, {running, runningReqID}, {remote, remoteReqID}} {
+		status, err := th.HistoryStore.FindByRequestID(ctx, run.dag.Location, run.requestID)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.StatusRunning, status.Status.Status)
+	}
+
+	// The failed run is not lost any more.
+	runs, err = cli.RecoverLostRuns(ctx, client.RecoverOptions{})
+	require.NoError(t, err)
+	require.Empty(t, runs)
+}
+
 func testNewStatus(dag *digraph.DAG, requestID string, status scheduler.Status, nodeStatus scheduler.NodeStatus) model.Status {
 	nodes := []scheduler.NodeData{{State: scheduler.NodeState{Status: nodeStatus}}}
 	startedAt := model.Time(time.Now())
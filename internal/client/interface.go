@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"io"
 	"path/filepath"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/dags"
@@ -19,11 +21,23 @@ type Client interface {
 	StartAsync(ctx context.Context, dag *digraph.DAG, opts StartOptions)
 	Start(ctx context.Context, dag *digraph.DAG, opts StartOptions) error
 	Restart(ctx context.Context, dag *digraph.DAG, opts RestartOptions) error
-	Retry(ctx context.Context, dag *digraph.DAG, requestID string) error
+	Retry(ctx context.Context, dag *digraph.DAG, requestID string, opts RetryOptions) error
+	Approve(ctx context.Context, dag *digraph.DAG, opts ApprovalOptions) error
+	Reject(ctx context.Context, dag *digraph.DAG, opts ApprovalOptions) error
+	CancelStep(ctx context.Context, dag *digraph.DAG, step string) error
+	SkipStep(ctx context.Context, dag *digraph.DAG, step string) error
+	RetryStep(ctx context.Context, dag *digraph.DAG, step string) error
+	SetMaxActiveRuns(ctx context.Context, dag *digraph.DAG, maxActiveRuns int) error
+	Pause(ctx context.Context, dag *digraph.DAG, suspendSteps bool) error
+	Resume(ctx context.Context, dag *digraph.DAG) error
+	RecoverLostRuns(ctx context.Context, opts RecoverOptions) ([]LostRun, error)
+	StreamLog(ctx context.Context, dag *digraph.DAG, step string, follow bool) (io.ReadCloser, error)
 	GetCurrentStatus(ctx context.Context, dag *digraph.DAG) (*model.Status, error)
+	GetActiveRuns(ctx context.Context, dag *digraph.DAG) ([]model.Status, error)
 	GetStatusByRequestID(ctx context.Context, dag *digraph.DAG, requestID string) (*model.Status, error)
 	GetLatestStatus(ctx context.Context, dag *digraph.DAG) (model.Status, error)
 	GetRecentHistory(ctx context.Context, dag *digraph.DAG, n int) []model.StatusFile
+	QueryHistory(ctx context.Context, query HistoryQuery) (*persistence.HistoryQueryResult, error)
 	UpdateStatus(ctx context.Context, dag *digraph.DAG, status model.Status) error
 	UpdateDAG(ctx context.Context, id string, spec string) error
 	DeleteDAG(ctx context.Context, id, loc string) error
@@ -38,12 +52,61 @@ type Client interface {
 type StartOptions struct {
 	Params string
 	Quiet  bool
+	// Steps is the names of the steps to run in a partial run.
+	Steps []string
+	// Downstream runs the steps downstream of Steps as well.
+	Downstream bool
+	// RequestID is the request ID of the run. It is generated if empty.
+	RequestID string
+	// Upstream is the run of another DAG that triggered the run.
+	Upstream *model.RunRef
+	// LogicalDate is the scheduled time the run is for.
+	LogicalDate time.Time
+}
+
+type ApprovalOptions struct {
+	// Step is the name of the step waiting for the approval.
+	Step string
+	// Approver is the identity of the user who approves or rejects the step.
+	Approver string
+	// Comment is recorded with the approval or rejection.
+	Comment string
 }
 
 type RestartOptions struct {
 	Quiet bool
 }
 
+type RetryOptions struct {
+	// Steps is the names of the steps to re-run. If it's empty, the failed
+	// and canceled steps are re-run.
+	Steps []string
+	// Downstream re-runs the steps downstream of Steps as well.
+	Downstream bool
+}
+
+type RecoverOptions struct {
+	// Retry retries the lost runs of the DAGs with onAgentLost: retry.
+	// Otherwise, the runs of those DAGs are left for the scheduler, which
+	// retries them.
+	Retry bool
+}
+
+// LostRun is a run marked failed because its agent was lost.
+type LostRun struct {
+	DAG       *digraph.DAG
+	RequestID string
+	// Retried is true if the run is retried.
+	Retried bool
+}
+
+// HistoryQuery filters the runs of all the DAGs in the history.
+type HistoryQuery struct {
+	persistence.HistoryQuery
+	// Tag selects the runs of the DAGs with the tag, case-insensitively.
+	Tag string
+}
+
 type DAGStatus struct {
 	File      string
 	Dir       string
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/procutil"
+	"github.com/dagu-org/dagu/internal/stringutil"
+)
+
+// ErrAgentLost is the error of the steps of a run whose agent was lost,
+// e.g. because the host crashed or rebooted in the middle of the run.
+var ErrAgentLost = errors.New("agent lost: the run was interrupted, e.g. by a crash or reboot of the host")
+
+// lostRunHistorySize is the number of the recent runs of each DAG checked
+// for the lost agents.
+const lostRunHistorySize = 10
+
+// LostRunCheckInterval is the interval to check the runs for the lost
+// agents.
+const LostRunCheckInterval = time.Minute
+
+// RecoverLostRuns marks the runs recorded as running or paused whose agent
+// is gone failed with ErrAgentLost. The agent is gone if it does not answer
+// on a socket of the DAG and its process, started on this host at the
+// recorded time, does not exist any more. The
+// lost runs of the DAGs with onAgentLost: retry are retried in the
+// background if opts.Retry is true.
+func (e *client) RecoverLostRuns(ctx context.Context, opts RecoverOptions) ([]LostRun, error) {
+	dags, _, err := e.dagStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DAGs: %w", err)
+	}
+
+	var lost []LostRun
+	for _, dag := range dags {
+		retry := dag.OnAgentLost == digraph.AgentLostRetry
+		if retry && !opts.Retry {
+			continue
+		}
+		for _, file := range e.historyStore.ReadStatusRecent(ctx, dag.Location, lostRunHistorySize) {
+			status := file.Status
+			if !status.Status.IsActive() || !isAgentLost(dag, status) {
+				continue
+			}
+			logger.Warn(ctx, "Agent lost", "dag", dag.Name, "reqId", status.RequestID, "pid", status.PID)
+			if err := e.historyStore.Update(ctx, dag.Location, status.RequestID, markAgentLost(status)); err != nil {
+				logger.Error(ctx, "Failed to mark the lost run failed", "dag", dag.Name, "reqId", status.RequestID, "err", err)
+				continue
+			}
+			if retry {
+				go func(dag *digraph.DAG, requestID string) {
+					logger.Info(ctx, "Retrying the lost run", "dag", dag.Name, "reqId", requestID)
+					if err := e.Retry(ctx, dag, requestID, RetryOptions{}); err != nil {
+						logger.Error(ctx, "Failed to retry the lost run", "dag", dag.Name, "reqId", requestID, "err", err)
+					}
+				}(dag, status.RequestID)
+			}
+			lost = append(lost, LostRun{DAG: dag, RequestID: status.RequestID, Retried: retry})
+		}
+	}
+	return lost, nil
+}
+
+// WatchLostRuns recovers the lost runs now and every LostRunCheckInterval
+// until the context is canceled.
+func WatchLostRuns(ctx context.Context, cli Client, opts RecoverOptions) {
+	ticker := time.NewTicker(LostRunCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := cli.RecoverLostRuns(ctx, opts); err != nil {
+			logger.Error(ctx, "Failed to recover the lost runs", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// isAgentLost returns true if the agent of the running run does not answer
+// on the socket and its process does not exist. The agent writes the
+// running status only while it is serving the socket, so the process is
+// checked only in case the socket file was removed, e.g. by a cleaner of
+// the temporary directory. The agent of a run on another host sharing the
+// data directory is never lost as neither can be checked from this host.
+func isAgentLost(dag *digraph.DAG, status model.Status) bool {
+	if status.Hostname != "" && status.Hostname != procutil.Hostname() {
+		return false
+	}
+	runs, err := servedRuns(dag)
+	if err != nil {
+		// An agent is busy.
+		return false
+	}
+	for _, run := range runs {
+		if run.status.RequestID == status.RequestID {
+			return false
+		}
+	}
+	return !agentExists(int(status.PID), status.ProcStartTime)
+}
+
+// agentExists returns true if the process of the agent exists. A process
+// with the PID that started at another time than the agent, e.g. after a
+// reboot, is not the agent. This process is never the agent of a run not
+// answering on the socket, even when the run is executed in process, so it
+// does not count.
+func agentExists(pid int, startTime string) bool {
+	if pid <= 0 || pid == os.Getpid() {
+		return false
+	}
+	if startTime != "" {
+		if current := procutil.StartTime(pid); current != "" {
+			return current == startTime
+		}
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// markAgentLost returns the status of the run marked failed because of the
+// lost agent. The steps that were running or waiting fail with
+// ErrAgentLost.
+func markAgentLost(status model.Status) model.Status {
+	now := stringutil.FormatTime(time.Now())
+	status.Status = scheduler.StatusError
+	status.StatusText = status.Status.String()
+	status.FinishedAt = now
+
+	// The nodes are copied as they may be shared with the cache of the
+	// history store.
+	mark := func(node *model.Node) *model.Node {
+		if node == nil {
+			return nil
+		}
+		copied := *node
+		switch copied.Status {
+		case scheduler.NodeStatusRunning, scheduler.NodeStatusQueued, scheduler.NodeStatusWaiting:
+			copied.Status = scheduler.NodeStatusError
+			copied.StatusText = copied.Status.String()
+			copied.Error = ErrAgentLost.Error()
+			copied.FinishedAt = now
+		}
+		return &copied
+	}
+	nodes := make([]*model.Node, len(status.Nodes))
+	for i, node := range status.Nodes {
+		nodes[i] = mark(node)
+	}
+	status.Nodes = nodes
+	status.OnExit = mark(status.OnExit)
+	status.OnSuccess = mark(status.OnSuccess)
+	status.OnFailure = mark(status.OnFailure)
+	status.OnCancel = mark(status.OnCancel)
+	return status
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/stringutil"
+)
+
+// Runner runs the DAGs started by the Client. Each method blocks until the
+// run finishes.
+type Runner interface {
+	Start(ctx context.Context, dag *digraph.DAG, opts StartOptions) error
+	Restart(ctx context.Context, dag *digraph.DAG, opts RestartOptions) error
+	Retry(ctx context.Context, dag *digraph.DAG, requestID string, opts RetryOptions) error
+}
+
+// Option is an option of the Client.
+type Option func(*client)
+
+// WithRunner sets the Runner of the DAGs. The DAGs run in the dagu processes
+// by default.
+func WithRunner(runner Runner) Option {
+	return func(c *client) {
+		c.runner = runner
+	}
+}
+
+// NewProcessRunner creates a Runner that runs each DAG in a new process of
+// the executable.
+func NewProcessRunner(executable, workDir string) Runner {
+	return &processRunner{executable: executable, workDir: workDir}
+}
+
+var _ Runner = (*processRunner)(nil)
+
+type processRunner struct {
+	executable string
+	workDir    string
+}
+
+func (r *processRunner) Start(_ context.Context, dag *digraph.DAG, opts StartOptions) error {
+	args := []string{"start"}
+	if opts.Params != "" {
+		args = append(args, "-p")
+		args = append(args, fmt.Sprintf(`"%s"`, escapeArg(opts.Params)))
+	}
+	if opts.Quiet {
+		args = append(args, "-q")
+	}
+	args = append(args, partialRunArgs(opts.Steps, opts.Downstream)...)
+	if opts.RequestID != "" {
+		args = append(args, fmt.Sprintf("--req=%s", opts.RequestID))
+	}
+	if opts.Upstream != nil {
+		args = append(args, fmt.Sprintf("--upstream=%s", opts.Upstream.Name))
+		args = append(args, fmt.Sprintf("--upstream-req=%s", opts.Upstream.RequestID))
+	}
+	if !opts.LogicalDate.IsZero() {
+		args = append(args, fmt.Sprintf("--logical-date=%s", stringutil.FormatTime(opts.LogicalDate)))
+	}
+	args = append(args, dag.Location)
+	cmd := r.command(args)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+	return cmd.Wait()
+}
+
+func (r *processRunner) Restart(_ context.Context, dag *digraph.DAG, opts RestartOptions) error {
+	args := []string{"restart"}
+	if opts.Quiet {
+		args = append(args, "-q")
+	}
+	args = append(args, dag.Location)
+	cmd := r.command(args)
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+	return cmd.Wait()
+}
+
+func (r *processRunner) Retry(_ context.Context, dag *digraph.DAG, requestID string, opts RetryOptions) error {
+	args := []string{"retry"}
+	args = append(args, fmt.Sprintf("--req=%s", requestID))
+	args = append(args, partialRunArgs(opts.Steps, opts.Downstream)...)
+	args = append(args, dag.Location)
+	cmd := r.command(args)
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+	return cmd.Wait()
+}
+
+func (r *processRunner) command(args []string) *exec.Cmd {
+	// nolint:gosec
+	cmd := exec.Command(r.executable, args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Pgid: 0}
+	cmd.Dir = r.workDir
+	cmd.Env = os.Environ()
+	return cmd
+}
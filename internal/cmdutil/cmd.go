@@ -61,7 +61,7 @@ func SplitCommandWithSub(cmd string) (string, []string, error) {
 			// Escape the command
 			command[i] = escapeReplacer.Replace(command[i])
 			// Substitute command in the command.
-			command[i], err = substituteCommands(command[i])
+			command[i], err = substituteCommands(command[i], nil)
 			if err != nil {
 				return "", nil, fmt.Errorf("failed to substitute command: %w", err)
 			}
@@ -18,6 +18,7 @@ type EvalOptions struct {
 	ExpandEnv  bool
 	Substitute bool
 	Variables  []map[string]string
+	Env        []string
 }
 
 type EvalOption func(*EvalOptions)
@@ -28,6 +29,15 @@ func WithVariables(vars map[string]string) EvalOption {
 	}
 }
 
+// WithEnv adds the environment variables in the form of KEY=VALUE to the
+// process environment for expanding the variables and running the commands.
+// The later entries take precedence.
+func WithEnv(envs []string) EvalOption {
+	return func(opts *EvalOptions) {
+		opts.Env = append(opts.Env, envs...)
+	}
+}
+
 func WithoutExpandEnv() EvalOption {
 	return func(opts *EvalOptions) {
 		opts.ExpandEnv = false
@@ -94,18 +104,18 @@ func EvalString(ctx context.Context, input string, opts ...EvalOption) (string,
 	}
 	value := input
 	for _, vars := range options.Variables {
-		value = ExpandReferences(ctx, value, vars)
+		value = expandReferences(ctx, value, vars, options.lookupEnv)
 		value = replaceVars(value, vars)
 	}
 	if options.Substitute {
 		var err error
-		value, err = substituteCommands(value)
+		value, err = substituteCommands(value, options.Env)
 		if err != nil {
 			return "", fmt.Errorf("failed to substitute string in %q: %w", input, err)
 		}
 	}
 	if options.ExpandEnv {
-		value = os.ExpandEnv(value)
+		value = options.expandEnv(value)
 	}
 	return value, nil
 }
@@ -118,13 +128,13 @@ func EvalIntString(ctx context.Context, input string, opts ...EvalOption) (int,
 	}
 	value := input
 	for _, vars := range options.Variables {
-		value = ExpandReferences(ctx, value, vars)
+		value = expandReferences(ctx, value, vars, options.lookupEnv)
 		value = replaceVars(value, vars)
 	}
 	if options.ExpandEnv {
-		value = os.ExpandEnv(value)
+		value = options.expandEnv(value)
 	}
-	value, err := substituteCommands(value)
+	value, err := substituteCommands(value, options.Env)
 	if err != nil {
 		return 0, err
 	}
@@ -177,14 +187,14 @@ func processStructFields(ctx context.Context, v reflect.Value, opts *EvalOptions
 
 			if opts.Substitute {
 				var err error
-				value, err = substituteCommands(value)
+				value, err = substituteCommands(value, opts.Env)
 				if err != nil {
 					return fmt.Errorf("field %q: %w", t.Field(i).Name, err)
 				}
 			}
 
 			if opts.ExpandEnv {
-				value = os.ExpandEnv(value)
+				value = opts.expandEnv(value)
 			}
 
 			field.SetString(value)
@@ -206,6 +216,12 @@ func processStructFields(ctx context.Context, v reflect.Value, opts *EvalOptions
 // If dataMap[name] is invalid JSON or the sub-path does not exist,
 // the placeholder is left as-is (or you could handle it differently).
 func ExpandReferences(ctx context.Context, input string, dataMap map[string]string) string {
+	return expandReferences(ctx, input, dataMap, os.LookupEnv)
+}
+
+func expandReferences(
+	ctx context.Context, input string, dataMap map[string]string, lookupEnv func(string) (string, bool),
+) string {
 	// Regex to match patterns like ${FOO.bar.baz}, capturing:
 	//   group 1 => FOO  (the top-level name)
 	//   group 2 => .bar.baz (the path portion)
@@ -241,7 +257,7 @@ func ExpandReferences(ctx context.Context, input string, dataMap map[string]stri
 		jsonStr, ok := dataMap[name]
 		if !ok {
 			// Find the variable from the environment
-			val, ok := os.LookupEnv(name)
+			val, ok := lookupEnv(name)
 			if !ok {
 				// Not found => leave as-is or handle otherwise
 				return match
@@ -307,6 +323,27 @@ func replaceVars(template string, vars map[string]string) string {
 	})
 }
 
+// lookupEnv looks up the variable in the environment variables of the
+// options first and then in the process environment.
+func (opts *EvalOptions) lookupEnv(key string) (string, bool) {
+	prefix := key + "="
+	for i := len(opts.Env) - 1; i >= 0; i-- {
+		if value, ok := strings.CutPrefix(opts.Env[i], prefix); ok {
+			return value, true
+		}
+	}
+	return os.LookupEnv(key)
+}
+
+// expandEnv replaces ${var} or $var in the string with the environment
+// variables of the options or the process.
+func (opts *EvalOptions) expandEnv(s string) string {
+	return os.Expand(s, func(key string) string {
+		value, _ := opts.lookupEnv(key)
+		return value
+	})
+}
+
 func newEvalOptions() *EvalOptions {
 	return &EvalOptions{
 		ExpandEnv:  true,
@@ -343,3 +343,25 @@ func TestExpandReferences(t *testing.T) {
 		})
 	}
 }
+
+func TestEvalStringWithEnv(t *testing.T) {
+	t.Setenv("TEST_PROCESS_VAR", "process")
+
+	ctx := context.Background()
+	env := []string{"TEST_RUN_VAR=first", "1=one", "TEST_RUN_VAR=second", `TEST_RUN_JSON={"a": "b"}`}
+
+	got, err := EvalString(ctx, "$TEST_RUN_VAR $1 ${TEST_PROCESS_VAR}", WithEnv(env))
+	require.NoError(t, err)
+	require.Equal(t, "second one process", got)
+
+	got, err = EvalString(ctx, "`echo $TEST_RUN_VAR`", WithEnv(env))
+	require.NoError(t, err)
+	require.Equal(t, "second", got)
+
+	got, err = EvalString(ctx, "${TEST_RUN_JSON.a}", WithEnv(env), WithVariables(map[string]string{}))
+	require.NoError(t, err)
+	require.Equal(t, "b", got)
+
+	_, ok := os.LookupEnv("TEST_RUN_VAR")
+	require.False(t, ok)
+}
@@ -9,10 +9,11 @@ import (
 )
 
 // runCommand executes cmdStr in a shell, capturing stdout (and ignoring stderr).
-func runCommand(cmdStr string) (string, error) {
+// The env is added to the environment of the process.
+func runCommand(cmdStr string, env []string) (string, error) {
 	sh := GetShellCommand("")
 	cmd := exec.Command(sh, "-c", cmdStr)
-	cmd.Env = os.Environ()
+	cmd.Env = append(os.Environ(), env...)
 
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
@@ -31,8 +32,9 @@ func runCommand(cmdStr string) (string, error) {
 
 // substituteCommands scans for backtick-delimited commands, including "escaped" backticks
 // (i.e. a backslash immediately before a backtick). If we see "\`", we treat it as a real
-// backtick delimiter, not a literal backslash + backtick. Commands are executed via runCommand().
-func substituteCommands(input string) (string, error) {
+// backtick delimiter, not a literal backslash + backtick. Commands are executed via runCommand()
+// with the env added to the environment of the process.
+func substituteCommands(input string, env []string) (string, error) {
 	var result strings.Builder     // final output
 	var cmdBuilder strings.Builder // accumulates text inside a command
 	inCommand := false             // whether we're currently capturing a command
@@ -58,7 +60,7 @@ func substituteCommands(input string) (string, error) {
 					result.WriteString("``")
 				} else {
 					// We are closing a command
-					output, err := runCommand(cmdBuilder.String())
+					output, err := runCommand(cmdBuilder.String(), env)
 					if err != nil {
 						return "", err
 					}
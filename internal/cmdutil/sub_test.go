@@ -111,7 +111,7 @@ func TestSubstituteCommands(t *testing.T) {
 			}
 
 			// Run test
-			got, err := substituteCommands(tt.input)
+			got, err := substituteCommands(tt.input, nil)
 
 			// Check error
 			if (err != nil) != tt.wantErr {
@@ -173,7 +173,7 @@ func TestSubstituteCommandsEdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := substituteCommands(tt.input)
+			got, err := substituteCommands(tt.input, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("substituteCommands() error = %v, wantErr %v", err, tt.wantErr)
 				return
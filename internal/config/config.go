@@ -76,6 +76,131 @@ type Config struct {
 
 	// TLS configuration
 	TLS *TLSConfig `mapstructure:"tls"`
+
+	// Pools maps the name of a resource pool to the number of slots.
+	// Steps that reference the same pool share the slots across DAG runs.
+	Pools map[string]int `mapstructure:"pools"`
+
+	// StepCache configures the eviction of the cached step results.
+	StepCache StepCacheConfig `mapstructure:"stepCache"`
+
+	// Scheduler configures the scheduler process.
+	Scheduler SchedulerConfig `mapstructure:"scheduler"`
+
+	// Calendars maps the name of an exclusion calendar to its definition.
+	// DAGs reference the calendars by name with excludeCalendars.
+	Calendars map[string]CalendarConfig `mapstructure:"calendars"`
+
+	// ExecutionMode is how the scheduler and the web server run the DAGs.
+	ExecutionMode string `mapstructure:"executionMode"`
+
+	// HistoryBackend is the storage of the history of the DAG runs.
+	HistoryBackend string `mapstructure:"historyBackend"`
+
+	// RemoteStorage is the storage the status files and the logs of the
+	// finished runs are moved to, so that they outlive the host of the runs.
+	RemoteStorage RemoteStorageConfig `mapstructure:"remoteStorage"`
+}
+
+const (
+	// ExecutionModeProcess runs each DAG in a new dagu process.
+	ExecutionModeProcess = "process"
+	// ExecutionModeInProcess runs the DAGs as goroutines of the scheduler or
+	// the web server that starts them.
+	ExecutionModeInProcess = "inProcess"
+)
+
+const (
+	// HistoryBackendJSON stores the status of each run in a JSON file under
+	// the data directory.
+	HistoryBackendJSON = "json"
+	// HistoryBackendSQLite stores the statuses of the runs in a SQLite
+	// database file, Paths.HistoryDB.
+	HistoryBackendSQLite = "sqlite"
+)
+
+const (
+	// RemoteStorageS3 stores the files in a bucket of an S3-compatible
+	// storage.
+	RemoteStorageS3 = "s3"
+)
+
+// RemoteStorageConfig represents the remote storage configuration
+type RemoteStorageConfig struct {
+	// Type is the type of the storage. The files are kept local if it's
+	// empty.
+	Type string   `mapstructure:"type"`
+	S3   S3Config `mapstructure:"s3"`
+}
+
+// S3Config represents the configuration of an S3-compatible storage
+type S3Config struct {
+	// Endpoint is the URL of the storage, e.g. http://minio:9000. Defaults
+	// to Amazon S3.
+	Endpoint string `mapstructure:"endpoint"`
+	Region   string `mapstructure:"region"`
+	Bucket   string `mapstructure:"bucket"`
+	// Prefix is prepended to the keys of the objects.
+	Prefix string `mapstructure:"prefix"`
+	// AccessKeyID, SecretAccessKey and SessionToken default to the AWS_*
+	// environment variables.
+	AccessKeyID     string `mapstructure:"accessKeyId"`
+	SecretAccessKey string `mapstructure:"secretAccessKey"`
+	SessionToken    string `mapstructure:"sessionToken"`
+	// UsePathStyle addresses the bucket in the path of the URL, which most
+	// S3-compatible storages require.
+	UsePathStyle bool `mapstructure:"usePathStyle"`
+}
+
+// CalendarConfig represents an exclusion calendar, e.g. the holidays
+type CalendarConfig struct {
+	// Timezone is the time zone of the dates and windows. Defaults to tz.
+	Timezone string `mapstructure:"timezone"`
+	// Dates are the days excluded from the schedules, e.g. 2025-12-25.
+	Dates []string `mapstructure:"dates"`
+	// Windows are the periods excluded from the schedules, e.g. blackout
+	// windows for maintenance.
+	Windows []CalendarWindow `mapstructure:"windows"`
+}
+
+// CalendarWindow represents a period from Start (inclusive) to End
+// (exclusive), e.g. 2025-12-24T18:00.
+type CalendarWindow struct {
+	Start string `mapstructure:"start"`
+	End   string `mapstructure:"end"`
+}
+
+// SchedulerConfig represents the scheduler configuration
+type SchedulerConfig struct {
+	// LeaderElection lets the schedulers on multiple hosts share the DAGs,
+	// with only the elected leader starting the scheduled runs.
+	LeaderElection LeaderElectionConfig `mapstructure:"leaderElection"`
+	// StatusFile is the file the scheduler reports its status to, such as
+	// the DAGs failed to load. Defaults to <dataDir>/scheduler/status.json.
+	StatusFile string `mapstructure:"statusFile"`
+}
+
+// LeaderElectionConfig represents the leader election configuration
+type LeaderElectionConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// LeaseFile is the file of the lease on the storage shared by the
+	// schedulers. Defaults to <dataDir>/scheduler/leader.json.
+	LeaseFile string `mapstructure:"leaseFile"`
+	// LeaseTime is the time after which a standby takes over when the
+	// leader stops renewing the lease.
+	LeaseTime time.Duration `mapstructure:"leaseTime"`
+	// ID identifies the scheduler in the lease. Defaults to the hostname
+	// and the process ID.
+	ID string `mapstructure:"id"`
+}
+
+// StepCacheConfig represents the step cache configuration
+type StepCacheConfig struct {
+	// MaxAge is the time after which a cached result expires.
+	MaxAge time.Duration `mapstructure:"maxAge"`
+	// MaxSizeMB is the total size of the cached outputs in megabytes.
+	// The oldest results are evicted first when the size is exceeded.
+	MaxSizeMB int `mapstructure:"maxSizeMB"`
 }
 
 // Auth represents the authentication configuration
@@ -106,6 +231,9 @@ type PathsConfig struct {
 	SuspendFlagsDir string `mapstructure:"suspendFlagsDir"`
 	AdminLogsDir    string `mapstructure:"adminLogsDir"`
 	BaseConfig      string `mapstructure:"baseConfig"`
+	// HistoryDB is the database file of the sqlite history backend.
+	// Defaults to <dataDir>/history.db.
+	HistoryDB string `mapstructure:"historyDB"`
 }
 
 type UI struct {
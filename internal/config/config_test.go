@@ -265,6 +265,102 @@ func TestConfigLoader_ValidateConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid pool size",
+			setup: func(cfg *Config) {
+				cfg.Port = 8080
+				cfg.UI.MaxDashboardPageLimit = 100
+				cfg.Pools = map[string]int{"warehouse": 0}
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid step cache size",
+			setup: func(cfg *Config) {
+				cfg.Port = 8080
+				cfg.UI.MaxDashboardPageLimit = 100
+				cfg.StepCache.MaxSizeMB = -1
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid leader election lease time",
+			setup: func(cfg *Config) {
+				cfg.Port = 8080
+				cfg.UI.MaxDashboardPageLimit = 100
+				cfg.Scheduler.LeaderElection.Enabled = true
+			},
+			wantErr: true,
+		},
+		{
+			name: "in-process execution mode",
+			setup: func(cfg *Config) {
+				cfg.Port = 8080
+				cfg.UI.MaxDashboardPageLimit = 100
+				cfg.ExecutionMode = ExecutionModeInProcess
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid execution mode",
+			setup: func(cfg *Config) {
+				cfg.Port = 8080
+				cfg.UI.MaxDashboardPageLimit = 100
+				cfg.ExecutionMode = "thread"
+			},
+			wantErr: true,
+		},
+		{
+			name: "sqlite history backend",
+			setup: func(cfg *Config) {
+				cfg.Port = 8080
+				cfg.UI.MaxDashboardPageLimit = 100
+				cfg.HistoryBackend = HistoryBackendSQLite
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid history backend",
+			setup: func(cfg *Config) {
+				cfg.Port = 8080
+				cfg.UI.MaxDashboardPageLimit = 100
+				cfg.HistoryBackend = "postgres"
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid remote storage",
+			setup: func(cfg *Config) {
+				cfg.Port = 8080
+				cfg.UI.MaxDashboardPageLimit = 100
+				cfg.RemoteStorage = RemoteStorageConfig{
+					Type: RemoteStorageS3,
+					S3:   S3Config{Endpoint: "http://localhost:9000", Bucket: "dagu"},
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name: "remote storage without bucket",
+			setup: func(cfg *Config) {
+				cfg.Port = 8080
+				cfg.UI.MaxDashboardPageLimit = 100
+				cfg.RemoteStorage = RemoteStorageConfig{Type: RemoteStorageS3}
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid remote storage endpoint",
+			setup: func(cfg *Config) {
+				cfg.Port = 8080
+				cfg.UI.MaxDashboardPageLimit = 100
+				cfg.RemoteStorage = RemoteStorageConfig{
+					Type: RemoteStorageS3,
+					S3:   S3Config{Endpoint: "localhost:9000", Bucket: "dagu"},
+				}
+			},
+			wantErr: true,
+		},
 	}
 
 	loader := NewConfigLoader()
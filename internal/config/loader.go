@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -73,6 +74,16 @@ func (l *ConfigLoader) Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to set timezone: %w", err)
 	}
 
+	if cfg.Scheduler.LeaderElection.LeaseFile == "" {
+		cfg.Scheduler.LeaderElection.LeaseFile = filepath.Join(cfg.Paths.DataDir, "scheduler", "leader.json")
+	}
+	if cfg.Paths.HistoryDB == "" {
+		cfg.Paths.HistoryDB = filepath.Join(cfg.Paths.DataDir, "history.db")
+	}
+	if cfg.Scheduler.StatusFile == "" {
+		cfg.Scheduler.StatusFile = filepath.Join(cfg.Paths.DataDir, "scheduler", "status.json")
+	}
+
 	// Validate the configuration
 	if err := l.validateConfig(&cfg); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
@@ -149,6 +160,19 @@ func (l *ConfigLoader) setDefaultValues(resolver PathResolver) {
 
 	// Logging settings
 	viper.SetDefault("logFormat", "text")
+
+	// Step cache settings
+	viper.SetDefault("stepCache.maxAge", "168h")
+	viper.SetDefault("stepCache.maxSizeMB", 1024)
+
+	// Scheduler settings
+	viper.SetDefault("scheduler.leaderElection.leaseTime", "15s")
+
+	// Execution settings
+	viper.SetDefault("executionMode", ExecutionModeProcess)
+
+	// History settings
+	viper.SetDefault("historyBackend", HistoryBackendJSON)
 }
 
 func (l *ConfigLoader) bindEnvironmentVariables() {
@@ -205,6 +229,31 @@ func (l *ConfigLoader) bindEnvironmentVariables() {
 
 	// UI customization
 	l.bindEnv("latestStatusToday", "LATEST_STATUS_TODAY")
+
+	// Scheduler configurations
+	l.bindEnv("scheduler.leaderElection.enabled", "SCHEDULER_LEADER_ELECTION_ENABLED")
+	l.bindEnv("scheduler.leaderElection.leaseFile", "SCHEDULER_LEADER_ELECTION_LEASE_FILE")
+	l.bindEnv("scheduler.leaderElection.leaseTime", "SCHEDULER_LEADER_ELECTION_LEASE_TIME")
+	l.bindEnv("scheduler.leaderElection.id", "SCHEDULER_LEADER_ELECTION_ID")
+	l.bindEnv("scheduler.statusFile", "SCHEDULER_STATUS_FILE")
+
+	// Execution configurations
+	l.bindEnv("executionMode", "EXECUTION_MODE")
+
+	// History configurations
+	l.bindEnv("historyBackend", "HISTORY_BACKEND")
+	l.bindEnv("paths.historyDB", "HISTORY_DB")
+
+	// Remote storage configurations
+	l.bindEnv("remoteStorage.type", "REMOTE_STORAGE_TYPE")
+	l.bindEnv("remoteStorage.s3.endpoint", "REMOTE_STORAGE_S3_ENDPOINT")
+	l.bindEnv("remoteStorage.s3.region", "REMOTE_STORAGE_S3_REGION")
+	l.bindEnv("remoteStorage.s3.bucket", "REMOTE_STORAGE_S3_BUCKET")
+	l.bindEnv("remoteStorage.s3.prefix", "REMOTE_STORAGE_S3_PREFIX")
+	l.bindEnv("remoteStorage.s3.accessKeyId", "REMOTE_STORAGE_S3_ACCESS_KEY_ID")
+	l.bindEnv("remoteStorage.s3.secretAccessKey", "REMOTE_STORAGE_S3_SECRET_ACCESS_KEY")
+	l.bindEnv("remoteStorage.s3.sessionToken", "REMOTE_STORAGE_S3_SESSION_TOKEN")
+	l.bindEnv("remoteStorage.s3.usePathStyle", "REMOTE_STORAGE_S3_USE_PATH_STYLE")
 }
 
 func (l *ConfigLoader) bindEnv(key, env string) {
@@ -328,5 +377,51 @@ func (l *ConfigLoader) validateConfig(cfg *Config) error {
 		return fmt.Errorf("invalid max dashboard page limit: %d", cfg.UI.MaxDashboardPageLimit)
 	}
 
+	for name, size := range cfg.Pools {
+		if size < 1 {
+			return fmt.Errorf("invalid size for pool %q: %d", name, size)
+		}
+	}
+
+	if cfg.StepCache.MaxAge < 0 || cfg.StepCache.MaxSizeMB < 0 {
+		return fmt.Errorf("invalid step cache limits: maxAge=%s, maxSizeMB=%d",
+			cfg.StepCache.MaxAge, cfg.StepCache.MaxSizeMB)
+	}
+
+	if cfg.Scheduler.LeaderElection.Enabled && cfg.Scheduler.LeaderElection.LeaseTime < time.Second {
+		return fmt.Errorf("invalid leader election lease time: %s", cfg.Scheduler.LeaderElection.LeaseTime)
+	}
+
+	switch cfg.ExecutionMode {
+	case "", ExecutionModeProcess, ExecutionModeInProcess:
+	default:
+		return fmt.Errorf("invalid execution mode %q: must be %q or %q",
+			cfg.ExecutionMode, ExecutionModeProcess, ExecutionModeInProcess)
+	}
+
+	switch cfg.HistoryBackend {
+	case "", HistoryBackendJSON, HistoryBackendSQLite:
+	default:
+		return fmt.Errorf("invalid history backend %q: must be %q or %q",
+			cfg.HistoryBackend, HistoryBackendJSON, HistoryBackendSQLite)
+	}
+
+	switch cfg.RemoteStorage.Type {
+	case "":
+	case RemoteStorageS3:
+		if cfg.RemoteStorage.S3.Bucket == "" {
+			return fmt.Errorf("remoteStorage.s3.bucket is required")
+		}
+		if endpoint := cfg.RemoteStorage.S3.Endpoint; endpoint != "" {
+			u, err := url.Parse(endpoint)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("invalid remoteStorage.s3.endpoint %q: must be an http or https URL", endpoint)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid remote storage type %q: must be %q",
+			cfg.RemoteStorage.Type, RemoteStorageS3)
+	}
+
 	return nil
 }
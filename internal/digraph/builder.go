@@ -3,6 +3,9 @@ package digraph
 import (
 	"context"
 	"fmt"
+	"maps"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -22,6 +25,8 @@ type BuildContext struct {
 	ctx  context.Context
 	file string
 	opts BuildOpts
+	// envs is the env of the DAG for evaluating the params.
+	envs []string
 }
 
 func (c BuildContext) WithOpts(opts BuildOpts) BuildContext {
@@ -36,6 +41,12 @@ func (c BuildContext) WithFile(file string) BuildContext {
 	return copy
 }
 
+func (c BuildContext) WithEnvs(envs []string) BuildContext {
+	copy := c
+	copy.envs = envs
+	return copy
+}
+
 // BuildOpts is used to control the behavior of the builder.
 type BuildOpts struct {
 	// Base specifies the Base configuration file for the DAG.
@@ -56,7 +67,13 @@ var builderRegistry = []builderEntry{
 	{metadata: true, name: "schedule", fn: buildSchedule},
 	{metadata: true, name: "skipIfSuccessful", fn: skipIfSuccessful},
 	{metadata: true, name: "params", fn: buildParams},
-	{name: "dotenv", fn: buildDotenv},
+	{metadata: true, name: "triggers", fn: buildTriggers},
+	{metadata: true, name: "catchup", fn: buildCatchup},
+	{metadata: true, name: "excludeCalendars", fn: buildExcludeCalendars},
+	{metadata: true, name: "dotenv", fn: buildDotenv},
+	{metadata: true, name: "concurrency", fn: buildConcurrency},
+	{metadata: true, name: "sla", fn: buildSLA},
+	{metadata: true, name: "onAgentLost", fn: buildOnAgentLost},
 	{name: "mailOn", fn: buildMailOn},
 	{name: "steps", fn: buildSteps},
 	{name: "logDir", fn: buildLogDir},
@@ -66,7 +83,9 @@ var builderRegistry = []builderEntry{
 	{name: "infoMailConfig", fn: buildInfoMailConfig},
 	{name: "maxHistoryRetentionDays", fn: maxHistoryRetentionDays},
 	{name: "maxCleanUpTime", fn: maxCleanUpTime},
+	{name: "retryWindow", fn: buildRetryWindow},
 	{name: "preconditions", fn: buildPrecondition},
+	{name: "workspace", fn: buildWorkspace},
 }
 
 type builderEntry struct {
@@ -85,6 +104,11 @@ var stepBuilderRegistry = []stepBuilderEntry{
 	{name: "repeatPolicy", fn: buildRepeatPolicy},
 	{name: "signalOnStop", fn: buildSignalOnStop},
 	{name: "precondition", fn: buildStepPrecondition},
+	{name: "weight", fn: buildWeight},
+	{name: "cache", fn: buildCache},
+	{name: "approval", fn: buildApproval},
+	{name: "sensor", fn: buildSensor},
+	{name: "env", fn: buildStepEnv},
 }
 
 type stepBuilderEntry struct {
@@ -98,15 +122,16 @@ type StepBuilderFn func(ctx BuildContext, def stepDef, step *Step) error
 // build builds a DAG from the specification.
 func build(ctx BuildContext, spec *definition) (*DAG, error) {
 	dag := &DAG{
-		Location:      ctx.file,
-		Name:          spec.Name,
-		Group:         spec.Group,
-		Description:   spec.Description,
-		Timeout:       time.Second * time.Duration(spec.TimeoutSec),
-		Delay:         time.Second * time.Duration(spec.DelaySec),
-		RestartWait:   time.Second * time.Duration(spec.RestartWaitSec),
-		Tags:          parseTags(spec.Tags),
-		MaxActiveRuns: spec.MaxActiveRuns,
+		Location:        ctx.file,
+		Name:            spec.Name,
+		Group:           spec.Group,
+		Description:     spec.Description,
+		Timeout:         time.Second * time.Duration(spec.TimeoutSec),
+		Delay:           time.Second * time.Duration(spec.DelaySec),
+		RestartWait:     time.Second * time.Duration(spec.RestartWaitSec),
+		Tags:            parseTags(spec.Tags),
+		MaxActiveRuns:   spec.MaxActiveRuns,
+		MaxActiveWeight: spec.MaxActiveWeight,
 	}
 
 	var errs ErrorList
@@ -221,17 +246,28 @@ func buildSchedule(_ BuildContext, spec *definition, dag *DAG) error {
 
 	}
 
+	// The schedules are evaluated in the time zone of the DAG if specified.
+	var loc *time.Location
+	if spec.Timezone != "" {
+		var err error
+		loc, err = time.LoadLocation(spec.Timezone)
+		if err != nil {
+			return wrapError("timezone", spec.Timezone, fmt.Errorf("%w: %s", ErrInvalidTimezone, err))
+		}
+		dag.Timezone = spec.Timezone
+	}
+
 	// Parse each schedule as a cron expression.
 	var err error
-	dag.Schedule, err = buildScheduler(starts)
+	dag.Schedule, err = buildScheduler(starts, loc)
 	if err != nil {
 		return err
 	}
-	dag.StopSchedule, err = buildScheduler(stops)
+	dag.StopSchedule, err = buildScheduler(stops, loc)
 	if err != nil {
 		return err
 	}
-	dag.RestartSchedule, err = buildScheduler(restarts)
+	dag.RestartSchedule, err = buildScheduler(restarts, loc)
 	return err
 }
 
@@ -256,7 +292,9 @@ func buildDotenv(ctx BuildContext, spec *definition, dag *DAG) error {
 		return wrapError("dotenv", v, ErrDotenvMustBeStringOrArray)
 	}
 
-	if !ctx.opts.NoEval {
+	// The paths are kept in the metadata, but the files are loaded only
+	// when the DAG is loaded to run.
+	if !ctx.opts.NoEval && !ctx.opts.OnlyMetadata {
 		var relativeTos []string
 		if ctx.file != "" {
 			relativeTos = append(relativeTos, ctx.file)
@@ -264,7 +302,7 @@ func buildDotenv(ctx BuildContext, spec *definition, dag *DAG) error {
 
 		resolver := fileutil.NewFileResolver(relativeTos)
 		for _, filePath := range dag.Dotenv {
-			filePath, err := cmdutil.EvalString(ctx.ctx, filePath)
+			filePath, err := cmdutil.EvalString(ctx.ctx, filePath, cmdutil.WithEnv(dag.Env))
 			if err != nil {
 				return wrapError("dotenv", filePath, fmt.Errorf("failed to evaluate dotenv file path %s: %w", filePath, err))
 			}
@@ -272,9 +310,23 @@ func buildDotenv(ctx BuildContext, spec *definition, dag *DAG) error {
 			if err != nil {
 				continue
 			}
-			if err := godotenv.Load(resolvedPath); err != nil {
+			vars, err := godotenv.Read(resolvedPath)
+			if err != nil {
 				return wrapError("dotenv", filePath, fmt.Errorf("failed to load dotenv file %s: %w", filePath, err))
 			}
+			// The variables do not override the env of the DAG or the
+			// process.
+			defined := make(map[string]bool)
+			for _, env := range dag.Env {
+				key, _, _ := strings.Cut(env, "=")
+				defined[key] = true
+			}
+			for _, key := range slices.Sorted(maps.Keys(vars)) {
+				if _, ok := os.LookupEnv(key); ok || defined[key] {
+					continue
+				}
+				dag.Env = append(dag.Env, key+"="+vars[key])
+			}
 			// Break after the first successful load.
 			break
 		}
@@ -290,6 +342,7 @@ func buildMailOn(_ BuildContext, spec *definition, dag *DAG) error {
 	dag.MailOn = &MailOn{
 		Failure: spec.MailOn.Failure,
 		Success: spec.MailOn.Success,
+		SLAMiss: spec.MailOn.SLAMiss,
 	}
 	return nil
 }
@@ -318,7 +371,7 @@ func buildLogDir(_ BuildContext, spec *definition, dag *DAG) (err error) {
 
 // buildHandlers builds the handlers for the DAG.
 // The handlers are executed when the DAG is stopped, succeeded, failed, or
-// cancelled.
+// cancelled, or when the SLA of the DAG is missed.
 func buildHandlers(ctx BuildContext, spec *definition, dag *DAG) (err error) {
 	if spec.HandlerOn.Exit != nil {
 		spec.HandlerOn.Exit.Name = HandlerOnExit.String()
@@ -348,6 +401,13 @@ func buildHandlers(ctx BuildContext, spec *definition, dag *DAG) (err error) {
 		}
 	}
 
+	if spec.HandlerOn.SLAMiss != nil {
+		spec.HandlerOn.SLAMiss.Name = HandlerOnSLAMiss.String()
+		if dag.HandlerOn.SLAMiss, err = buildStep(ctx, *spec.HandlerOn.SLAMiss, spec.Functions); err != nil {
+			return
+		}
+	}
+
 	return nil
 }
 
@@ -446,6 +506,227 @@ func maxHistoryRetentionDays(_ BuildContext, spec *definition, dag *DAG) error {
 	return nil
 }
 
+// buildTriggers builds the links to the downstream DAGs (triggers) and to
+// the upstream DAGs (dependsOn).
+func buildTriggers(_ BuildContext, spec *definition, dag *DAG) error {
+	triggers, err := parseDAGTriggers("triggers", spec.Triggers)
+	if err != nil {
+		return err
+	}
+	dependsOn, err := parseDAGTriggers("dependsOn", spec.DependsOn)
+	if err != nil {
+		return err
+	}
+	dag.Triggers = triggers
+	dag.DependsOn = dependsOn
+	return nil
+}
+
+// parseDAGTriggers parses the value of triggers or dependsOn. Each item is
+// either the name of the DAG or a triggerDef.
+func parseDAGTriggers(field string, value any) ([]DAGTrigger, error) {
+	var items []any
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []any:
+		items = v
+	default:
+		items = []any{v}
+	}
+
+	var ret []DAGTrigger
+	for _, item := range items {
+		var def triggerDef
+		switch v := item.(type) {
+		case string:
+			def.DAG = v
+
+		case map[string]any, map[any]any:
+			md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+				ErrorUnused:      true,
+				WeaklyTypedInput: true,
+				Result:           &def,
+			})
+			if err := md.Decode(triggerDefMap(v)); err != nil {
+				return nil, wrapError(field, v, err)
+			}
+
+		default:
+			return nil, wrapError(field, v, ErrInvalidTriggerType)
+		}
+
+		if def.DAG == "" {
+			return nil, wrapError(field, item, ErrTriggerDAGRequired)
+		}
+
+		on := TriggerOn(def.On)
+		switch on {
+		case "":
+			on = TriggerOnSuccess
+		case TriggerOnSuccess, TriggerOnFailure, TriggerOnAlways:
+		default:
+			return nil, wrapError(field+".on", def.On, ErrInvalidTriggerOn)
+		}
+
+		ret = append(ret, DAGTrigger{DAG: def.DAG, On: on, Params: def.Params})
+	}
+	return ret, nil
+}
+
+// triggerDefMap converts the keys of the trigger definition to strings.
+// YAML 1.1 parses the key "on" as the boolean true, so it is restored.
+func triggerDefMap(value any) map[string]any {
+	ret := make(map[string]any)
+	switch v := value.(type) {
+	case map[string]any:
+		return v
+	case map[any]any:
+		for key, val := range v {
+			if key == true {
+				key = "on"
+			}
+			ret[fmt.Sprint(key)] = val
+		}
+	}
+	return ret
+}
+
+// buildCatchup builds the catch-up policy of the missed scheduled runs.
+func buildCatchup(_ BuildContext, spec *definition, dag *DAG) error {
+	switch policy := CatchupPolicy(spec.Catchup); policy {
+	case "":
+		dag.Catchup = CatchupNone
+	case CatchupNone, CatchupLatest, CatchupAll:
+		dag.Catchup = policy
+	default:
+		return wrapError("catchup", spec.Catchup, ErrInvalidCatchup)
+	}
+	return nil
+}
+
+// buildExcludeCalendars sets the names of the calendars excluded from the
+// schedule. The calendars are resolved by the scheduler.
+func buildExcludeCalendars(_ BuildContext, spec *definition, dag *DAG) error {
+	for _, name := range spec.ExcludeCalendars {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return wrapError("excludeCalendars", spec.ExcludeCalendars, ErrEmptyCalendarName)
+		}
+		dag.ExcludeCalendars = append(dag.ExcludeCalendars, name)
+	}
+	return nil
+}
+
+// buildOnAgentLost builds the action for a run whose agent was lost. It
+// defaults to marking the run failed.
+func buildOnAgentLost(_ BuildContext, spec *definition, dag *DAG) error {
+	switch policy := AgentLostPolicy(spec.OnAgentLost); policy {
+	case "":
+		dag.OnAgentLost = AgentLostFail
+	case AgentLostFail, AgentLostRetry:
+		dag.OnAgentLost = policy
+	default:
+		return wrapError("onAgentLost", spec.OnAgentLost, ErrInvalidOnAgentLost)
+	}
+	return nil
+}
+
+// buildConcurrency sets the policy for the starts while the DAG is running.
+// It defaults to one run at a time and skipping the other starts.
+func buildConcurrency(_ BuildContext, spec *definition, dag *DAG) error {
+	dag.Concurrency = Concurrency{Max: 1, OnConflict: OnConflictSkip}
+	if spec.Concurrency == nil {
+		return nil
+	}
+	if spec.Concurrency.Max != nil {
+		if *spec.Concurrency.Max < 1 {
+			return wrapError("concurrency.max", *spec.Concurrency.Max, ErrInvalidConcurrencyMax)
+		}
+		dag.Concurrency.Max = *spec.Concurrency.Max
+	}
+	switch onConflict := OnConflict(spec.Concurrency.OnConflict); onConflict {
+	case "":
+	case OnConflictSkip, OnConflictQueue, OnConflictCancelPrevious:
+		dag.Concurrency.OnConflict = onConflict
+	default:
+		return wrapError("concurrency.onConflict", spec.Concurrency.OnConflict, ErrInvalidOnConflict)
+	}
+	return nil
+}
+
+// buildSLA builds the service level of the runs monitored by the scheduler.
+func buildSLA(_ BuildContext, spec *definition, dag *DAG) error {
+	if spec.SLA == nil {
+		return nil
+	}
+	sla := &SLA{}
+	if spec.SLA.MaxDuration != "" {
+		d, err := time.ParseDuration(spec.SLA.MaxDuration)
+		if err != nil || d <= 0 {
+			return wrapError("sla.maxDuration", spec.SLA.MaxDuration, ErrInvalidSLAMaxDuration)
+		}
+		sla.MaxDuration = d
+	}
+	if spec.SLA.MustFinishBy != "" {
+		if _, err := time.Parse("15:04", spec.SLA.MustFinishBy); err != nil {
+			return wrapError("sla.mustFinishBy", spec.SLA.MustFinishBy, ErrInvalidSLAMustFinishBy)
+		}
+		sla.MustFinishBy = spec.SLA.MustFinishBy
+	}
+	if sla.MaxDuration > 0 || sla.MustFinishBy != "" {
+		dag.SLA = sla
+	}
+	return nil
+}
+
+// buildRetryWindow sets how long a run with failed steps is kept open for
+// them to be retried.
+func buildRetryWindow(_ BuildContext, spec *definition, dag *DAG) error {
+	if spec.RetryWindow == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(spec.RetryWindow)
+	if err != nil || d <= 0 {
+		return wrapError("retryWindow", spec.RetryWindow, ErrInvalidRetryWindow)
+	}
+	dag.RetryWindow = d
+	return nil
+}
+
+// buildWorkspace builds the workspace configuration for the DAG.
+func buildWorkspace(_ BuildContext, spec *definition, dag *DAG) error {
+	switch v := spec.Workspace.(type) {
+	case nil:
+		return nil
+
+	case bool:
+		if v {
+			dag.Workspace = &Workspace{}
+		}
+		return nil
+
+	case map[string]any, map[any]any:
+		var workspace workspaceDef
+		md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			ErrorUnused:      true,
+			WeaklyTypedInput: true,
+			Result:           &workspace,
+		})
+		if err := md.Decode(v); err != nil {
+			return wrapError("workspace", v, err)
+		}
+		if workspace.RetentionDays < 0 {
+			return wrapError("workspace.retentionDays", workspace.RetentionDays, ErrWorkspaceRetentionMustBePositive)
+		}
+		dag.Workspace = &Workspace{RetentionDays: workspace.RetentionDays}
+		return nil
+
+	default:
+		return wrapError("workspace", v, ErrInvalidWorkspaceType)
+	}
+}
+
 // skipIfSuccessful sets the skipIfSuccessful field for the DAG.
 func skipIfSuccessful(_ BuildContext, spec *definition, dag *DAG) error {
 	dag.SkipIfSuccessful = spec.SkipIfSuccessful
@@ -555,6 +836,8 @@ func buildStep(ctx BuildContext, def stepDef, fns []*funcDef) (*Step, error) {
 		Output:         def.Output,
 		Dir:            def.Dir,
 		MailOnError:    def.MailOnError,
+		Priority:       def.Priority,
+		Pool:           def.Pool,
 		ExecutorConfig: ExecutorConfig{Config: make(map[string]any)},
 	}
 
@@ -619,10 +902,45 @@ func buildRetryPolicy(_ BuildContext, def stepDef, step *Step) error {
 	return nil
 }
 
-func buildRepeatPolicy(_ BuildContext, def stepDef, step *Step) error {
-	if def.RepeatPolicy != nil {
-		step.RepeatPolicy.Repeat = def.RepeatPolicy.Repeat
-		step.RepeatPolicy.Interval = time.Second * time.Duration(def.RepeatPolicy.IntervalSec)
+// buildRepeatPolicy builds the repeat policy for a step. The step is
+// repeated if repeat is true or any of limit, until and while is set.
+func buildRepeatPolicy(ctx BuildContext, def stepDef, step *Step) error {
+	if def.RepeatPolicy == nil {
+		return nil
+	}
+	policy := def.RepeatPolicy
+
+	if policy.Limit < 0 {
+		return wrapError("repeatPolicy.limit", policy.Limit, ErrRepeatLimitMustBePositive)
+	}
+	until, err := parsePrecondition(ctx, policy.Until)
+	if err != nil {
+		return err
+	}
+	while, err := parsePrecondition(ctx, policy.While)
+	if err != nil {
+		return err
+	}
+	exitCodes, err := parseIntOrArray(policy.ExitCode)
+	if err != nil {
+		return wrapError("repeatPolicy.exitCode", policy.ExitCode, ErrRepeatExitCodeMustBeIntOrArray)
+	}
+	if policy.Cron != "" {
+		if _, err := cronParser.Parse(policy.Cron); err != nil {
+			return wrapError("repeatPolicy.cron", policy.Cron, fmt.Errorf("%w: %s", ErrInvalidRepeatCron, err))
+		}
+	}
+
+	step.RepeatPolicy = RepeatPolicy{
+		Repeat:      policy.Repeat || policy.Limit > 0 || len(until) > 0 || len(while) > 0,
+		Interval:    time.Second * time.Duration(policy.IntervalSec),
+		Limit:       policy.Limit,
+		Until:       until,
+		While:       while,
+		ExitCode:    exitCodes,
+		Backoff:     policy.Backoff,
+		MaxInterval: time.Second * time.Duration(policy.MaxIntervalSec),
+		Cron:        policy.Cron,
 	}
 	return nil
 }
@@ -642,6 +960,187 @@ func buildStepPrecondition(ctx BuildContext, def stepDef, step *Step) error {
 	return nil
 }
 
+// buildWeight sets the number of concurrency slots the step occupies.
+func buildWeight(_ BuildContext, def stepDef, step *Step) error {
+	if def.Weight == nil {
+		return nil
+	}
+	if *def.Weight < 1 {
+		return wrapError("weight", *def.Weight, ErrWeightMustBePositive)
+	}
+	step.Weight = *def.Weight
+	return nil
+}
+
+// buildCache builds the cache configuration for a step.
+func buildCache(_ BuildContext, def stepDef, step *Step) error {
+	switch v := def.Cache.(type) {
+	case nil:
+		return nil
+
+	case bool:
+		if v {
+			step.Cache = &StepCache{}
+		}
+		return nil
+
+	case map[string]any, map[any]any:
+		var cache cacheDef
+		md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			ErrorUnused: true,
+			Result:      &cache,
+		})
+		if err := md.Decode(v); err != nil {
+			return wrapError("cache", v, err)
+		}
+		env, err := parseStringOrArray(cache.Env)
+		if err != nil {
+			return wrapError("cache.env", cache.Env, ErrCacheEnvMustBeStringOrArray)
+		}
+		files, err := parseStringOrArray(cache.Files)
+		if err != nil {
+			return wrapError("cache.files", cache.Files, ErrCacheFilesMustBeStringOrArray)
+		}
+		step.Cache = &StepCache{Env: env, Files: files}
+		return nil
+
+	default:
+		return wrapError("cache", v, ErrInvalidCacheType)
+	}
+}
+
+// buildApproval builds the manual approval configuration for a step.
+func buildApproval(_ BuildContext, def stepDef, step *Step) error {
+	switch v := def.Approval.(type) {
+	case nil:
+		return nil
+
+	case bool:
+		if v {
+			step.Approval = &Approval{}
+		}
+		return nil
+
+	case map[string]any, map[any]any:
+		var approval approvalDef
+		md, _ := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			ErrorUnused:      true,
+			WeaklyTypedInput: true,
+			Result:           &approval,
+		})
+		if err := md.Decode(v); err != nil {
+			return wrapError("approval", v, err)
+		}
+		if approval.TimeoutSec < 0 {
+			return wrapError("approval.timeoutSec", approval.TimeoutSec, ErrApprovalTimeoutMustBePositive)
+		}
+		step.Approval = &Approval{
+			Message: approval.Message,
+			Timeout: time.Duration(approval.TimeoutSec) * time.Second,
+		}
+		return nil
+
+	default:
+		return wrapError("approval", v, ErrInvalidApprovalType)
+	}
+}
+
+// buildStepEnv builds the environment variables of a step. Unlike the DAG
+// env, the values are not evaluated here but when the step starts, so that
+// they can refer to the outputs of the preceding steps.
+func buildStepEnv(_ BuildContext, def stepDef, step *Step) error {
+	var pairs []pair
+	switch v := def.Env.(type) {
+	case nil:
+		return nil
+
+	case map[any]any:
+		if err := parseKeyValue(v, &pairs); err != nil {
+			return wrapError("env", v, err)
+		}
+
+	case []any:
+		for _, item := range v {
+			switch vv := item.(type) {
+			case map[any]any:
+				if err := parseKeyValue(vv, &pairs); err != nil {
+					return wrapError("env", vv, err)
+				}
+			case string:
+				key, value, ok := strings.Cut(vv, "=")
+				if !ok || key == "" {
+					return wrapError("env", vv, ErrInvalidStepEnvType)
+				}
+				pairs = append(pairs, pair{key: key, val: value})
+			default:
+				return wrapError("env", vv, ErrInvalidStepEnvType)
+			}
+		}
+
+	default:
+		return wrapError("env", v, ErrInvalidStepEnvType)
+	}
+
+	for _, p := range pairs {
+		step.Env = append(step.Env, p.key+"="+p.val)
+	}
+	return nil
+}
+
+// defaultPokeInterval is the interval between the checks of a sensor.
+const defaultPokeInterval = time.Minute
+
+func buildSensor(_ BuildContext, def stepDef, step *Step) error {
+	if def.Sensor == nil {
+		return nil
+	}
+	sensor := def.Sensor
+
+	var target string
+	switch SensorKind(sensor.Kind) {
+	case SensorKindFile:
+		target = sensor.Path
+	case SensorKindHTTP:
+		target = sensor.URL
+	case SensorKindDAG:
+		target = sensor.DAG
+	case SensorKindCommand:
+		target = sensor.Command
+	default:
+		return wrapError("sensor.kind", sensor.Kind, ErrInvalidSensorKind)
+	}
+	if target == "" {
+		return wrapError("sensor", sensor.Kind, ErrSensorTargetRequired)
+	}
+	if sensor.PokeIntervalSec < 0 {
+		return wrapError("sensor.pokeIntervalSec", sensor.PokeIntervalSec, ErrSensorPokeIntervalMustBePositive)
+	}
+	if sensor.TimeoutSec < 0 {
+		return wrapError("sensor.timeoutSec", sensor.TimeoutSec, ErrSensorTimeoutMustBePositive)
+	}
+
+	pokeInterval := defaultPokeInterval
+	if sensor.PokeIntervalSec > 0 {
+		pokeInterval = time.Duration(sensor.PokeIntervalSec) * time.Second
+	}
+
+	step.Sensor = &Sensor{
+		Kind:         SensorKind(sensor.Kind),
+		Path:         sensor.Path,
+		URL:          sensor.URL,
+		Method:       sensor.Method,
+		StatusCode:   sensor.Status,
+		Body:         sensor.Body,
+		DAG:          sensor.DAG,
+		Date:         sensor.Date,
+		Command:      sensor.Command,
+		PokeInterval: pokeInterval,
+		Timeout:      time.Duration(sensor.TimeoutSec) * time.Second,
+		SoftFail:     sensor.SoftFail,
+	}
+	return nil
+}
+
 func buildSignalOnStop(_ BuildContext, def stepDef, step *Step) error {
 	if def.SignalOnStop != nil {
 		sigDef := *def.SignalOnStop
@@ -3,6 +3,7 @@ package digraph_test
 import (
 	"context"
 	"errors"
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
@@ -23,11 +24,64 @@ func TestBuild(t *testing.T) {
 		th := testLoad(t, "skip_if_successful.yaml")
 		assert.True(t, th.SkipIfSuccessful)
 	})
+	t.Run("Catchup", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "catchup.yaml")
+		assert.Equal(t, digraph.CatchupAll, th.Catchup)
+
+		th = testLoad(t, "skip_if_successful.yaml")
+		assert.Equal(t, digraph.CatchupNone, th.Catchup)
+	})
+	t.Run("OnAgentLost", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "on_agent_lost.yaml")
+		assert.Equal(t, digraph.AgentLostRetry, th.OnAgentLost)
+
+		th = testLoad(t, "skip_if_successful.yaml")
+		assert.Equal(t, digraph.AgentLostFail, th.OnAgentLost)
+	})
+	t.Run("RetryWindow", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "retry_window.yaml")
+		assert.Equal(t, 30*time.Minute, th.RetryWindow)
+
+		th = testLoad(t, "skip_if_successful.yaml")
+		assert.Zero(t, th.RetryWindow)
+	})
+	t.Run("Concurrency", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "concurrency.yaml")
+		assert.Equal(t, digraph.Concurrency{Max: 2, OnConflict: digraph.OnConflictQueue}, th.Concurrency)
+
+		th = testLoad(t, "skip_if_successful.yaml")
+		assert.Equal(t, digraph.Concurrency{Max: 1, OnConflict: digraph.OnConflictSkip}, th.Concurrency)
+	})
+	t.Run("SLA", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "sla.yaml")
+		assert.Equal(t, &digraph.SLA{MaxDuration: 90 * time.Minute, MustFinishBy: "06:00"}, th.SLA)
+		assert.True(t, th.MailOn.SLAMiss)
+		require.NotNil(t, th.HandlerOn.SLAMiss)
+		assert.Equal(t, digraph.HandlerOnSLAMiss.String(), th.HandlerOn.SLAMiss.Name)
+		assert.Equal(t, "echo", th.HandlerOn.SLAMiss.Command)
+
+		th = testLoad(t, "skip_if_successful.yaml")
+		assert.Nil(t, th.SLA)
+	})
 	t.Run("ParamsWithSubstitution", func(t *testing.T) {
 		t.Parallel()
 
 		th := testLoad(t, "params_with_substitution.yaml")
 		th.AssertParam(t, "x", "x")
+
+		// The params are not set to the process environment.
+		_, ok := os.LookupEnv("1")
+		assert.False(t, ok)
 	})
 	t.Run("ParamsWithQuotedValues", func(t *testing.T) {
 		t.Parallel()
@@ -142,7 +196,26 @@ func TestBuild(t *testing.T) {
 		th := testLoad(t, "max_active_runs.yaml")
 		assert.Equal(t, 3, th.MaxActiveRuns)
 	})
+	t.Run("MaxActiveWeight", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "priority_and_weight.yaml")
+		assert.Equal(t, 4, th.MaxActiveWeight)
+	})
+
+	t.Run("Triggers", func(t *testing.T) {
+		t.Parallel()
 
+		th := testLoad(t, "triggers.yaml")
+		assert.Equal(t, []digraph.DAGTrigger{
+			{DAG: "report", On: digraph.TriggerOnSuccess},
+			{DAG: "cleanup", On: digraph.TriggerOnAlways},
+			{DAG: "alert", On: digraph.TriggerOnFailure, Params: "UPSTREAM=${DAG_UPSTREAM_NAME} FILE=${OUTPUT_FILE}"},
+		}, th.Triggers)
+		assert.Equal(t, []digraph.DAGTrigger{
+			{DAG: "extract", On: digraph.TriggerOnSuccess},
+		}, th.DependsOn)
+	})
 	t.Run("ValidationError", func(t *testing.T) {
 		t.Parallel()
 
@@ -173,11 +246,71 @@ func TestBuild(t *testing.T) {
 				dag:         "invalid_schedule.yaml",
 				expectedErr: digraph.ErrInvalidSchedule,
 			},
+			{
+				name:        "InvalidTimezone",
+				dag:         "invalid_timezone.yaml",
+				expectedErr: digraph.ErrInvalidTimezone,
+			},
 			{
 				name:        "NoCommand",
 				dag:         "invalid_no_command.yaml",
 				expectedErr: digraph.ErrStepCommandIsRequired,
 			},
+			{
+				name:        "InvalidWeight",
+				dag:         "invalid_weight.yaml",
+				expectedErr: digraph.ErrWeightMustBePositive,
+			},
+			{
+				name:        "InvalidRepeatCron",
+				dag:         "invalid_repeat_cron.yaml",
+				expectedErr: digraph.ErrInvalidRepeatCron,
+			},
+			{
+				name:        "InvalidCatchup",
+				dag:         "invalid_catchup.yaml",
+				expectedErr: digraph.ErrInvalidCatchup,
+			},
+			{
+				name:        "InvalidOnAgentLost",
+				dag:         "invalid_on_agent_lost.yaml",
+				expectedErr: digraph.ErrInvalidOnAgentLost,
+			},
+			{
+				name:        "InvalidConcurrencyMax",
+				dag:         "invalid_concurrency_max.yaml",
+				expectedErr: digraph.ErrInvalidConcurrencyMax,
+			},
+			{
+				name:        "InvalidOnConflict",
+				dag:         "invalid_on_conflict.yaml",
+				expectedErr: digraph.ErrInvalidOnConflict,
+			},
+			{
+				name:        "InvalidSLAMaxDuration",
+				dag:         "invalid_sla_max_duration.yaml",
+				expectedErr: digraph.ErrInvalidSLAMaxDuration,
+			},
+			{
+				name:        "InvalidSLAMustFinishBy",
+				dag:         "invalid_sla_must_finish_by.yaml",
+				expectedErr: digraph.ErrInvalidSLAMustFinishBy,
+			},
+			{
+				name:        "InvalidRetryWindow",
+				dag:         "invalid_retry_window.yaml",
+				expectedErr: digraph.ErrInvalidRetryWindow,
+			},
+			{
+				name:        "InvalidTrigger",
+				dag:         "invalid_trigger.yaml",
+				expectedErr: digraph.ErrInvalidTriggerOn,
+			},
+			{
+				name:        "InvalidSensor",
+				dag:         "invalid_sensor.yaml",
+				expectedErr: digraph.ErrInvalidSensorKind,
+			},
 		}
 
 		for _, tc := range testCases {
@@ -230,6 +363,10 @@ func TestBuildEnv(t *testing.T) {
 			th := testLoad(t, tc.file)
 			for key, val := range tc.expected {
 				th.AssertEnv(t, key, val)
+
+				// The env is not set to the process environment.
+				_, ok := os.LookupEnv(key)
+				assert.False(t, ok)
 			}
 		})
 	}
@@ -303,6 +440,56 @@ func TestBuildSchedule(t *testing.T) {
 	}
 }
 
+func TestBuildScheduleWithSeconds(t *testing.T) {
+	t.Parallel()
+
+	th := testLoad(t, "schedule_with_seconds.yaml")
+	require.Len(t, th.Schedule, 2)
+
+	now := time.Date(2025, 1, 1, 0, 0, 5, 0, time.UTC)
+	assert.Equal(t, now.Add(15*time.Second), th.Schedule[0].Parsed.Next(now))
+
+	// @every runs at fixed times regardless of when it is evaluated.
+	next := th.Schedule[1].Parsed.Next(now)
+	assert.True(t, next.After(now) && !next.After(now.Add(90*time.Second)))
+	assert.Equal(t, next, th.Schedule[1].Parsed.Next(next.Add(-time.Second)))
+	assert.Equal(t, next.Add(90*time.Second), th.Schedule[1].Parsed.Next(next))
+}
+
+func TestBuildScheduleWithTimezone(t *testing.T) {
+	t.Parallel()
+
+	th := testLoad(t, "schedule_with_timezone.yaml")
+	assert.Equal(t, "America/New_York", th.Timezone)
+	assert.Equal(t, []string{"holidays"}, th.ExcludeCalendars)
+
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2025, month, day, hour, minute, 0, 0, loc)
+	}
+	next := func(s digraph.Schedule, t time.Time) time.Time {
+		return s.Parsed.Next(t.In(time.UTC)).In(loc)
+	}
+
+	t.Run("Timezone", func(t *testing.T) {
+		assert.Equal(t, at(time.January, 1, 2, 30), next(th.Schedule[0], at(time.January, 1, 0, 0)))
+	})
+	t.Run("SpringForward", func(t *testing.T) {
+		// 02:30 does not exist on 2025-03-09; it runs when the clocks
+		// go forward from 02:00 to 03:00.
+		got := next(th.Schedule[0], at(time.March, 9, 0, 0))
+		assert.Equal(t, time.Date(2025, time.March, 9, 3, 0, 0, 0, loc), got)
+		assert.Equal(t, at(time.March, 10, 2, 30), next(th.Schedule[0], got))
+	})
+	t.Run("FallBack", func(t *testing.T) {
+		// 01:30 occurs twice on 2025-11-02; it runs only the first time.
+		first := next(th.Schedule[1], at(time.November, 2, 0, 0))
+		assert.Equal(t, at(time.November, 2, 1, 30), first)
+		assert.Equal(t, at(time.November, 3, 1, 30), next(th.Schedule[1], first))
+	})
+}
+
 func TestBuildStep(t *testing.T) {
 	t.Parallel()
 	t.Run("ValidCommand", func(t *testing.T) {
@@ -397,6 +584,110 @@ func TestBuildStep(t *testing.T) {
 		assert.True(t, th.Steps[0].RepeatPolicy.Repeat)
 		assert.Equal(t, 60*time.Second, th.Steps[0].RepeatPolicy.Interval)
 	})
+	t.Run("StepEnvAndWorkspace", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "step_env.yaml")
+		assert.Equal(t, &digraph.Workspace{RetentionDays: 3}, th.Workspace)
+		assert.Len(t, th.Steps, 2)
+		assert.Equal(t, []string{"FOO=foo", "BAR=${FOO}/bar"}, th.Steps[0].Env)
+	})
+	t.Run("RepeatPolicyConditions", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "repeat_policy_conditions.yaml")
+		assert.Len(t, th.Steps, 2)
+		assert.Equal(t, digraph.RepeatPolicy{
+			Repeat:      true,
+			Interval:    10 * time.Second,
+			Limit:       5,
+			Until:       []digraph.Condition{{Condition: "$RESULT", Expected: "done"}},
+			ExitCode:    []int{2, 3},
+			Backoff:     2,
+			MaxInterval: 60 * time.Second,
+		}, th.Steps[0].RepeatPolicy)
+		assert.Equal(t, digraph.RepeatPolicy{
+			Repeat: true,
+			While:  []digraph.Condition{{Command: "test -f /tmp/running"}},
+			Cron:   "*/5 * * * *",
+		}, th.Steps[1].RepeatPolicy)
+	})
+	t.Run("PriorityAndWeight", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "priority_and_weight.yaml")
+		assert.Len(t, th.Steps, 2)
+		assert.Equal(t, 10, th.Steps[0].Priority)
+		assert.Equal(t, 4, th.Steps[0].Weight)
+		assert.Equal(t, 0, th.Steps[1].Priority)
+		assert.Equal(t, 0, th.Steps[1].Weight)
+	})
+	t.Run("Pool", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "pool.yaml")
+		assert.Len(t, th.Steps, 1)
+		assert.Equal(t, "warehouse", th.Steps[0].Pool)
+	})
+	t.Run("Cache", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "cache.yaml")
+		assert.Len(t, th.Steps, 3)
+		require.NotNil(t, th.Steps[0].Cache)
+		assert.Empty(t, th.Steps[0].Cache.Env)
+		require.NotNil(t, th.Steps[1].Cache)
+		assert.Equal(t, []string{"TARGET"}, th.Steps[1].Cache.Env)
+		assert.Equal(t, []string{"input/*.csv", "config.json"}, th.Steps[1].Cache.Files)
+		assert.Nil(t, th.Steps[2].Cache)
+	})
+	t.Run("Approval", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "approval.yaml")
+		assert.Len(t, th.Steps, 3)
+		require.NotNil(t, th.Steps[0].Approval)
+		assert.Equal(t, "Deploy to production?", th.Steps[0].Approval.Message)
+		assert.Equal(t, time.Hour, th.Steps[0].Approval.Timeout)
+		assert.Nil(t, th.Steps[1].Approval)
+		require.NotNil(t, th.Steps[2].Approval)
+		assert.Equal(t, time.Duration(0), th.Steps[2].Approval.Timeout)
+	})
+	t.Run("Sensor", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "sensor.yaml")
+		assert.Len(t, th.Steps, 4)
+
+		file := th.Steps[0].Sensor
+		require.NotNil(t, file)
+		assert.Equal(t, digraph.SensorKindFile, file.Kind)
+		assert.Equal(t, "/data/*.csv", file.Path)
+		assert.Equal(t, 10*time.Second, file.PokeInterval)
+		assert.Equal(t, time.Hour, file.Timeout)
+		assert.True(t, file.SoftFail)
+
+		http := th.Steps[1].Sensor
+		require.NotNil(t, http)
+		assert.Equal(t, digraph.SensorKindHTTP, http.Kind)
+		assert.Equal(t, "https://example.com/health", http.URL)
+		assert.Equal(t, 200, http.StatusCode)
+		assert.Equal(t, "ok", http.Body)
+		assert.Equal(t, time.Minute, http.PokeInterval)
+		assert.Equal(t, time.Duration(0), http.Timeout)
+
+		dag := th.Steps[2].Sensor
+		require.NotNil(t, dag)
+		assert.Equal(t, digraph.SensorKindDAG, dag.Kind)
+		assert.Equal(t, "upstream", dag.DAG)
+		assert.Equal(t, "2025-01-02", dag.Date)
+
+		command := th.Steps[3].Sensor
+		require.NotNil(t, command)
+		assert.Equal(t, digraph.SensorKindCommand, command.Kind)
+		assert.Equal(t, "test -f /tmp/ready", command.Command)
+		assert.Equal(t, "echo", th.Steps[3].Command)
+	})
 	t.Run("SignalOnStop", func(t *testing.T) {
 		t.Parallel()
 
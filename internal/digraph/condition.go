@@ -54,19 +54,24 @@ func (c Condition) eval(ctx context.Context) (bool, error) {
 }
 
 func (c Condition) evalCommand(ctx context.Context) (bool, error) {
-	var commandToRun string
+	var (
+		commandToRun string
+		env          []string
+	)
 	if IsStepContext(ctx) {
 		command, err := GetStepContext(ctx).EvalString(c.Command, cmdutil.OnlyReplaceVars())
 		if err != nil {
 			return false, err
 		}
 		commandToRun = command
+		env = GetStepContext(ctx).AllEnvs()
 	} else if IsContext(ctx) {
 		command, err := GetContext(ctx).EvalString(c.Command, cmdutil.OnlyReplaceVars())
 		if err != nil {
 			return false, err
 		}
 		commandToRun = command
+		env = GetContext(ctx).AllEnvs()
 	} else {
 		command, err := cmdutil.EvalString(ctx, c.Command, cmdutil.OnlyReplaceVars())
 		if err != nil {
@@ -79,6 +84,7 @@ func (c Condition) evalCommand(ctx context.Context) (bool, error) {
 	if shell == "" {
 		// Run the command directly
 		cmd := exec.CommandContext(ctx, commandToRun)
+		cmd.Env = env
 		_, err := cmd.Output()
 		if err != nil {
 			return false, fmt.Errorf("%w: %s", ErrConditionNotMet, err)
@@ -88,6 +94,7 @@ func (c Condition) evalCommand(ctx context.Context) (bool, error) {
 
 	// Run the command through a shell
 	cmd := exec.CommandContext(ctx, shell, "-c", commandToRun)
+	cmd.Env = env
 	_, err := cmd.Output()
 	if err != nil {
 		return false, fmt.Errorf("%w: %s", ErrConditionNotMet, err)
@@ -13,4 +13,14 @@ const (
 	EnvKeyDAGName          = "DAG_NAME"
 	EnvKeyDAGStepName      = "DAG_STEP_NAME"
 	EnvKeyDAGStepLogPath   = "DAG_STEP_LOG_PATH"
+	EnvKeyDAGRunWorkspace  = "DAG_RUN_WORKSPACE"
+	EnvKeyDAGLogicalDate   = "DAG_LOGICAL_DATE"
+	EnvKeyDAGSLAMiss       = "DAG_SLA_MISS"
+)
+
+// Variables available in the params of the triggers of the DAGs.
+const (
+	VarKeyUpstreamName      = "DAG_UPSTREAM_NAME"
+	VarKeyUpstreamRequestID = "DAG_UPSTREAM_REQUEST_ID"
+	VarKeyUpstreamStatus    = "DAG_UPSTREAM_STATUS"
 )
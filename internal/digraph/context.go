@@ -3,9 +3,12 @@ package digraph
 import (
 	"context"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/cmdutil"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/stringutil"
 )
 
 type Context struct {
@@ -23,21 +26,42 @@ func (c Context) GetResult(name, requestID string) (*Status, error) {
 	return c.client.GetStatus(c.ctx, name, requestID)
 }
 
-func (c Context) AllEnvs() []string {
-	envs := os.Environ()
-	envs = append(envs, c.dag.Env...)
+// GetLatestResult returns the result of the latest run of the DAG on the
+// logical date.
+func (c Context) GetLatestResult(name string, date time.Time) (*Status, error) {
+	return c.client.GetLatestStatus(c.ctx, name, date)
+}
+
+// LogicalDate returns the scheduled time the run is for, or the zero time
+// if the run was not started by the schedule.
+func (c Context) LogicalDate() time.Time {
+	t, _ := stringutil.ParseTime(c.envs[EnvKeyDAGLogicalDate])
+	return t
+}
+
+// Envs returns the environment variables of the run: the env and the
+// params of the DAG and the variables set for the run. The params are also
+// set as the positional variables $1, $2, ... The process environment is
+// not changed by the runs, so that the runs in the same process do not
+// share their environment.
+func (c Context) Envs() []string {
+	var envs []string
+	if c.dag != nil {
+		envs = append(envs, c.dag.Env...)
+		for i, param := range c.dag.Params {
+			envs = append(envs, strconv.Itoa(i+1)+"="+param)
+		}
+	}
 	for k, v := range c.envs {
 		envs = append(envs, k+"="+v)
 	}
 	return envs
 }
 
-func (c Context) ApplyEnvs() {
-	for k, v := range c.envs {
-		if err := os.Setenv(k, v); err != nil {
-			logger.Error(c.ctx, "failed to set environment variable %q: %v", k, err)
-		}
-	}
+// AllEnvs returns the environment of the commands of the run, which is the
+// process environment with the variables of the run.
+func (c Context) AllEnvs() []string {
+	return append(os.Environ(), c.Envs()...)
 }
 
 func (c Context) WithEnv(key, value string) Context {
@@ -46,7 +70,7 @@ func (c Context) WithEnv(key, value string) Context {
 }
 
 func (c Context) EvalString(s string, opts ...cmdutil.EvalOption) (string, error) {
-	opts = append(opts, cmdutil.WithVariables(c.envs))
+	opts = append(opts, cmdutil.WithVariables(c.envs), cmdutil.WithEnv(c.Envs()))
 	return cmdutil.EvalString(c.ctx, s, opts...)
 }
 
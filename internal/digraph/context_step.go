@@ -3,6 +3,7 @@ package digraph
 import (
 	"context"
 	"fmt"
+	"os"
 	"strconv"
 
 	"github.com/dagu-org/dagu/internal/cmdutil"
@@ -28,8 +29,10 @@ func NewStepContext(ctx context.Context, step Step) StepContext {
 	}
 }
 
-func (c StepContext) AllEnvs() []string {
-	envs := c.Context.AllEnvs()
+// Envs returns the environment variables of the run with the env and the
+// output variables of the step.
+func (c StepContext) Envs() []string {
+	envs := c.Context.Envs()
 	for k, v := range c.envs {
 		envs = append(envs, k+"="+v)
 	}
@@ -40,6 +43,11 @@ func (c StepContext) AllEnvs() []string {
 	return envs
 }
 
+// AllEnvs returns the environment of the command of the step.
+func (c StepContext) AllEnvs() []string {
+	return append(os.Environ(), c.Envs()...)
+}
+
 func (c StepContext) LoadOutputVariables(vars *SyncMap) {
 	vars.Range(func(key, value any) bool {
 		// Skip if the key already exists
@@ -63,6 +71,7 @@ func (c StepContext) MailerConfig() (mailer.Config, error) {
 func (c StepContext) EvalString(s string, opts ...cmdutil.EvalOption) (string, error) {
 	opts = append(opts, cmdutil.WithVariables(c.envs))
 	opts = append(opts, cmdutil.WithVariables(c.outputVariables.Variables()))
+	opts = append(opts, cmdutil.WithEnv(c.Envs()))
 	return cmdutil.EvalString(c.ctx, s, opts...)
 }
 
@@ -107,5 +116,6 @@ type stepCtxKey struct{}
 
 func EvalStringFields[T any](stepContext StepContext, obj T) (T, error) {
 	return cmdutil.EvalStringFields(stepContext.ctx, obj,
-		cmdutil.WithVariables(stepContext.outputVariables.Variables()))
+		cmdutil.WithVariables(stepContext.outputVariables.Variables()),
+		cmdutil.WithEnv(stepContext.Envs()))
 }
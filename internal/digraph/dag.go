@@ -5,6 +5,7 @@ import (
 	"crypto/md5"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -69,10 +70,137 @@ type DAG struct {
 	RestartWait time.Duration `json:"RestartWait"`
 	// MaxActiveRuns specifies the maximum concurrent steps to run in an execution.
 	MaxActiveRuns int `json:"MaxActiveRuns"`
+	// MaxActiveWeight specifies the maximum total weight of the concurrent
+	// steps in an execution. Each step counts as its Weight.
+	MaxActiveWeight int `json:"MaxActiveWeight,omitempty"`
 	// MaxCleanUpTime is the maximum time to wait for cleanup when the DAG is stopped.
 	MaxCleanUpTime time.Duration `json:"MaxCleanUpTime"`
 	// HistRetentionDays is the number of days to keep the history.
 	HistRetentionDays int `json:"HistRetentionDays"`
+	// Triggers contains the DAGs started when a run of this DAG finishes.
+	Triggers []DAGTrigger `json:"Triggers,omitempty"`
+	// DependsOn contains the DAGs whose finished runs start this DAG.
+	DependsOn []DAGTrigger `json:"DependsOn,omitempty"`
+	// Workspace gives each run a fresh directory shared by its steps.
+	// The steps without a working directory run in the workspace.
+	Workspace *Workspace `json:"Workspace,omitempty"`
+	// Catchup is how the scheduler runs the scheduled runs missed while it
+	// was not running.
+	Catchup CatchupPolicy `json:"Catchup,omitempty"`
+	// Timezone is the time zone the schedule is evaluated in. The location
+	// of the scheduler is used if it is empty.
+	Timezone string `json:"Timezone,omitempty"`
+	// ExcludeCalendars contains the names of the calendars excluded from
+	// the schedule. They are defined in the config of the scheduler.
+	ExcludeCalendars []string `json:"ExcludeCalendars,omitempty"`
+	// Concurrency is what happens when the DAG is started while it is
+	// running.
+	Concurrency Concurrency `json:"Concurrency,omitempty"`
+	// SLA is the service level the runs of the DAG are expected to meet,
+	// which the scheduler monitors.
+	SLA *SLA `json:"SLA,omitempty"`
+	// OnAgentLost is what happens to a run whose agent was lost, e.g.
+	// because the host crashed or rebooted in the middle of the run.
+	OnAgentLost AgentLostPolicy `json:"OnAgentLost,omitempty"`
+	// RetryWindow is how long a run with failed steps is kept open after no
+	// step is left to run, so that the failed steps can be retried in the
+	// run. The run finishes right away if it is zero.
+	RetryWindow time.Duration `json:"RetryWindow,omitempty"`
+}
+
+// SLA is the service level the runs of a DAG are expected to meet.
+type SLA struct {
+	// MaxDuration is the longest time a run may take.
+	MaxDuration time.Duration `json:"MaxDuration,omitempty"`
+	// MustFinishBy is the time of the day, HH:MM in the timezone of the DAG,
+	// by which a run must have finished successfully on the days the DAG is
+	// scheduled.
+	MustFinishBy string `json:"MustFinishBy,omitempty"`
+}
+
+// Concurrency is the policy for the starts of the DAG while it is running.
+type Concurrency struct {
+	// Max is the number of the runs of the DAG running at the same time.
+	// It defaults to 1, i.e. the runs never overlap.
+	Max int `json:"Max,omitempty"`
+	// OnConflict is what happens to a start while Max runs are running.
+	OnConflict OnConflict `json:"OnConflict,omitempty"`
+}
+
+// Limit returns the number of the runs of the DAG running at the same time,
+// which is 1 if Max is not set.
+func (c Concurrency) Limit() int {
+	return max(c.Max, 1)
+}
+
+// OnConflict is the action for a start of a DAG while it is running.
+type OnConflict string
+
+const (
+	// OnConflictSkip drops the start.
+	OnConflictSkip OnConflict = "skip"
+	// OnConflictQueue queues the start until a running run finishes.
+	OnConflictQueue OnConflict = "queue"
+	// OnConflictCancelPrevious stops the running runs and starts the DAG.
+	OnConflictCancelPrevious OnConflict = "cancelPrevious"
+)
+
+// CatchupPolicy is the policy to run the scheduled runs missed while the
+// scheduler was not running.
+type CatchupPolicy string
+
+const (
+	// CatchupNone skips the missed runs.
+	CatchupNone CatchupPolicy = "none"
+	// CatchupLatest runs only the latest missed run.
+	CatchupLatest CatchupPolicy = "latest"
+	// CatchupAll runs all the missed runs in order.
+	CatchupAll CatchupPolicy = "all"
+)
+
+// AgentLostPolicy is the action for a run whose agent was lost.
+type AgentLostPolicy string
+
+const (
+	// AgentLostFail marks the run failed.
+	AgentLostFail AgentLostPolicy = "fail"
+	// AgentLostRetry marks the run failed and retries it.
+	AgentLostRetry AgentLostPolicy = "retry"
+)
+
+// Workspace contains the configuration of the workspace directory created
+// for each run of the DAG. The path is available to the steps as
+// DAG_RUN_WORKSPACE.
+type Workspace struct {
+	// RetentionDays is the number of days to keep the workspace after the
+	// run finishes. Zero means that the workspace is removed as soon as
+	// the run finishes.
+	RetentionDays int `json:"RetentionDays,omitempty"`
+}
+
+// TriggerOn is the outcome of an upstream run that starts the downstream DAG.
+type TriggerOn string
+
+const (
+	// TriggerOnSuccess starts the downstream DAG when the run succeeds.
+	TriggerOnSuccess TriggerOn = "success"
+	// TriggerOnFailure starts the downstream DAG when the run fails.
+	TriggerOnFailure TriggerOn = "failure"
+	// TriggerOnAlways starts the downstream DAG whenever the run finishes.
+	TriggerOnAlways TriggerOn = "always"
+)
+
+// DAGTrigger links the runs of a DAG to another DAG. The runs are detected
+// by the scheduler process, which starts the downstream DAG.
+type DAGTrigger struct {
+	// DAG is the name of the other DAG; the downstream DAG in Triggers and
+	// the upstream DAG in DependsOn.
+	DAG string `json:"DAG"`
+	// On is the outcome of the upstream run that starts the downstream DAG.
+	On TriggerOn `json:"On"`
+	// Params is the parameters passed to the downstream DAG. The parameters
+	// and the outputs of the upstream run can be referenced as variables.
+	Params string `json:"Params,omitempty"`
 }
 
 // Schedule contains the cron expression and the parsed cron schedule.
@@ -89,12 +217,15 @@ type HandlerOn struct {
 	Success *Step `json:"Success"`
 	Cancel  *Step `json:"Cancel"`
 	Exit    *Step `json:"Exit"`
+	// SLAMiss is run by the scheduler when the SLA of the DAG is missed.
+	SLAMiss *Step `json:"SLAMiss,omitempty"`
 }
 
 // MailOn contains the conditions to send mail.
 type MailOn struct {
 	Failure bool `json:"Failure"`
 	Success bool `json:"Success"`
+	SLAMiss bool `json:"SLAMiss,omitempty"`
 }
 
 // SMTPConfig contains the SMTP configuration.
@@ -121,6 +252,7 @@ const (
 	HandlerOnFailure HandlerType = "onFailure"
 	HandlerOnCancel  HandlerType = "onCancel"
 	HandlerOnExit    HandlerType = "onExit"
+	HandlerOnSLAMiss HandlerType = "onSLAMiss"
 )
 
 func (h HandlerType) String() string {
@@ -149,16 +281,70 @@ func (d *DAG) HasTag(tag string) bool {
 	return false
 }
 
+// ScheduledTimes returns the times the DAG is scheduled to start after from
+// and not after to, in ascending order. At most limit times are returned.
+// The times excluded by the calendars are skipped.
+func (d *DAG) ScheduledTimes(from, to time.Time, limit int, calendars ...Calendar) []time.Time {
+	var ret []time.Time
+	schedules := make([]cron.Schedule, len(d.Schedule))
+	next := make([]time.Time, len(d.Schedule))
+	for i, s := range d.Schedule {
+		schedules[i] = ExcludeCalendars(s.Parsed, calendars)
+		next[i] = schedules[i].Next(from)
+	}
+	for len(ret) < limit {
+		// Take the earliest of the next times of the schedules.
+		idx := -1
+		for i, t := range next {
+			if t.IsZero() || t.After(to) {
+				continue
+			}
+			if idx == -1 || t.Before(next[idx]) {
+				idx = i
+			}
+		}
+		if idx == -1 {
+			break
+		}
+		t := next[idx]
+		if len(ret) == 0 || !ret[len(ret)-1].Equal(t) {
+			ret = append(ret, t)
+		}
+		next[idx] = schedules[idx].Next(t)
+	}
+	return ret
+}
+
 // SockAddr returns the unix socket address for the DAG.
 // The address is used to communicate with the agent process.
 func (d *DAG) SockAddr() string {
+	return d.sockAddr(0)
+}
+
+// SockAddrs returns the unix socket addresses of the runs of the DAG
+// running at the same time, one for each of the Concurrency.Max runs. The
+// first one is SockAddr. A run is served on the first address not used by
+// another run.
+func (d *DAG) SockAddrs() []string {
+	addrs := make([]string, d.Concurrency.Limit())
+	for i := range addrs {
+		addrs[i] = d.sockAddr(i)
+	}
+	return addrs
+}
+
+func (d *DAG) sockAddr(slot int) string {
 	// Normalize the location path
 	normalizedPath := strings.ReplaceAll(d.Location, " ", "_")
 	name := strings.TrimSuffix(filepath.Base(normalizedPath), filepath.Ext(filepath.Base(normalizedPath)))
 
-	// Generate hash for uniqueness
+	// Generate hash for uniqueness. The slot is hashed as well so that the
+	// length of the address does not change.
 	hash := md5.New() // nolint // gosec
 	hash.Write([]byte(normalizedPath))
+	if slot > 0 {
+		hash.Write([]byte("#" + strconv.Itoa(slot)))
+	}
 	hashSum := hash.Sum(nil)
 
 	// Truncate name if necessary
@@ -201,6 +387,10 @@ func (d *DAG) setup() {
 	}
 
 	workDir := filepath.Dir(d.Location)
+	if d.Workspace != nil {
+		// The steps run in the workspace of the run by default.
+		workDir = "${" + EnvKeyDAGRunWorkspace + "}"
+	}
 	d.setupSteps(workDir)
 	d.setupHandlers(workDir)
 }
@@ -3,9 +3,11 @@ package digraph_test
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/test"
+	"github.com/robfig/cron/v3"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,6 +20,67 @@ func TestDAG(t *testing.T) {
 	})
 }
 
+func TestScheduledTimes(t *testing.T) {
+	parse := func(t *testing.T, exprs ...string) []digraph.Schedule {
+		t.Helper()
+		var ret []digraph.Schedule
+		for _, expr := range exprs {
+			parsed, err := cron.ParseStandard(expr)
+			require.NoError(t, err)
+			ret = append(ret, digraph.Schedule{Expression: expr, Parsed: parsed})
+		}
+		return ret
+	}
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Range", func(t *testing.T) {
+		dag := &digraph.DAG{Schedule: parse(t, "0 */6 * * *")}
+		require.Equal(t, []time.Time{
+			from.Add(6 * time.Hour),
+			from.Add(12 * time.Hour),
+			from.Add(18 * time.Hour),
+			from.Add(24 * time.Hour),
+		}, dag.ScheduledTimes(from, from.Add(24*time.Hour), 10))
+	})
+	t.Run("MergeSchedules", func(t *testing.T) {
+		dag := &digraph.DAG{Schedule: parse(t, "0 */12 * * *", "0 */6 * * *")}
+		require.Equal(t, []time.Time{
+			from.Add(6 * time.Hour),
+			from.Add(12 * time.Hour),
+			from.Add(18 * time.Hour),
+		}, dag.ScheduledTimes(from, from.Add(23*time.Hour), 10))
+	})
+	t.Run("Limit", func(t *testing.T) {
+		dag := &digraph.DAG{Schedule: parse(t, "* * * * *")}
+		require.Len(t, dag.ScheduledTimes(from, from.Add(24*time.Hour), 5), 5)
+	})
+	t.Run("NoSchedule", func(t *testing.T) {
+		dag := &digraph.DAG{}
+		require.Empty(t, dag.ScheduledTimes(from, from.Add(24*time.Hour), 5))
+	})
+	t.Run("ExcludeCalendars", func(t *testing.T) {
+		dag := &digraph.DAG{Schedule: parse(t, "0 0 * * *")}
+		// Exclude the third day.
+		calendar := windowCalendar{start: from.Add(48 * time.Hour), end: from.Add(72 * time.Hour)}
+		require.Equal(t, []time.Time{
+			from.Add(24 * time.Hour * 3),
+			from.Add(24 * time.Hour * 4),
+		}, dag.ScheduledTimes(from.Add(24*time.Hour), from.Add(24*time.Hour*4), 10, calendar))
+	})
+}
+
+// windowCalendar excludes the times in [start, end).
+type windowCalendar struct {
+	start, end time.Time
+}
+
+func (c windowCalendar) ExcludedUntil(t time.Time) time.Time {
+	if t.Before(c.start) || !t.Before(c.end) {
+		return time.Time{}
+	}
+	return c.end
+}
+
 func TestUnixSocket(t *testing.T) {
 	t.Run("Location", func(t *testing.T) {
 		dag := &digraph.DAG{Location: "testdata/testDag.yml"}
@@ -35,4 +98,17 @@ func TestUnixSocket(t *testing.T) {
 			dag.SockAddr(),
 		)
 	})
+	t.Run("SockAddrs", func(t *testing.T) {
+		dag := &digraph.DAG{Location: "testdata/testDag.yml"}
+		require.Equal(t, []string{dag.SockAddr()}, dag.SockAddrs())
+
+		dag.Concurrency.Max = 3
+		addrs := dag.SockAddrs()
+		require.Len(t, addrs, 3)
+		require.Equal(t, dag.SockAddr(), addrs[0])
+		for _, addr := range addrs {
+			require.Len(t, addr, len(dag.SockAddr()))
+		}
+		require.NotEqual(t, addrs[1], addrs[2])
+	})
 }
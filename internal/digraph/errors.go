@@ -67,6 +67,34 @@ var (
 	ErrContinueOnExitCodeMustBeIntOrArray  = errors.New("continueOn.ExitCode must be an int or an array of ints")
 	ErrDependsMustBeStringOrArray          = errors.New("depends must be a string or an array of strings")
 	ErrStepsMustBeArrayOrMap               = errors.New("steps must be an array or a map")
+	ErrWeightMustBePositive                = errors.New("weight must be a positive integer")
+	ErrInvalidCacheType                    = errors.New("cache must be a boolean or a map")
+	ErrCacheEnvMustBeStringOrArray         = errors.New("cache.env must be a string or an array of strings")
+	ErrCacheFilesMustBeStringOrArray       = errors.New("cache.files must be a string or an array of strings")
+	ErrInvalidApprovalType                 = errors.New("approval must be a boolean or a map")
+	ErrApprovalTimeoutMustBePositive       = errors.New("approval.timeoutSec must not be negative")
+	ErrInvalidTriggerType                  = errors.New("triggers and dependsOn must be a string, a map or an array of them")
+	ErrTriggerDAGRequired                  = errors.New("dag is required in triggers and dependsOn")
+	ErrInvalidTriggerOn                    = errors.New("on must be one of success, failure and always")
+	ErrInvalidSensorKind                   = errors.New("sensor.kind must be one of file, http, dag and command")
+	ErrSensorTargetRequired                = errors.New("sensor requires path, url, dag or command depending on its kind")
+	ErrSensorPokeIntervalMustBePositive    = errors.New("sensor.pokeIntervalSec must not be negative")
+	ErrSensorTimeoutMustBePositive         = errors.New("sensor.timeoutSec must not be negative")
+	ErrRepeatLimitMustBePositive           = errors.New("repeatPolicy.limit must not be negative")
+	ErrRepeatExitCodeMustBeIntOrArray      = errors.New("repeatPolicy.exitCode must be an int or an array of ints")
+	ErrInvalidRepeatCron                   = errors.New("invalid repeatPolicy.cron")
+	ErrInvalidStepEnvType                  = errors.New("step env must be a map or an array of maps or KEY=VALUE strings")
+	ErrInvalidWorkspaceType                = errors.New("workspace must be a boolean or a map")
+	ErrWorkspaceRetentionMustBePositive    = errors.New("workspace.retentionDays must not be negative")
+	ErrInvalidCatchup                      = errors.New("catchup must be one of latest, all and none")
+	ErrInvalidTimezone                     = errors.New("invalid timezone")
+	ErrEmptyCalendarName                   = errors.New("excludeCalendars must not contain an empty name")
+	ErrInvalidConcurrencyMax               = errors.New("concurrency.max must be a positive integer")
+	ErrInvalidOnConflict                   = errors.New("concurrency.onConflict must be one of skip, queue and cancelPrevious")
+	ErrInvalidSLAMaxDuration               = errors.New("sla.maxDuration must be a positive duration, e.g. 2h30m")
+	ErrInvalidSLAMustFinishBy              = errors.New("sla.mustFinishBy must be a time of the day in the format HH:MM")
+	ErrInvalidOnAgentLost                  = errors.New("onAgentLost must be one of fail and retry")
+	ErrInvalidRetryWindow                  = errors.New("retryWindow must be a positive duration, e.g. 30m")
 )
 
 // ErrorList is just a list of errors.
@@ -16,6 +16,7 @@ import (
 
 var _ Executor = (*commandExecutor)(nil)
 var _ ExitCoder = (*commandExecutor)(nil)
+var _ Suspender = (*commandExecutor)(nil)
 
 type commandExecutor struct {
 	mu         sync.Mutex
@@ -86,6 +87,16 @@ func (e *commandExecutor) Kill(sig os.Signal) error {
 	return nil
 }
 
+// Suspend implements Suspender.
+func (e *commandExecutor) Suspend() error {
+	return e.Kill(syscall.SIGSTOP)
+}
+
+// Resume implements Suspender.
+func (e *commandExecutor) Resume() error {
+	return e.Kill(syscall.SIGCONT)
+}
+
 type commandConfig struct {
 	Ctx              context.Context
 	Dir              string
@@ -21,6 +21,13 @@ type ExitCoder interface {
 	ExitCode() int
 }
 
+// Suspender is implemented by the executors whose process can be suspended
+// and resumed in place, e.g. with SIGSTOP and SIGCONT.
+type Suspender interface {
+	Suspend() error
+	Resume() error
+}
+
 type Creator func(ctx context.Context, step digraph.Step) (Executor, error)
 
 var (
@@ -3,12 +3,23 @@
 
 package digraph
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrRunNotFound is returned when no run of a DAG matches the query.
+var ErrRunNotFound = errors.New("run not found")
 
 // DBClient gets a result of a DAG execution.
 type DBClient interface {
 	GetDAG(ctx context.Context, name string) (*DAG, error)
 	GetStatus(ctx context.Context, name string, requestID string) (*Status, error)
+	// GetLatestStatus returns the result of the latest run of the DAG for
+	// the logical date, taking the date the run started on for the runs
+	// without one. It returns ErrRunNotFound if there is none.
+	GetLatestStatus(ctx context.Context, name string, date time.Time) (*Status, error)
 }
 
 // Status is the result of a DAG execution.
@@ -19,4 +30,6 @@ type Status struct {
 	Params string `json:"params,omitempty"`
 	// Outputs is the outputs of the DAG execution.
 	Outputs map[string]string `json:"outputs,omitempty"`
+	// Status is the status of the DAG execution, e.g. "finished".
+	Status string `json:"status,omitempty"`
 }
@@ -134,7 +134,7 @@ func LoadBaseConfig(ctx BuildContext, file string) (*DAG, error) {
 		return nil, err
 	}
 
-	ctx = ctx.WithOpts(BuildOpts{NoEval: ctx.opts.NoEval}).WithFile(file)
+	ctx = ctx.WithOpts(BuildOpts{NoEval: ctx.opts.NoEval, OnlyMetadata: ctx.opts.OnlyMetadata}).WithFile(file)
 	return build(ctx, def)
 }
 
@@ -206,8 +206,9 @@ func resolveYamlFilePath(file string) (string, error) {
 }
 
 // loadBaseConfigIfRequired loads the base config if needed, based on the given options.
+// Only the metadata of the base config is loaded along with the metadata of the DAG.
 func loadBaseConfigIfRequired(ctx BuildContext, baseConfig string) (*DAG, error) {
-	if !ctx.opts.OnlyMetadata && baseConfig != "" {
+	if baseConfig != "" {
 		dag, err := LoadBaseConfig(ctx, baseConfig)
 		if err != nil {
 			// Failed to load the base config.
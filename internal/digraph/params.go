@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -18,6 +19,9 @@ func buildParams(ctx BuildContext, spec *definition, dag *DAG) error {
 		envs       []string
 	)
 
+	// The params may refer to the env of the DAG.
+	ctx = ctx.WithEnvs(dag.Env)
+
 	if err := parseParams(ctx, spec.Params, &paramPairs, &envs); err != nil {
 		return err
 	}
@@ -115,26 +119,26 @@ func parseParams(ctx BuildContext, value any, params *[]paramPair, envs *[]strin
 		return wrapError("params", value, fmt.Errorf("%w: %s", ErrInvalidParamValue, err))
 	}
 
+	// The params may refer to the params before them as $1, $2, $3, ...
+	// or by their names.
+	vars := slices.Clone(ctx.envs)
 	for index, paramPair := range paramPairs {
 		if !ctx.opts.NoEval {
-			paramPair.Value = os.ExpandEnv(paramPair.Value)
+			value, err := cmdutil.EvalString(ctx.ctx, paramPair.Value, cmdutil.WithEnv(vars), cmdutil.WithoutSubstitute())
+			if err != nil {
+				return wrapError("params", paramPair.Value, fmt.Errorf("%w: %s", ErrInvalidParamValue, err))
+			}
+			paramPair.Value = value
 		}
 
 		*params = append(*params, paramPair)
 
 		paramString := paramPair.String()
-
-		// Set the parameter as an environment variable for the command
-		// $1, $2, $3, ...
-		if err := os.Setenv(strconv.Itoa(index+1), paramString); err != nil {
-			return wrapError("params", paramString, fmt.Errorf("failed to set environment variable: %w", err))
-		}
+		vars = append(vars, strconv.Itoa(index+1)+"="+paramString)
 
 		if !ctx.opts.NoEval && paramPair.Name != "" {
 			*envs = append(*envs, paramString)
-			if err := os.Setenv(paramPair.Name, paramPair.Value); err != nil {
-				return wrapError("params", paramString, fmt.Errorf("failed to set environment variable: %w", err))
-			}
+			vars = append(vars, paramString)
 		}
 	}
 
@@ -215,7 +219,7 @@ func parseMapParams(ctx BuildContext, input []any) ([]paramPair, error) {
 				}
 
 				if !ctx.opts.NoEval {
-					parsed, err := cmdutil.EvalString(ctx.ctx, valueStr)
+					parsed, err := cmdutil.EvalString(ctx.ctx, valueStr, cmdutil.WithEnv(ctx.envs))
 					if err != nil {
 						return nil, wrapError("params", valueStr, fmt.Errorf("%w: %s", ErrInvalidParamValue, err))
 					}
@@ -263,8 +267,14 @@ func parseStringParams(ctx BuildContext, input string) ([]paramPair, error) {
 					value,
 					func(match string) string {
 						cmdStr := strings.Trim(match, "`")
-						cmdStr = os.ExpandEnv(cmdStr)
-						cmdOut, err := exec.Command("sh", "-c", cmdStr).Output()
+						cmdStr, err := cmdutil.EvalString(ctx.ctx, cmdStr, cmdutil.WithEnv(ctx.envs), cmdutil.WithoutSubstitute())
+						if err != nil {
+							cmdErr = err
+							return match
+						}
+						cmd := exec.Command("sh", "-c", cmdStr)
+						cmd.Env = append(os.Environ(), ctx.envs...)
+						cmdOut, err := cmd.Output()
 						if err != nil {
 							cmdErr = err
 							// Leave the original command if it fails
@@ -2,21 +2,26 @@ package digraph
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/robfig/cron/v3"
 )
 
+// cronParser parses the cron expressions with an optional seconds field and
+// the descriptors such as @daily and @every 90s.
 var cronParser = cron.NewParser(
-	cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow,
+	cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
 )
 
 // buildScheduler parses the schedule values and returns a list of schedules.
-// each schedule is parsed as a cron expression.
-func buildScheduler(values []string) ([]Schedule, error) {
+// each schedule is parsed as a cron expression in the location, unless the
+// expression specifies its own with CRON_TZ.
+func buildScheduler(values []string, loc *time.Location) ([]Schedule, error) {
 	var ret []Schedule
 
 	for _, v := range values {
-		parsed, err := cronParser.Parse(v)
+		parsed, err := parseSchedule(v, loc)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %s", ErrInvalidSchedule, err)
 		}
@@ -26,6 +31,172 @@ func buildScheduler(values []string) ([]Schedule, error) {
 	return ret, nil
 }
 
+// parseSchedule parses the cron expression. The schedules with fields are
+// adjusted for the daylight saving time transitions of their location.
+func parseSchedule(expr string, loc *time.Location) (cron.Schedule, error) {
+	parsed, err := cronParser.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	if every, ok := parsed.(cron.ConstantDelaySchedule); ok {
+		return everySchedule{delay: every.Delay}, nil
+	}
+	spec, ok := parsed.(*cron.SpecSchedule)
+	if !ok {
+		return parsed, nil
+	}
+	if loc != nil && !strings.HasPrefix(expr, "CRON_TZ=") && !strings.HasPrefix(expr, "TZ=") {
+		spec.Location = loc
+	}
+	return &dstSchedule{spec: spec}, nil
+}
+
+// everySchedule runs at the multiples of the delay, counted from the zero
+// time, e.g. @every 90s. Unlike cron.ConstantDelaySchedule, the times do not
+// depend on when the schedule is evaluated, so that the scheduler can
+// compute the same times on every tick and after a restart.
+type everySchedule struct {
+	delay time.Duration
+}
+
+// Next implements cron.Schedule.
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.Truncate(s.delay).Add(s.delay)
+}
+
+// allHours is the bits of the hour field of a schedule run every hour.
+const allHours = 1<<24 - 1
+
+// dstSchedule adjusts a schedule run at fixed hours for the daylight saving
+// time transitions, like the traditional cron:
+//
+//   - A time skipped by the clocks going forward runs at the end of the gap,
+//     e.g. 02:30 runs at 03:00 on the day the clocks go from 02:00 to 03:00.
+//   - A time repeated by the clocks going back runs only the first time.
+//
+// The schedules run every hour are not adjusted; they run every hour that
+// exists on the clock.
+type dstSchedule struct {
+	spec *cron.SpecSchedule
+}
+
+// Next implements cron.Schedule.
+func (s *dstSchedule) Next(t time.Time) time.Time {
+	next := s.spec.Next(t)
+	if next.IsZero() || s.spec.Hour&allHours == allHours {
+		return next
+	}
+	loc := s.spec.Location
+	if loc == time.Local {
+		loc = t.Location()
+	}
+
+	if gapEnd, ok := s.skippedByGap(t.In(loc), next.In(loc)); ok {
+		return gapEnd.In(t.Location())
+	}
+	for repeatedWallClock(next.In(loc)) {
+		next = s.spec.Next(next)
+		if next.IsZero() {
+			break
+		}
+	}
+	return next
+}
+
+// skippedByGap returns the end of the gap of the clocks going forward
+// between t and next if the schedule would have run in the gap.
+func (s *dstSchedule) skippedByGap(t, next time.Time) (time.Time, bool) {
+	_, before := t.Zone()
+	_, after := next.Zone()
+	if after <= before {
+		return time.Time{}, false
+	}
+
+	// Find the instant of the transition.
+	lo, hi := t, next
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		if _, offset := mid.Zone(); offset > before {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	transition := hi.Truncate(time.Second)
+	gap := time.Duration(after-before) * time.Second
+
+	// Run the schedule on the wall clock before the transition, which goes
+	// through the times skipped by the transition.
+	spec := *s.spec
+	spec.Location = time.FixedZone("", before)
+	if skipped := spec.Next(transition.Add(-time.Second)); skipped.Before(transition.Add(gap)) {
+		return transition, true
+	}
+	return time.Time{}, false
+}
+
+// repeatedWallClock returns true if the time shows the same wall clock as
+// an earlier time, which happens after the clocks go back.
+func repeatedWallClock(t time.Time) bool {
+	_, offset := t.Zone()
+	_, earlier := t.Add(-3 * time.Hour).Zone()
+	if earlier <= offset {
+		return false
+	}
+	prev := t.Add(-time.Duration(earlier-offset) * time.Second)
+	return prev.Format(time.DateTime) == t.Format(time.DateTime)
+}
+
+// Calendar excludes periods such as holidays and blackout windows from the
+// schedules of the DAGs.
+type Calendar interface {
+	// ExcludedUntil returns the end of the excluded period containing the
+	// time, or the zero time if the time is not excluded.
+	ExcludedUntil(t time.Time) time.Time
+}
+
+// maxExcludedRuns bounds the consecutive scheduled times skipped by the
+// calendars, to give up on a schedule excluded forever.
+const maxExcludedRuns = 10000
+
+// ExcludeCalendars returns the schedule skipping the times excluded by the
+// calendars.
+func ExcludeCalendars(schedule cron.Schedule, calendars []Calendar) cron.Schedule {
+	if len(calendars) == 0 {
+		return schedule
+	}
+	return &calendarSchedule{schedule: schedule, calendars: calendars}
+}
+
+type calendarSchedule struct {
+	schedule  cron.Schedule
+	calendars []Calendar
+}
+
+// Next implements cron.Schedule. It returns the zero time if the schedule
+// is excluded for too long.
+func (s *calendarSchedule) Next(t time.Time) time.Time {
+	next := s.schedule.Next(t)
+	for i := 0; i < maxExcludedRuns && !next.IsZero(); i++ {
+		until := s.excludedUntil(next)
+		if until.IsZero() {
+			return next
+		}
+		next = s.schedule.Next(until.Add(-time.Nanosecond))
+	}
+	return time.Time{}
+}
+
+func (s *calendarSchedule) excludedUntil(t time.Time) time.Time {
+	var ret time.Time
+	for _, c := range s.calendars {
+		if until := c.ExcludedUntil(t); until.After(ret) {
+			ret = until
+		}
+	}
+	return ret
+}
+
 // parseScheduleMap parses the schedule map and populates the starts, stops,
 // and restarts slices. Each key in the map must be either "start", "stop", or
 // "restart". The value can be Case 1 or Case 2.
@@ -0,0 +1,266 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/logger"
+)
+
+// control is a change of the run requested while it is running. It is
+// applied by the scheduling loop so that it does not race with the loop.
+type control struct {
+	apply  func(ctx context.Context, state *runState) error
+	result chan error
+}
+
+// runState is the state of the scheduling loop.
+type runState struct {
+	graph *ExecutionGraph
+	// ready holds the nodes that can be started.
+	ready []*Node
+	// launched holds the nodes launched in the run.
+	launched map[int]bool
+	// active holds the nodes launched and not completed yet.
+	active map[int]bool
+	done   chan *Node
+}
+
+// report sends the node to the done channel to report its status.
+func (s *runState) report(node *Node) {
+	if s.done != nil {
+		s.done <- node
+	}
+}
+
+// failedNode returns a node launched in the run that has failed or been
+// canceled, or nil if there is none.
+func (s *runState) failedNode() *Node {
+	for id := range s.launched {
+		node := s.graph.dict[id]
+		if status := node.State().Status; status == NodeStatusError || status == NodeStatusCancel {
+			return node
+		}
+	}
+	return nil
+}
+
+// awaitRetry keeps the run open for the retry window when no step is left
+// to run and a step has failed or been canceled, so that the step can be
+// retried after the other steps finished. It applies the changes of the run
+// meanwhile, and returns true when a step is ready to run again, or false
+// when the run is to finish: the window elapsed, or the run was canceled or
+// timed out.
+func (sc *Scheduler) awaitRetry(ctx context.Context, state *runState) bool {
+	if sc.retryWindow <= 0 {
+		return false
+	}
+	failed := state.failedNode()
+	if failed == nil {
+		return false
+	}
+
+	logger.Info(ctx, "Waiting for the failed steps to be retried", "retryWindow", sc.retryWindow)
+	sc.setAwaitingRetry(true)
+	defer sc.setAwaitingRetry(false)
+	// Report the run as running while it waits.
+	state.report(failed)
+
+	timer := time.NewTimer(sc.retryWindow)
+	defer timer.Stop()
+	for len(state.ready) == 0 {
+		select {
+		case req := <-sc.controls:
+			req.result <- req.apply(ctx, state)
+
+		case <-timer.C:
+			logger.Info(ctx, "The retry window has elapsed")
+			return false
+
+		case <-sc.cancelCh:
+			return false
+
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// control sends the change to the scheduling loop and waits for the result.
+func (sc *Scheduler) control(apply func(ctx context.Context, state *runState) error) error {
+	req := control{apply: apply, result: make(chan error, 1)}
+	select {
+	case sc.controls <- req:
+		return <-req.result
+	case <-sc.controlsClosed:
+		return ErrRunFinished
+	}
+}
+
+// stopControls rejects the changes requested after the scheduling loop
+// stopped.
+func (sc *Scheduler) stopControls() {
+	sc.closeControls.Do(func() {
+		close(sc.controlsClosed)
+	})
+}
+
+// CancelStep cancels the running step. The steps depending on it are
+// canceled and the run finishes as failed unless the step is retried.
+func (sc *Scheduler) CancelStep(graph *ExecutionGraph, stepName string) error {
+	node, err := graph.findStep(stepName)
+	if err != nil {
+		return err
+	}
+	return sc.control(func(ctx context.Context, state *runState) error {
+		switch node.State().Status {
+		case NodeStatusRunning, NodeStatusQueued, NodeStatusWaiting:
+			if state.active[node.id] {
+				break
+			}
+			fallthrough
+		default:
+			return fmt.Errorf("%w: %s", ErrStepNotRunning, stepName)
+		}
+		logger.Info(ctx, "Canceling step", "step", stepName)
+		node.Cancel(ctx)
+		sc.setLastError(fmt.Errorf("%w: %s", ErrStepCanceled, stepName))
+		return nil
+	})
+}
+
+// SkipStep skips the step that has not started. The steps depending on it
+// are skipped as well unless they have continueOn.skipped set.
+func (sc *Scheduler) SkipStep(graph *ExecutionGraph, stepName string) error {
+	node, err := graph.findStep(stepName)
+	if err != nil {
+		return err
+	}
+	return sc.control(func(ctx context.Context, state *runState) error {
+		if node.State().Status != NodeStatusNone || state.active[node.id] || state.graph.reused[node.id] {
+			return fmt.Errorf("%w: %s", ErrStepNotPending, stepName)
+		}
+		logger.Info(ctx, "Skipping step", "step", stepName)
+		node.data.SetStatus(NodeStatusSkipped)
+		node.data.SetError(ErrStepSkipped)
+		if idx := slices.Index(state.ready, node); idx >= 0 {
+			state.ready = slices.Delete(state.ready, idx, idx+1)
+			state.ready = append(state.ready, sc.resolveReady(ctx, state.graph, state.graph.finishNode(node))...)
+		}
+		// Otherwise the downstream nodes are resolved when the upstream
+		// nodes of the node finish.
+		state.report(node)
+		return nil
+	})
+}
+
+// RetryStep runs the failed or canceled step again in the running run. The
+// steps canceled because of it wait for it again.
+func (sc *Scheduler) RetryStep(graph *ExecutionGraph, stepName string) error {
+	node, err := graph.findStep(stepName)
+	if err != nil {
+		return err
+	}
+	return sc.control(func(ctx context.Context, state *runState) error {
+		status := node.State().Status
+		if !state.launched[node.id] || state.active[node.id] ||
+			(status != NodeStatusError && status != NodeStatusCancel) {
+			return fmt.Errorf("%w: %s", ErrStepNotFailed, stepName)
+		}
+		logger.Info(ctx, "Retrying step", "step", stepName)
+		node.data.ResetError()
+		node.data.SetRetriedAt(time.Now())
+		node.data.SetStatus(NodeStatusNone)
+		state.graph.resetDownstream(node, state.launched)
+
+		// The ready nodes depending on the node wait for it again.
+		state.ready = slices.DeleteFunc(state.ready, state.graph.isWaiting)
+		state.ready = append(state.ready, node)
+		sc.clearLastError(state.graph)
+		state.report(node)
+		return nil
+	})
+}
+
+// SetMaxActiveRuns changes the maximum number of the steps running at the
+// same time. Zero means no limit. The running steps are not stopped.
+func (sc *Scheduler) SetMaxActiveRuns(maxActiveRuns int) error {
+	if maxActiveRuns < 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidMaxActiveRuns, maxActiveRuns)
+	}
+	return sc.control(func(ctx context.Context, _ *runState) error {
+		logger.Info(ctx, "Changing max active runs", "from", sc.maxActiveRuns, "to", maxActiveRuns)
+		sc.maxActiveRuns = maxActiveRuns
+		return nil
+	})
+}
+
+// Pause stops starting new steps until the run is resumed. The running
+// steps keep running unless suspendSteps is true, in which case the steps
+// that support it are suspended in place, e.g. with SIGSTOP.
+func (sc *Scheduler) Pause(suspendSteps bool) error {
+	return sc.control(func(ctx context.Context, state *runState) error {
+		sc.mu.Lock()
+		defer sc.mu.Unlock()
+
+		if sc.paused {
+			return ErrRunPaused
+		}
+		logger.Info(ctx, "Pausing run", "suspendSteps", suspendSteps)
+		sc.paused = true
+		if !suspendSteps {
+			return nil
+		}
+		for id := range state.active {
+			node := state.graph.dict[id]
+			if node.State().Status == NodeStatusRunning && node.suspend(ctx) {
+				sc.suspended = append(sc.suspended, node)
+			}
+		}
+		return nil
+	})
+}
+
+// Resume starts the steps waiting since the run was paused and resumes the
+// suspended steps.
+func (sc *Scheduler) Resume() error {
+	return sc.control(func(ctx context.Context, _ *runState) error {
+		sc.mu.Lock()
+		if !sc.paused {
+			sc.mu.Unlock()
+			return ErrRunNotPaused
+		}
+		logger.Info(ctx, "Resuming run")
+		sc.paused = false
+		sc.mu.Unlock()
+
+		sc.resumeSuspended(ctx)
+		return nil
+	})
+}
+
+// resumeSuspended resumes the steps suspended by the pause.
+func (sc *Scheduler) resumeSuspended(ctx context.Context) {
+	sc.mu.Lock()
+	suspended := sc.suspended
+	sc.suspended = nil
+	sc.mu.Unlock()
+
+	for _, node := range suspended {
+		node.resume(ctx)
+	}
+}
+
+// clearLastError clears the last error if no step has failed or been
+// canceled, e.g. after the failed step is retried.
+func (sc *Scheduler) clearLastError(graph *ExecutionGraph) {
+	for _, node := range graph.Nodes() {
+		if status := node.State().Status; status == NodeStatusError || status == NodeStatusCancel {
+			return
+		}
+	}
+	sc.setLastError(nil)
+}
@@ -33,8 +33,26 @@ type NodeState struct {
 	DoneCount  int
 	Error      error
 	ExitCode   int
+	// Approver is the identity of the user who approved or rejected the
+	// node that requires a manual approval.
+	Approver string
+	// ApprovalComment is the comment given with the approval or rejection.
+	ApprovalComment string
+	// Repetitions contains the executions of a node with a repeat policy.
+	// Only the latest maxRepetitions executions are kept.
+	Repetitions []Repetition
+}
+
+// Repetition is an execution of a node with a repeat policy.
+type Repetition struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+	ExitCode   int
 }
 
+// maxRepetitions is the number of the latest repetitions kept in the state.
+const maxRepetitions = 100
+
 type NodeStatus int
 
 const (
@@ -44,6 +62,15 @@ const (
 	NodeStatusCancel
 	NodeStatusSuccess
 	NodeStatusSkipped
+	// NodeStatusQueued means the node is waiting for a slot of its resource
+	// pool before it starts running.
+	NodeStatusQueued
+	// NodeStatusCached means the node succeeded with the result of a
+	// previous run taken from the step cache.
+	NodeStatusCached
+	// NodeStatusWaiting means the node is waiting for a manual approval
+	// before it starts running.
+	NodeStatusWaiting
 )
 
 func (s NodeStatus) String() string {
@@ -58,6 +85,12 @@ func (s NodeStatus) String() string {
 		return "finished"
 	case NodeStatusSkipped:
 		return "skipped"
+	case NodeStatusQueued:
+		return "queued"
+	case NodeStatusCached:
+		return "cached"
+	case NodeStatusWaiting:
+		return "waiting"
 	case NodeStatusNone:
 		fallthrough
 	default:
@@ -314,6 +347,21 @@ func (n *SafeData) GetDoneCount() int {
 	return n.inner.State.DoneCount
 }
 
+// AddRepetition records an execution of the node with a repeat policy.
+func (n *SafeData) AddRepetition(startedAt, finishedAt time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.inner.State.Repetitions = append(n.inner.State.Repetitions, Repetition{
+		StartedAt:  startedAt,
+		FinishedAt: finishedAt,
+		ExitCode:   n.inner.State.ExitCode,
+	})
+	if over := len(n.inner.State.Repetitions) - maxRepetitions; over > 0 {
+		n.inner.State.Repetitions = n.inner.State.Repetitions[over:]
+	}
+}
+
 func (n *SafeData) GetExitCode() int {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
@@ -335,6 +383,14 @@ func (n *SafeData) ClearState() {
 	n.inner.State = NodeState{}
 }
 
+func (n *SafeData) SetApproval(approver, comment string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.inner.State.Approver = approver
+	n.inner.State.ApprovalComment = comment
+}
+
 func (n *SafeData) MarkError(err error) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
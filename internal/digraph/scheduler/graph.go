@@ -20,6 +20,16 @@ type ExecutionGraph struct {
 	from       map[int][]int
 	to         map[int][]int
 	mu         sync.RWMutex
+
+	// inDegrees holds the number of upstream nodes that have not finished
+	// yet for each node. A node becomes a candidate for execution when its
+	// counter reaches zero.
+	inDegrees map[int]int
+
+	// reused holds the nodes that are not executed in a partial run.
+	// Their states and outputs are taken from a previous run, and the
+	// downstream nodes do not wait for them.
+	reused map[int]bool
 }
 
 // NewExecutionGraph creates a new execution graph with the given steps.
@@ -65,6 +75,55 @@ func CreateRetryExecutionGraph(ctx context.Context, nodes ...*Node) (*ExecutionG
 	return graph, nil
 }
 
+// CreatePartialExecutionGraph creates a new execution graph that runs only
+// the target steps, and also the steps that depend on them if downstream
+// is true. The other nodes are not executed; their states and outputs are
+// copied from the nodes of the same name in the reference nodes so that
+// the target steps can use the outputs.
+func CreatePartialExecutionGraph(
+	ctx context.Context, steps []digraph.Step, reference []*Node, targets []string, downstream bool,
+) (*ExecutionGraph, error) {
+	graph, err := NewExecutionGraph(steps...)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := graph.subgraph(targets, downstream)
+	if err != nil {
+		return nil, err
+	}
+
+	prev := make(map[string]NodeData, len(reference))
+	for _, node := range reference {
+		data := node.Data()
+		prev[data.Step.Name] = data
+	}
+
+	graph.reused = make(map[int]bool)
+	for _, node := range graph.nodes {
+		if selected[node.id] {
+			continue
+		}
+		graph.reused[node.id] = true
+
+		data, ok := prev[node.data.Name()]
+		if !ok {
+			continue
+		}
+		step := node.data.Step()
+		step.OutputVariables = data.Step.OutputVariables
+		state := data.State
+		if !isFinished(state.Status) {
+			// the reference run was interrupted
+			state.Status = NodeStatusNone
+		}
+		node.data = newSafeData(NodeData{Step: step, State: state})
+		logger.Info(ctx, "reuse node state", "step", step.Name, "status", state.Status)
+	}
+
+	return graph, nil
+}
+
 // Duration returns the duration of the execution.
 func (g *ExecutionGraph) Duration() time.Duration {
 	g.mu.RLock()
@@ -97,7 +156,7 @@ func (g *ExecutionGraph) IsRunning() bool {
 	g.mu.RLock()
 	defer g.mu.RUnlock()
 	for _, node := range g.Nodes() {
-		if node.State().Status == NodeStatusRunning {
+		if status := node.State().Status; status == NodeStatusRunning || status == NodeStatusQueued || status == NodeStatusWaiting {
 			return true
 		}
 	}
@@ -145,6 +204,113 @@ func (g *ExecutionGraph) node(id int) *Node {
 	return g.dict[id]
 }
 
+// initInDegrees resets the in-degree counters from the current node states
+// and returns the nodes that are not started and have no unfinished upstream
+// nodes. Nodes that already finished (e.g., in a retry graph) are not counted.
+func (g *ExecutionGraph) initInDegrees() []*Node {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.inDegrees = make(map[int]int, len(g.nodes))
+	var ret []*Node
+	for _, node := range g.nodes {
+		if g.reused[node.id] {
+			continue
+		}
+		for _, dep := range g.to[node.id] {
+			if !g.reused[dep] && !isFinished(g.dict[dep].State().Status) {
+				g.inDegrees[node.id]++
+			}
+		}
+		if g.inDegrees[node.id] == 0 && node.State().Status == NodeStatusNone {
+			ret = append(ret, node)
+		}
+	}
+	return ret
+}
+
+// finishNode decrements the in-degree counters of the downstream nodes of
+// the given node and returns the nodes whose counter reached zero.
+func (g *ExecutionGraph) finishNode(node *Node) []*Node {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var ret []*Node
+	for _, next := range g.from[node.id] {
+		if g.reused[next] {
+			continue
+		}
+		g.inDegrees[next]--
+		if g.inDegrees[next] == 0 {
+			ret = append(ret, g.dict[next])
+		}
+	}
+	return ret
+}
+
+// resetDownstream clears the states of the downstream nodes of the node
+// that were not launched, i.e. canceled or skipped because of its result,
+// so that they wait for the node again. The in-degree counters of the
+// pending downstream nodes are restored since the node is going to finish
+// again.
+func (g *ExecutionGraph) resetDownstream(node *Node, launched map[int]bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	reset := []*Node{node}
+	for i := 0; i < len(reset); i++ {
+		for _, id := range g.from[reset[i].id] {
+			if g.reused[id] || launched[id] {
+				continue
+			}
+			next := g.dict[id]
+			state := next.State()
+			if isFinished(state.Status) && !errors.Is(state.Error, ErrStepSkipped) {
+				next.data.ClearState()
+				reset = append(reset, next)
+			}
+			if next.State().Status == NodeStatusNone {
+				g.inDegrees[id]++
+			}
+		}
+	}
+}
+
+// isWaiting returns true if the node has upstream nodes not finished yet.
+func (g *ExecutionGraph) isWaiting(node *Node) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.inDegrees[node.id] > 0
+}
+
+// subgraph returns the IDs of the target nodes, and the IDs of all the
+// nodes downstream of them if downstream is true.
+func (g *ExecutionGraph) subgraph(targets []string, downstream bool) (map[int]bool, error) {
+	ret := make(map[int]bool)
+	var frontier []int
+	for _, name := range targets {
+		node, err := g.findStep(name)
+		if err != nil {
+			return nil, err
+		}
+		ret[node.id] = true
+		frontier = append(frontier, node.id)
+	}
+	for downstream && len(frontier) > 0 {
+		var next []int
+		for _, u := range frontier {
+			for _, v := range g.from[u] {
+				if !ret[v] {
+					ret[v] = true
+					next = append(next, v)
+				}
+			}
+		}
+		frontier = next
+	}
+	return ret, nil
+}
+
 func (g *ExecutionGraph) setupRetry(ctx context.Context) error {
 	dict := map[int]NodeStatus{}
 	retry := map[int]bool{}
@@ -247,6 +413,12 @@ func (g *ExecutionGraph) findStep(name string) (*Node, error) {
 	return nil, fmt.Errorf("%w: %s", errStepNotFound, name)
 }
 
+// isFinished returns true if the status is one of the final node statuses.
+func isFinished(status NodeStatus) bool {
+	return status != NodeStatusNone && status != NodeStatusRunning &&
+		status != NodeStatusQueued && status != NodeStatusWaiting
+}
+
 var (
 	errCycleDetected = errors.New("cycle detected")
 	errStepNotFound  = errors.New("step not found")
@@ -103,3 +103,39 @@ func TestRetryExecution(t *testing.T) {
 	require.Equal(t, scheduler.NodeStatusNone, nodes[6].State().Status)
 	require.Equal(t, scheduler.NodeStatusSkipped, nodes[7].State().Status)
 }
+
+func TestPartialExecution(t *testing.T) {
+	steps := []digraph.Step{
+		{Name: "1", Command: "true"},
+		{Name: "2", Command: "true", Depends: []string{"1"}},
+		{Name: "3", Command: "true", Depends: []string{"2"}},
+	}
+	reference := []*scheduler.Node{
+		scheduler.NodeWithData(scheduler.NodeData{
+			Step:  steps[0],
+			State: scheduler.NodeState{Status: scheduler.NodeStatusSuccess},
+		}),
+		scheduler.NodeWithData(scheduler.NodeData{
+			Step:  steps[1],
+			State: scheduler.NodeState{Status: scheduler.NodeStatusRunning},
+		}),
+		scheduler.NodeWithData(scheduler.NodeData{
+			Step:  steps[2],
+			State: scheduler.NodeState{Status: scheduler.NodeStatusError},
+		}),
+	}
+	ctx := context.Background()
+
+	graph, err := scheduler.CreatePartialExecutionGraph(ctx, steps, reference, []string{"3"}, false)
+	require.NoError(t, err)
+
+	nodes := graph.Nodes()
+	require.Equal(t, scheduler.NodeStatusSuccess, nodes[0].State().Status)
+	// the state of the interrupted node is not reused
+	require.Equal(t, scheduler.NodeStatusNone, nodes[1].State().Status)
+	// the target node is executed from scratch
+	require.Equal(t, scheduler.NodeStatusNone, nodes[2].State().Status)
+
+	_, err = scheduler.CreatePartialExecutionGraph(ctx, steps, reference, []string{"unknown"}, false)
+	require.Error(t, err)
+}
@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+)
+
+// followerBufferSize is the number of the chunks of the output buffered for
+// a follower of the log.
+const followerBufferSize = 1024
+
+// logStream writes the output of the step to the log file and sends it to
+// the followers of the log as well.
+type logStream struct {
+	mu        sync.Mutex
+	w         *bufio.Writer
+	filename  string
+	followers map[chan []byte]struct{}
+	// done is true when the step or the run has finished, so no output will
+	// be written to the log any more.
+	done bool
+}
+
+// open starts writing the log to the writer of the log file.
+func (s *logStream) open(w *bufio.Writer, filename string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.w = w
+	s.filename = filename
+	s.done = false
+}
+
+func (s *logStream) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.w == nil {
+		return len(p), nil
+	}
+	// The output is written through to the log file so that the readers of
+	// the file see it as soon as it is written.
+	n, err := s.w.Write(p)
+	if err == nil {
+		err = s.w.Flush()
+	}
+	if n > 0 && len(s.followers) > 0 {
+		chunk := slices.Clone(p[:n])
+		for ch := range s.followers {
+			select {
+			case ch <- chunk:
+			default:
+				// The follower does not keep up with the output.
+				close(ch)
+				delete(s.followers, ch)
+			}
+		}
+	}
+	return n, err
+}
+
+// flush writes the buffered output to the log file.
+func (s *logStream) flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.w == nil {
+		return nil
+	}
+	return s.w.Flush()
+}
+
+// close stops the followers of the log.
+func (s *logStream) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.done = true
+	for ch := range s.followers {
+		close(ch)
+	}
+	s.followers = nil
+}
+
+// follow returns the log written so far. If follow is true and the log is
+// still written, it also returns the channel receiving the output written
+// after it. The channel is closed when the log is closed.
+func (s *logStream) follow(follow bool) ([]byte, chan []byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var data []byte
+	if s.filename != "" {
+		if s.w != nil {
+			if err := s.w.Flush(); err != nil {
+				return nil, nil, fmt.Errorf("failed to flush log: %w", err)
+			}
+		}
+		var err error
+		data, err = os.ReadFile(s.filename)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, nil, fmt.Errorf("failed to read log file: %w", err)
+		}
+	}
+	if !follow || s.done {
+		return data, nil, nil
+	}
+
+	ch := make(chan []byte, followerBufferSize)
+	if s.followers == nil {
+		s.followers = make(map[chan []byte]struct{})
+	}
+	s.followers[ch] = struct{}{}
+	return data, ch, nil
+}
+
+// unfollow stops sending the output to the follower.
+func (s *logStream) unfollow(ch chan []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.followers, ch)
+}
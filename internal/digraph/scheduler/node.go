@@ -4,13 +4,19 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	"golang.org/x/sys/unix"
@@ -35,6 +41,14 @@ type Node struct {
 	done         atomic.Bool
 	retryPolicy  RetryPolicy
 	cmdEvaluated atomic.Bool
+	approval     chan approvalDecision
+}
+
+// approvalDecision is the decision on a node waiting for a manual approval.
+type approvalDecision struct {
+	approved bool
+	approver string
+	comment  string
 }
 
 func NewNode(step digraph.Step, state NodeState) *Node {
@@ -60,6 +74,45 @@ func (n *Node) LogFile() string {
 	return n.outputs.LogFile()
 }
 
+// StreamLog writes the log of the step to w. If follow is true, it keeps
+// writing the output of the step until the step or the run finishes or ctx
+// is canceled. The output is not followed any more if w does not keep up
+// with it.
+func (n *Node) StreamLog(ctx context.Context, w io.Writer, follow bool) error {
+	data, ch, err := n.outputs.log.follow(follow)
+	if err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	if ch == nil {
+		return nil
+	}
+	defer n.outputs.log.unfollow(ch)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case chunk, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if _, err := w.Write(chunk); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// closeLog stops the followers of the log of the step.
+func (n *Node) closeLog() {
+	n.outputs.log.close()
+}
+
 func (n *Node) SetStatus(status NodeStatus) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
@@ -84,7 +137,7 @@ func (n *Node) shouldContinue(ctx context.Context) bool {
 
 	status := n.data.Status()
 	switch status {
-	case NodeStatusSuccess:
+	case NodeStatusSuccess, NodeStatusCached:
 		return true
 
 	case NodeStatusError:
@@ -102,7 +155,7 @@ func (n *Node) shouldContinue(ctx context.Context) bool {
 	case NodeStatusNone:
 		fallthrough
 
-	case NodeStatusRunning:
+	case NodeStatusRunning, NodeStatusQueued, NodeStatusWaiting:
 		// Unexpected state
 		logger.Error(ctx, "unexpected node status", "status", status)
 		return false
@@ -174,7 +227,6 @@ func (n *Node) Execute(ctx context.Context) error {
 }
 
 func (n *Node) clearVariable(key string) {
-	_ = os.Unsetenv(key)
 	n.data.ClearVariable(key)
 }
 
@@ -328,13 +380,32 @@ func (n *Node) Signal(ctx context.Context, sig os.Signal, allowOverride bool) {
 	if status == NodeStatusRunning {
 		n.data.SetStatus(NodeStatusCancel)
 	}
+	if status == NodeStatusQueued || status == NodeStatusWaiting || (status == NodeStatusRunning && n.cmd == nil) {
+		// Stop waiting for the pool slot, the approval or the sensor.
+		n.data.SetStatus(NodeStatusCancel)
+		if n.cancelFunc != nil {
+			n.cancelFunc()
+		}
+	}
 }
 
+// Cancel cancels the node. The command of the running node is terminated
+// with the signal of signalOnStop or SIGTERM.
 func (n *Node) Cancel(ctx context.Context) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 	status := n.data.Status()
-	if status == NodeStatusRunning {
+	if status == NodeStatusRunning && n.cmd != nil {
+		sig := syscall.SIGTERM
+		if n.data.SignalOnStop() != "" {
+			sig = unix.SignalNum(n.data.SignalOnStop())
+		}
+		logger.Info(ctx, "Sending signal", "signal", sig, "step", n.data.Name())
+		if err := n.cmd.Kill(sig); err != nil {
+			logger.Error(ctx, "Failed to send signal", "err", err, "step", n.data.Name())
+		}
+	}
+	if status == NodeStatusRunning || status == NodeStatusQueued || status == NodeStatusWaiting {
 		n.data.SetStatus(NodeStatusCancel)
 	}
 	if n.cancelFunc != nil {
@@ -343,6 +414,51 @@ func (n *Node) Cancel(ctx context.Context) {
 	}
 }
 
+// suspend suspends the command of the running node in place. It returns
+// false if the executor of the node does not support it.
+func (n *Node) suspend(ctx context.Context) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	suspender, ok := n.cmd.(executor.Suspender)
+	if !ok || n.data.Status() != NodeStatusRunning {
+		return false
+	}
+	logger.Info(ctx, "Suspending step", "step", n.data.Name())
+	if err := suspender.Suspend(); err != nil {
+		logger.Error(ctx, "Failed to suspend step", "err", err, "step", n.data.Name())
+		return false
+	}
+	return true
+}
+
+// resume resumes the command suspended by suspend.
+func (n *Node) resume(ctx context.Context) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	suspender, ok := n.cmd.(executor.Suspender)
+	if !ok {
+		return
+	}
+	logger.Info(ctx, "Resuming step", "step", n.data.Name())
+	if err := suspender.Resume(); err != nil {
+		logger.Error(ctx, "Failed to resume step", "err", err, "step", n.data.Name())
+	}
+}
+
+// setCancelFunc sets the function called when the node is canceled. It
+// returns false if the node has been canceled already.
+func (n *Node) setCancelFunc(cancel func()) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.data.Status() == NodeStatusCancel {
+		return false
+	}
+	n.cancelFunc = cancel
+	return true
+}
+
 func (n *Node) SetupContextBeforeExec(ctx context.Context) context.Context {
 	n.mu.RLock()
 	defer n.mu.RUnlock()
@@ -354,6 +470,45 @@ func (n *Node) SetupContextBeforeExec(ctx context.Context) context.Context {
 	return digraph.WithStepContext(ctx, stepContext)
 }
 
+// setupEnv evaluates the environment variables of the step in order and
+// adds them to the step context, so that a variable can refer to the ones
+// defined before it.
+func (n *Node) setupEnv(ctx context.Context) (context.Context, error) {
+	env := n.data.Step().Env
+	if len(env) == 0 {
+		return ctx, nil
+	}
+
+	stepContext := digraph.GetStepContext(ctx)
+	for _, kv := range env {
+		key, value, _ := strings.Cut(kv, "=")
+		evaluated, err := stepContext.EvalString(value)
+		if err != nil {
+			return ctx, fmt.Errorf("failed to evaluate env %q: %w", key, err)
+		}
+		stepContext = stepContext.WithEnv(key, evaluated)
+	}
+	return digraph.WithStepContext(ctx, stepContext), nil
+}
+
+// outputContext returns the context in which the output variables of the
+// last execution of the node take precedence over the loaded ones.
+func (n *Node) outputContext(ctx context.Context) context.Context {
+	vars := n.data.Step().OutputVariables
+	if vars == nil {
+		return ctx
+	}
+
+	stepContext := digraph.GetStepContext(ctx)
+	vars.Range(func(_, value any) bool {
+		if key, value, ok := strings.Cut(value.(string), "="); ok {
+			stepContext = stepContext.WithEnv(key, value)
+		}
+		return true
+	})
+	return digraph.WithStepContext(ctx, stepContext)
+}
+
 func (n *Node) Setup(ctx context.Context, logDir string, requestID string) error {
 	n.mu.Lock()
 	defer n.mu.Unlock()
@@ -401,6 +556,39 @@ func (n *Node) Teardown(ctx context.Context) error {
 	return lastErr
 }
 
+// acquirePool waits for a slot of the resource pool of the step. The node
+// is queued while waiting and becomes running once the slot is acquired.
+// The notify function is called after the node enters the queued status.
+// The returned function releases the slot.
+func (n *Node) acquirePool(ctx context.Context, pools ResourcePools, notify func()) (func(), error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	n.mu.Lock()
+	n.cancelFunc = cancel
+	n.data.SetStatus(NodeStatusQueued)
+	n.mu.Unlock()
+
+	notify()
+
+	release, err := pools.Acquire(ctx, n.data.Step().Pool)
+	if err != nil {
+		return nil, err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.data.Status() != NodeStatusQueued {
+		// The node was canceled right after the slot was acquired.
+		release()
+		return nil, context.Canceled
+	}
+	n.data.SetStatus(NodeStatusRunning)
+
+	return release, nil
+}
+
 // LogContainsPattern checks if any of the given patterns exist in the node's log file.
 // If a pattern starts with "regexp:", it will be treated as a regular expression.
 // Returns false if no log file exists or no pattern is found.
@@ -470,6 +658,290 @@ func (n *Node) Init() {
 	n.id = getNextNodeID()
 }
 
+// waitApproval waits for the manual approval of the node. The node is in
+// the waiting status until it is approved, rejected, or the approval times
+// out, and becomes running once approved. The notify function is called
+// after the node enters the waiting status.
+func (n *Node) waitApproval(ctx context.Context, notify func()) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	decisions := make(chan approvalDecision, 1)
+
+	n.mu.Lock()
+	n.cancelFunc = cancel
+	n.approval = decisions
+	n.data.SetStatus(NodeStatusWaiting)
+	n.mu.Unlock()
+
+	notify()
+
+	defer func() {
+		n.mu.Lock()
+		n.approval = nil
+		n.mu.Unlock()
+	}()
+
+	var timeout <-chan time.Time
+	if d := n.data.Step().Approval.Timeout; d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var decision approvalDecision
+	select {
+	case decision = <-decisions:
+	case <-timeout:
+		return ErrApprovalTimeout
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.data.SetApproval(decision.approver, decision.comment)
+	if !decision.approved {
+		return fmt.Errorf("%w by %q", ErrApprovalRejected, decision.approver)
+	}
+	if n.data.Status() != NodeStatusWaiting {
+		// The node was canceled right after the approval.
+		return context.Canceled
+	}
+	n.data.SetStatus(NodeStatusRunning)
+
+	return nil
+}
+
+// decide approves or rejects the node waiting for a manual approval.
+func (n *Node) decide(decision approvalDecision) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.approval == nil || n.data.Status() != NodeStatusWaiting {
+		return fmt.Errorf("%w: %s", ErrNotWaitingForApproval, n.data.Name())
+	}
+
+	select {
+	case n.approval <- decision:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrNotWaitingForApproval, n.data.Name())
+	}
+}
+
+// waitSensor pokes the sensor of the node until its condition is met. It
+// returns ErrSensorTimeout if the condition is not met within the timeout.
+// notify is called after the node is marked as running.
+func (n *Node) waitSensor(ctx context.Context, notify func()) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cfg := *n.data.Step().Sensor
+	dir, err := digraph.GetStepContext(ctx).EvalString(n.data.Step().Dir)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate the working directory: %w", err)
+	}
+	s, err := newSensor(ctx, cfg, dir)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	n.cancelFunc = cancel
+	n.data.SetStatus(NodeStatusRunning)
+	n.mu.Unlock()
+
+	notify()
+
+	var timeout <-chan time.Time
+	if cfg.Timeout > 0 {
+		timer := time.NewTimer(cfg.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	ticker := time.NewTicker(cfg.PokeInterval)
+	defer ticker.Stop()
+
+	for {
+		met, err := s.poke(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.Warn(ctx, "Sensor poke failed", "step", n.data.Name(), "err", err)
+		}
+		if met {
+			break
+		}
+		select {
+		case <-ticker.C:
+		case <-timeout:
+			return ErrSensorTimeout
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.data.Status() != NodeStatusRunning {
+		// The node was canceled right after the condition was met.
+		return context.Canceled
+	}
+	return nil
+}
+
+// cacheKey returns the key of the step cache. The key is computed from the
+// evaluated command and script, the values of the environment variables
+// selected in the cache configuration, and the contents of the input files.
+func (n *Node) cacheKey(ctx context.Context) (string, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.evaluateCommandArgs(ctx); err != nil {
+		return "", err
+	}
+
+	step := n.data.Step()
+	stepContext := digraph.GetStepContext(ctx)
+
+	dagName, err := stepContext.EvalString("${"+digraph.EnvKeyDAGName+"}", cmdutil.WithoutSubstitute())
+	if err != nil {
+		return "", fmt.Errorf("failed to evaluate DAG name: %w", err)
+	}
+	script, err := stepContext.EvalString(step.Script, cmdutil.WithoutSubstitute())
+	if err != nil {
+		return "", fmt.Errorf("failed to evaluate script: %w", err)
+	}
+	executorConfig, err := json.Marshal(step.ExecutorConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal executor config: %w", err)
+	}
+
+	h := sha256.New()
+	write := func(key, value string) {
+		_, _ = fmt.Fprintf(h, "%s=%q\n", key, value)
+	}
+	write("dag", dagName)
+	write("step", step.Name)
+	write("command", step.Command)
+	write("args", strings.Join(step.Args, "\x00"))
+	write("shellCmdArgs", step.ShellCmdArgs)
+	write("shell", step.Shell)
+	write("script", script)
+	write("dir", step.Dir)
+	write("output", step.Output)
+	write("executor", string(executorConfig))
+
+	for _, name := range step.Cache.Env {
+		value, err := stepContext.EvalString("${"+name+"}", cmdutil.WithoutSubstitute())
+		if err != nil {
+			return "", fmt.Errorf("failed to evaluate %s: %w", name, err)
+		}
+		write("env:"+name, value)
+	}
+
+	for _, pattern := range step.Cache.Files {
+		files, err := cacheInputFiles(step.Dir, pattern)
+		if err != nil {
+			return "", err
+		}
+		for _, file := range files {
+			sum, err := hashFile(file)
+			if err != nil {
+				return "", fmt.Errorf("failed to hash input file %s: %w", file, err)
+			}
+			write("file:"+file, sum)
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// cacheInputFiles returns the regular files that match the pattern in
+// lexical order. Directories are walked recursively.
+func cacheInputFiles(dir, pattern string) ([]string, error) {
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(dir, pattern)
+	}
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache file pattern %q: %w", pattern, err)
+	}
+
+	var files []string
+	for _, match := range matches {
+		err := filepath.WalkDir(match, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.Type().IsRegular() {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read input files %s: %w", match, err)
+		}
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// restoreCache replays the cached result as if the step was executed.
+func (n *Node) restoreCache(result *CachedResult) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := n.outputs.writeCachedStdout(result.Stdout); err != nil {
+		return err
+	}
+	for key, value := range result.OutputVariables {
+		n.data.setVariable(key, value)
+	}
+	return nil
+}
+
+// cacheResult returns the result of the execution to be stored in the
+// step cache.
+func (n *Node) cacheResult() (CachedResult, error) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	stdout, err := n.outputs.cachedStdout()
+	if err != nil {
+		return CachedResult{}, err
+	}
+
+	vars := make(map[string]string)
+	if outputVariables := n.data.Step().OutputVariables; outputVariables != nil {
+		outputVariables.Range(func(key, value any) bool {
+			if k := key.(string); !strings.HasPrefix(k, digraph.SystemVariablePrefix) {
+				vars[k] = stringutil.KeyValue(value.(string)).Value()
+			}
+			return true
+		})
+	}
+
+	return CachedResult{Stdout: stdout, OutputVariables: vars}, nil
+}
+
 type RetryPolicy struct {
 	Limit    int
 	Interval time.Duration
@@ -491,7 +963,7 @@ func (n *Node) setupRetryPolicy(ctx context.Context) error {
 	// Evaluate the configuration if it's configured as a string
 	// e.g. environment variable or command substitution
 	if step.RetryPolicy.LimitStr != "" {
-		v, err := cmdutil.EvalIntString(ctx, step.RetryPolicy.LimitStr)
+		v, err := cmdutil.EvalIntString(ctx, step.RetryPolicy.LimitStr, cmdutil.WithEnv(digraph.GetStepContext(ctx).Envs()))
 		if err != nil {
 			return fmt.Errorf("failed to substitute retry limit %q: %w", step.RetryPolicy.LimitStr, err)
 		}
@@ -500,7 +972,7 @@ func (n *Node) setupRetryPolicy(ctx context.Context) error {
 	}
 
 	if step.RetryPolicy.IntervalSecStr != "" {
-		v, err := cmdutil.EvalIntString(ctx, step.RetryPolicy.IntervalSecStr)
+		v, err := cmdutil.EvalIntString(ctx, step.RetryPolicy.IntervalSecStr, cmdutil.WithEnv(digraph.GetStepContext(ctx).Envs()))
 		if err != nil {
 			return fmt.Errorf("failed to substitute retry interval %q: %w", step.RetryPolicy.IntervalSecStr, err)
 		}
@@ -521,12 +993,14 @@ type OutputCoordinator struct {
 	logFilename  string
 	logFile      *os.File
 	logWriter    *bufio.Writer
+	log          logStream
 	stdoutFile   *os.File
 	stdoutWriter *bufio.Writer
 	stderrFile   *os.File
 	stderrWriter *bufio.Writer
 	outputWriter *os.File
 	outputReader *os.File
+	cacheFile    *os.File
 }
 
 func (oc *OutputCoordinator) LogFile() string {
@@ -562,13 +1036,13 @@ func (oc *OutputCoordinator) setupExecutorIO(_ context.Context, cmd executor.Exe
 
 	// Output to log only
 	if oc.logWriter != nil {
-		stdout = oc.logWriter
+		stdout = &oc.log
 		cmd.SetStderr(stdout)
 	}
 
 	// Output to both log and stdout
 	if oc.stdoutWriter != nil {
-		stdout = io.MultiWriter(oc.logWriter, oc.stdoutWriter)
+		stdout = io.MultiWriter(&oc.log, oc.stdoutWriter)
 	}
 
 	// Setup output capture
@@ -580,7 +1054,20 @@ func (oc *OutputCoordinator) setupExecutorIO(_ context.Context, cmd executor.Exe
 		stdout = io.MultiWriter(stdout, oc.outputWriter)
 	}
 
-	cmd.SetStdout(stdout)
+	// Setup stdout capture for the step cache
+	if data.Step.Cache != nil {
+		if oc.cacheFile != nil {
+			_ = oc.cacheFile.Close()
+			_ = os.Remove(oc.cacheFile.Name())
+		}
+		var err error
+		if oc.cacheFile, err = os.CreateTemp("", "dagu_stdout_*"); err != nil {
+			return fmt.Errorf("failed to create stdout capture file: %w", err)
+		}
+		cmd.SetStdout(io.MultiWriter(stdout, oc.cacheFile))
+	} else {
+		cmd.SetStdout(stdout)
+	}
 
 	if oc.stderrWriter != nil {
 		cmd.SetStderr(oc.stderrWriter)
@@ -597,7 +1084,11 @@ func (oc *OutputCoordinator) closeResources(_ context.Context) error {
 	defer oc.mu.Unlock()
 
 	var lastErr error
-	for _, w := range []*bufio.Writer{oc.logWriter, oc.stdoutWriter, oc.stderrWriter} {
+	if err := oc.log.flush(); err != nil {
+		lastErr = err
+	}
+	oc.log.close()
+	for _, w := range []*bufio.Writer{oc.stdoutWriter, oc.stderrWriter} {
 		if w != nil {
 			if err := w.Flush(); err != nil {
 				lastErr = err
@@ -612,9 +1103,46 @@ func (oc *OutputCoordinator) closeResources(_ context.Context) error {
 			_ = f.Close()
 		}
 	}
+	if oc.cacheFile != nil {
+		_ = oc.cacheFile.Close()
+		_ = os.Remove(oc.cacheFile.Name())
+		oc.cacheFile = nil
+	}
 	return lastErr
 }
 
+// cachedStdout returns the standard output captured for the step cache.
+func (oc *OutputCoordinator) cachedStdout() ([]byte, error) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+
+	if oc.cacheFile == nil {
+		return nil, nil
+	}
+	data, err := os.ReadFile(oc.cacheFile.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read captured stdout: %w", err)
+	}
+	return data, nil
+}
+
+// writeCachedStdout writes the standard output of a cached result to the
+// log and stdout files.
+func (oc *OutputCoordinator) writeCachedStdout(data []byte) error {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+
+	if _, err := oc.log.Write(data); err != nil {
+		return fmt.Errorf("failed to write cached stdout: %w", err)
+	}
+	if oc.stdoutWriter != nil {
+		if _, err := oc.stdoutWriter.Write(data); err != nil {
+			return fmt.Errorf("failed to write cached stdout: %w", err)
+		}
+	}
+	return nil
+}
+
 func (oc *OutputCoordinator) setupStdout(ctx context.Context, data NodeData) error {
 	oc.mu.Lock()
 	defer oc.mu.Unlock()
@@ -664,6 +1192,7 @@ func (oc *OutputCoordinator) setupLog(_ context.Context, data NodeData) error {
 	}
 	oc.logWriter = bufio.NewWriter(oc.logFile)
 	oc.logFilename = data.State.Log
+	oc.log.open(oc.logWriter, oc.logFilename)
 
 	return nil
 }
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"runtime/debug"
@@ -20,6 +21,8 @@ const (
 	StatusError
 	StatusCancel
 	StatusSuccess
+	_ // 5 is reserved for the skipped status, which is not used any more.
+	StatusPaused
 )
 
 func (s Status) String() string {
@@ -32,6 +35,8 @@ func (s Status) String() string {
 		return "canceled"
 	case StatusSuccess:
 		return "finished"
+	case StatusPaused:
+		return "paused"
 	case StatusNone:
 		fallthrough
 	default:
@@ -39,227 +44,253 @@ func (s Status) String() string {
 	}
 }
 
+// IsActive returns true if the run has not finished, i.e. it is running or
+// paused.
+func (s Status) IsActive() bool {
+	return s == StatusRunning || s == StatusPaused
+}
+
 var (
-	ErrUpstreamFailed  = fmt.Errorf("upstream failed")
-	ErrUpstreamSkipped = fmt.Errorf("upstream skipped")
+	ErrUpstreamFailed    = fmt.Errorf("upstream failed")
+	ErrUpstreamSkipped   = fmt.Errorf("upstream skipped")
+	ErrPoolNotConfigured = fmt.Errorf("pool not configured")
+
+	ErrApprovalRejected      = fmt.Errorf("rejected")
+	ErrApprovalTimeout       = fmt.Errorf("approval timed out")
+	ErrNotWaitingForApproval = fmt.Errorf("step is not waiting for approval")
+	ErrSensorTimeout         = fmt.Errorf("sensor timed out")
+
+	ErrRunFinished          = fmt.Errorf("the run has finished")
+	ErrStepNotRunning       = fmt.Errorf("step is not running")
+	ErrStepNotPending       = fmt.Errorf("step is not pending")
+	ErrStepNotFailed        = fmt.Errorf("step has not failed or been canceled")
+	ErrStepCanceled         = fmt.Errorf("step was canceled")
+	ErrStepSkipped          = fmt.Errorf("step was skipped")
+	ErrInvalidMaxActiveRuns = fmt.Errorf("max active runs must be zero or positive")
+	ErrRunPaused            = fmt.Errorf("the run is already paused")
+	ErrRunNotPaused         = fmt.Errorf("the run is not paused")
 )
 
 // Scheduler is a scheduler that runs a graph of steps.
 type Scheduler struct {
-	logDir        string
-	maxActiveRuns int
-	timeout       time.Duration
-	delay         time.Duration
-	dry           bool
-	onExit        *digraph.Step
-	onSuccess     *digraph.Step
-	onFailure     *digraph.Step
-	onCancel      *digraph.Step
-	requestID     string
+	logDir          string
+	maxActiveRuns   int
+	maxActiveWeight int
+	pools           ResourcePools
+	cache           StepCache
+	timeout         time.Duration
+	retryWindow     time.Duration
+	delay           time.Duration
+	dry             bool
+	onExit          *digraph.Step
+	onSuccess       *digraph.Step
+	onFailure       *digraph.Step
+	onCancel        *digraph.Step
+	requestID       string
 
 	canceled  int32
+	cancelCh  chan struct{}
 	mu        sync.RWMutex
 	pause     time.Duration
 	lastError error
 	handlers  map[digraph.HandlerType]*Node
+
+	// controls receives the changes of the run requested while the
+	// scheduling loop is running. controlsClosed is closed when the loop
+	// stops receiving them.
+	controls       chan control
+	controlsClosed chan struct{}
+	closeControls  sync.Once
+
+	// paused is true while the run is paused, and suspended holds the steps
+	// suspended by the pause. They are guarded by mu.
+	paused    bool
+	suspended []*Node
+
+	// awaitingRetry is true while the run waits for the failed steps to be
+	// retried. It is guarded by mu.
+	awaitingRetry bool
 }
 
 func New(cfg *Config) *Scheduler {
 	return &Scheduler{
-		logDir:        cfg.LogDir,
-		maxActiveRuns: cfg.MaxActiveRuns,
-		timeout:       cfg.Timeout,
-		delay:         cfg.Delay,
-		dry:           cfg.Dry,
-		onExit:        cfg.OnExit,
-		onSuccess:     cfg.OnSuccess,
-		onFailure:     cfg.OnFailure,
-		onCancel:      cfg.OnCancel,
-		requestID:     cfg.ReqID,
-		pause:         time.Millisecond * 100,
+		logDir:          cfg.LogDir,
+		maxActiveRuns:   cfg.MaxActiveRuns,
+		maxActiveWeight: cfg.MaxActiveWeight,
+		pools:           cfg.Pools,
+		cache:           cfg.StepCache,
+		timeout:         cfg.Timeout,
+		retryWindow:     cfg.RetryWindow,
+		delay:           cfg.Delay,
+		dry:             cfg.Dry,
+		onExit:          cfg.OnExit,
+		onSuccess:       cfg.OnSuccess,
+		onFailure:       cfg.OnFailure,
+		onCancel:        cfg.OnCancel,
+		requestID:       cfg.ReqID,
+		pause:           time.Millisecond * 100,
+		cancelCh:        make(chan struct{}),
+		controls:        make(chan control),
+		controlsClosed:  make(chan struct{}),
 	}
 }
 
+// ResourcePools limits the number of steps that use the same named pool
+// at a time across DAG runs.
+type ResourcePools interface {
+	// Acquire blocks until a slot of the pool is available or the context
+	// is done. The returned function releases the slot.
+	Acquire(ctx context.Context, name string) (func(), error)
+}
+
+// StepCache stores the results of the steps that have caching enabled.
+type StepCache interface {
+	// Load returns the result stored with the key. It returns nil if the
+	// result is not found or has expired.
+	Load(ctx context.Context, key string) (*CachedResult, error)
+	// Save stores the result with the key.
+	Save(ctx context.Context, key string, result CachedResult) error
+}
+
+// CachedResult is the result of a step that is replayed on a cache hit.
+type CachedResult struct {
+	// Stdout is the standard output of the step.
+	Stdout []byte
+	// OutputVariables maps the name of an output variable to its value.
+	OutputVariables map[string]string
+}
+
 type Config struct {
-	LogDir        string
-	MaxActiveRuns int
-	Timeout       time.Duration
-	Delay         time.Duration
-	Dry           bool
-	OnExit        *digraph.Step
-	OnSuccess     *digraph.Step
-	OnFailure     *digraph.Step
-	OnCancel      *digraph.Step
-	ReqID         string
+	LogDir          string
+	MaxActiveRuns   int
+	MaxActiveWeight int
+	Pools           ResourcePools
+	StepCache       StepCache
+	Timeout         time.Duration
+	RetryWindow     time.Duration
+	Delay           time.Duration
+	Dry             bool
+	OnExit          *digraph.Step
+	OnSuccess       *digraph.Step
+	OnFailure       *digraph.Step
+	OnCancel        *digraph.Step
+	ReqID           string
 }
 
 // Schedule runs the graph of steps.
 func (sc *Scheduler) Schedule(ctx context.Context, graph *ExecutionGraph, done chan *Node) error {
+	defer sc.stopControls()
+
 	if err := sc.setup(ctx); err != nil {
 		return err
 	}
 	graph.Start()
 	defer graph.Finish()
 
-	var wg = sync.WaitGroup{}
-
 	var cancel context.CancelFunc
 	if sc.timeout > 0 {
 		ctx, cancel = context.WithTimeout(ctx, sc.timeout)
 		defer cancel()
 	}
 
-	for !sc.isFinished(graph) {
-		if sc.isCanceled() {
+	// completed receives each node when its goroutine exits. The loop below
+	// blocks on it instead of polling the node states. The buffer is large
+	// enough to hold one event per node so that goroutines never block even
+	// after the loop stopped receiving on cancellation.
+	completed := make(chan *Node, len(graph.Nodes()))
+	running, activeWeight := 0, 0
+
+	state := &runState{
+		graph:    graph,
+		ready:    sc.resolveReady(ctx, graph, graph.initInDegrees()),
+		launched: map[int]bool{},
+		active:   map[int]bool{},
+		done:     done,
+	}
+
+	for !sc.isCanceled() {
+		if running == 0 && len(state.ready) == 0 && !sc.awaitRetry(ctx, state) {
 			break
 		}
-
-	NodesIteration:
-		for _, node := range graph.Nodes() {
-			if node.State().Status != NodeStatusNone || !isReady(ctx, graph, node) {
-				continue NodesIteration
-			}
-			if sc.isCanceled() {
-				break NodesIteration
-			}
-			if sc.maxActiveRuns > 0 && sc.runningCount(graph) >= sc.maxActiveRuns {
-				continue NodesIteration
+		for len(state.ready) > 0 && !sc.isCanceled() && !sc.isPaused() {
+			idx := sc.selectNode(state.ready, running, activeWeight)
+			if idx < 0 {
+				break
 			}
 
-			wg.Add(1)
+			node := state.ready[idx]
+			state.ready = append(state.ready[:idx], state.ready[idx+1:]...)
+			running++
+			activeWeight += sc.weight(node)
+			state.launched[node.id] = true
+			state.active[node.id] = true
 
 			logger.Info(ctx, "Step execution started", "step", node.data.Name())
 			node.data.SetStatus(NodeStatusRunning)
-			go func(ctx context.Context, node *Node) {
-				defer func() {
-					if panicObj := recover(); panicObj != nil {
-						stack := string(debug.Stack())
-						err := fmt.Errorf("panic recovered: %v\n%s", panicObj, stack)
-						logger.Error(ctx, "Panic occurred", "error", err, "step", node.data.Name(), "stack", stack)
-						node.data.MarkError(err)
-						sc.setLastError(err)
-					}
-				}()
-
-				defer func() {
-					node.data.Finish()
-					wg.Done()
-				}()
-
-				ctx = sc.setupContext(ctx, graph, node)
-
-				// Check preconditions
-				if len(node.data.Step().Preconditions) > 0 {
-					logger.Infof(ctx, "Checking pre conditions for \"%s\"", node.data.Name())
-					if err := digraph.EvalConditions(ctx, node.data.Step().Preconditions); err != nil {
-						logger.Infof(ctx, "Pre conditions failed for \"%s\"", node.data.Name())
-						node.data.SetStatus(NodeStatusSkipped)
-						node.data.SetError(err)
-						if done != nil {
-							done <- node
-						}
-						return
-					}
-				}
+			sc.launch(ctx, graph, node, completed, done)
 
-				setupSucceed := true
-				if err := sc.setupNode(ctx, node); err != nil {
-					setupSucceed = false
-					sc.setLastError(err)
-					node.data.MarkError(err)
-				}
-
-				ctx = node.SetupContextBeforeExec(ctx)
-
-				defer func() {
-					_ = sc.teardownNode(ctx, node)
-				}()
-
-			ExecRepeat: // repeat execution
-				for setupSucceed && !sc.isCanceled() {
-					execErr := sc.execNode(ctx, node)
-					if execErr != nil {
-						status := node.State().Status
-						switch {
-						case status == NodeStatusSuccess || status == NodeStatusCancel:
-							// do nothing
-
-						case sc.isTimeout(graph.startedAt):
-							logger.Info(ctx, "Step execution deadline exceeded", "step", node.data.Name(), "error", execErr)
-							node.data.SetStatus(NodeStatusCancel)
-							sc.setLastError(execErr)
-
-						case sc.isCanceled():
-							sc.setLastError(execErr)
-
-						case node.retryPolicy.Limit > node.data.GetRetryCount():
-							// retry
-							node.data.IncRetryCount()
-							logger.Info(ctx, "Step execution failed. Retrying...", "step", node.data.Name(), "error", execErr, "retry", node.data.GetRetryCount())
-							time.Sleep(node.retryPolicy.Interval)
-							node.data.SetRetriedAt(time.Now())
-							node.data.SetStatus(NodeStatusNone)
-
-						default:
-							// finish the node
-							node.data.SetStatus(NodeStatusError)
-							if node.shouldMarkSuccess(ctx) {
-								// mark as success if the node should be marked as success
-								// i.e. continueOn.markSuccess is set to true
-								node.data.SetStatus(NodeStatusSuccess)
-							} else {
-								node.data.MarkError(execErr)
-								sc.setLastError(execErr)
-							}
-						}
-					}
-
-					if node.State().Status != NodeStatusCancel {
-						node.data.IncDoneCount()
-					}
+			if sc.delay > 0 {
+				time.Sleep(sc.delay)
+			}
+		}
 
-					if node.data.Step().RepeatPolicy.Repeat {
-						if execErr == nil || node.data.Step().ContinueOn.Failure {
-							if !sc.isCanceled() {
-								time.Sleep(node.data.Step().RepeatPolicy.Interval)
-								if done != nil {
-									done <- node
-								}
-								continue ExecRepeat
-							}
-						}
-					}
+		if running == 0 && !sc.isPaused() {
+			continue
+		}
 
-					if execErr != nil && done != nil {
-						done <- node
-						return
-					}
+		// While the run is paused with no running step, the loop waits for
+		// it to be resumed or canceled.
+		var canceled, timedOut <-chan struct{}
+		if running == 0 {
+			canceled, timedOut = sc.cancelCh, ctx.Done()
+		}
 
-					break ExecRepeat
-				}
+		var node *Node
+		select {
+		case node = <-completed:
+		case req := <-sc.controls:
+			req.result <- req.apply(ctx, state)
+			continue
+		case <-canceled:
+			continue
+		case <-timedOut:
+			logger.Info(ctx, "Canceling the paused run", "err", ctx.Err())
+			sc.Cancel(ctx, graph)
+			continue
+		}
+		running--
+		activeWeight -= sc.weight(node)
+		delete(state.active, node.id)
 
-				// finish the node
-				if node.State().Status == NodeStatusRunning {
-					node.data.SetStatus(NodeStatusSuccess)
-				}
+		if sc.isCanceled() {
+			break
+		}
 
-				if err := sc.teardownNode(ctx, node); err != nil {
-					sc.setLastError(err)
-					node.data.SetStatus(NodeStatusError)
-				}
+		if node.State().Status == NodeStatusNone {
+			// The node is going to be retried.
+			state.ready = append(state.ready, node)
+			continue
+		}
 
-				if done != nil {
-					done <- node
-				}
-			}(ctx, node)
+		state.ready = append(state.ready, sc.resolveReady(ctx, graph, graph.finishNode(node))...)
+	}
+	sc.stopControls()
 
-			time.Sleep(sc.delay) // TODO: check if this is necessary
-		}
+	// The run is not paused any more as no step is going to start.
+	sc.mu.Lock()
+	sc.paused = false
+	sc.mu.Unlock()
+	sc.resumeSuspended(ctx)
 
-		time.Sleep(sc.pause) // avoid busy loop
+	// Wait for the running nodes to finish.
+	for ; running > 0; running-- {
+		<-completed
 	}
 
-	wg.Wait()
+	// Stop following the logs of the steps that are not going to run.
+	for _, node := range graph.Nodes() {
+		node.closeLog()
+	}
 
 	var handlers []digraph.HandlerType
 	switch sc.Status(graph) {
@@ -275,7 +306,7 @@ func (sc *Scheduler) Schedule(ctx context.Context, graph *ExecutionGraph, done c
 	case StatusNone:
 		// do nothing (should not happen)
 
-	case StatusRunning:
+	case StatusRunning, StatusPaused:
 		// do nothing (should not happen)
 
 	}
@@ -297,6 +328,390 @@ func (sc *Scheduler) Schedule(ctx context.Context, graph *ExecutionGraph, done c
 	return sc.lastError
 }
 
+// launch runs the node in a new goroutine. The node is sent to the
+// completed channel when the goroutine exits.
+func (sc *Scheduler) launch(ctx context.Context, graph *ExecutionGraph, node *Node, completed chan<- *Node, done chan *Node) {
+	go func(ctx context.Context, node *Node) {
+		// Notify the scheduling loop after the node status is settled,
+		// including the case of a recovered panic.
+		defer func() {
+			completed <- node
+		}()
+
+		defer func() {
+			if panicObj := recover(); panicObj != nil {
+				stack := string(debug.Stack())
+				err := fmt.Errorf("panic recovered: %v\n%s", panicObj, stack)
+				logger.Error(ctx, "Panic occurred", "error", err, "step", node.data.Name(), "stack", stack)
+				node.data.MarkError(err)
+				sc.setLastError(err)
+			}
+		}()
+
+		defer func() {
+			node.data.Finish()
+		}()
+
+		ctx = sc.setupContext(ctx, graph, node)
+
+		ctx, err := node.setupEnv(ctx)
+		if err != nil {
+			sc.setLastError(err)
+			node.data.MarkError(err)
+			if done != nil {
+				done <- node
+			}
+			return
+		}
+
+		// Check preconditions
+		if len(node.data.Step().Preconditions) > 0 {
+			logger.Infof(ctx, "Checking pre conditions for \"%s\"", node.data.Name())
+			if err := digraph.EvalConditions(ctx, node.data.Step().Preconditions); err != nil {
+				logger.Infof(ctx, "Pre conditions failed for \"%s\"", node.data.Name())
+				node.data.SetStatus(NodeStatusSkipped)
+				node.data.SetError(err)
+				if done != nil {
+					done <- node
+				}
+				return
+			}
+		}
+
+		if node.data.Step().Approval != nil && !sc.dry {
+			if err := sc.waitApproval(ctx, graph, node, done); err != nil {
+				if done != nil {
+					done <- node
+				}
+				return
+			}
+			if !hasCommand(node.data.Step()) && node.data.Step().Sensor == nil {
+				// the step only waits for the approval
+				sc.finishWithoutCommand(ctx, node, done)
+				return
+			}
+		}
+
+		if node.data.Step().Sensor != nil && !sc.dry {
+			if err := sc.waitSensor(ctx, graph, node, done); err != nil {
+				if done != nil {
+					done <- node
+				}
+				return
+			}
+			if !hasCommand(node.data.Step()) {
+				// the step only waits for the sensor condition
+				sc.finishWithoutCommand(ctx, node, done)
+				return
+			}
+		}
+
+		if pool := node.data.Step().Pool; pool != "" && !sc.dry {
+			release, err := sc.acquirePool(ctx, graph, node, done)
+			if err != nil {
+				if done != nil {
+					done <- node
+				}
+				return
+			}
+			defer release()
+		}
+
+		setupSucceed := true
+		if err := sc.setupNode(ctx, node); err != nil {
+			setupSucceed = false
+			sc.setLastError(err)
+			node.data.MarkError(err)
+		}
+
+		ctx = node.SetupContextBeforeExec(ctx)
+
+		defer func() {
+			_ = sc.teardownNode(ctx, node)
+		}()
+
+		var cacheKey string
+		if setupSucceed && sc.isCacheEnabled(node) {
+			var hit bool
+			if cacheKey, hit = sc.loadCache(ctx, node); hit {
+				node.data.SetStatus(NodeStatusCached)
+				if done != nil {
+					done <- node
+				}
+				return
+			}
+		}
+
+	ExecRepeat: // repeat execution
+		for setupSucceed && !sc.isCanceled() {
+			execStartedAt := time.Now()
+			execErr := sc.execNode(ctx, node)
+			if node.data.Step().RepeatPolicy.Repeat {
+				node.data.AddRepetition(execStartedAt, time.Now())
+			}
+			if execErr != nil {
+				status := node.State().Status
+				switch {
+				case status == NodeStatusSuccess || status == NodeStatusCancel:
+					// do nothing
+
+				case sc.isTimeout(graph.startedAt):
+					logger.Info(ctx, "Step execution deadline exceeded", "step", node.data.Name(), "error", execErr)
+					node.data.SetStatus(NodeStatusCancel)
+					sc.setLastError(execErr)
+
+				case sc.isCanceled():
+					sc.setLastError(execErr)
+
+				case node.retryPolicy.Limit > node.data.GetRetryCount():
+					// retry
+					node.data.IncRetryCount()
+					logger.Info(ctx, "Step execution failed. Retrying...", "step", node.data.Name(), "error", execErr, "retry", node.data.GetRetryCount())
+					time.Sleep(node.retryPolicy.Interval)
+					if node.State().Status == NodeStatusCancel {
+						// canceled on request while waiting for the retry
+						break
+					}
+					node.data.SetRetriedAt(time.Now())
+					node.data.SetStatus(NodeStatusNone)
+
+				default:
+					// finish the node
+					node.data.SetStatus(NodeStatusError)
+					if node.shouldMarkSuccess(ctx) {
+						// mark as success if the node should be marked as success
+						// i.e. continueOn.markSuccess is set to true
+						node.data.SetStatus(NodeStatusSuccess)
+					} else {
+						node.data.MarkError(execErr)
+						sc.setLastError(execErr)
+					}
+				}
+			}
+
+			if node.State().Status != NodeStatusCancel {
+				node.data.IncDoneCount()
+			}
+
+			if sc.shouldRepeat(ctx, node, execErr) && sc.waitRepeat(ctx, node) {
+				if done != nil {
+					done <- node
+				}
+				continue ExecRepeat
+			}
+
+			if execErr != nil && done != nil {
+				done <- node
+				return
+			}
+
+			break ExecRepeat
+		}
+
+		// finish the node
+		if node.State().Status == NodeStatusRunning {
+			node.data.SetStatus(NodeStatusSuccess)
+		}
+
+		if cacheKey != "" {
+			sc.saveCache(ctx, node, cacheKey)
+		}
+
+		if err := sc.teardownNode(ctx, node); err != nil {
+			sc.setLastError(err)
+			node.data.SetStatus(NodeStatusError)
+		}
+
+		if done != nil {
+			done <- node
+		}
+	}(ctx, node)
+}
+
+// shouldRepeat returns true if the node is executed again according to its
+// repeat policy. The conditions of the policy are evaluated with the output
+// variables of the last execution.
+func (sc *Scheduler) shouldRepeat(ctx context.Context, node *Node, execErr error) bool {
+	step := node.data.Step()
+	policy := step.RepeatPolicy
+	switch {
+	case !policy.Repeat || sc.isCanceled() || node.State().Status == NodeStatusCancel:
+		return false
+	case execErr != nil && !step.ContinueOn.Failure:
+		return false
+	case policy.Limit > 0 && node.data.GetDoneCount() >= policy.Limit:
+		logger.Info(ctx, "Step repeat limit reached", "step", step.Name, "limit", policy.Limit)
+		return false
+	case node.data.MatchExitCode(policy.ExitCode):
+		logger.Info(ctx, "Step repeat stopped by exit code", "step", step.Name, "exitCode", node.data.GetExitCode())
+		return false
+	}
+
+	ctx = node.outputContext(ctx)
+	if len(policy.Until) > 0 && evalRepeatConditions(ctx, step.Name, policy.Until) {
+		logger.Info(ctx, "Step repeat until condition met", "step", step.Name)
+		return false
+	}
+	if len(policy.While) > 0 && !evalRepeatConditions(ctx, step.Name, policy.While) {
+		logger.Info(ctx, "Step repeat while condition not met", "step", step.Name)
+		return false
+	}
+	return true
+}
+
+// evalRepeatConditions returns true if all the conditions are met. Errors
+// other than unmet conditions are logged and treated as unmet.
+func evalRepeatConditions(ctx context.Context, stepName string, conditions []digraph.Condition) bool {
+	err := digraph.EvalConditions(ctx, conditions)
+	if err != nil && !errors.Is(err, digraph.ErrConditionNotMet) {
+		logger.Warn(ctx, "Failed to evaluate repeat condition", "step", stepName, "err", err)
+	}
+	return err == nil
+}
+
+// waitRepeat waits before the next repetition of the node. It returns false
+// if the scheduler or the node is canceled or the context is done while
+// waiting.
+func (sc *Scheduler) waitRepeat(ctx context.Context, node *Node) bool {
+	delay := node.data.Step().RepeatPolicy.Delay(time.Now(), node.data.GetDoneCount())
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	// The node can be canceled on request while waiting.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if !node.setCancelFunc(cancel) {
+		return false
+	}
+
+	select {
+	case <-timer.C:
+		return true
+	case <-sc.cancelCh:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// isCacheEnabled returns true if the result of the node is looked up in and
+// stored to the step cache. Repeated steps are never cached.
+func (sc *Scheduler) isCacheEnabled(node *Node) bool {
+	step := node.data.Step()
+	return sc.cache != nil && !sc.dry && step.Cache != nil && !step.RepeatPolicy.Repeat
+}
+
+// loadCache looks up the result of the node in the step cache and replays
+// it on a hit. It returns the cache key and whether the result was found.
+// Errors are logged and treated as a miss so that the step is executed.
+func (sc *Scheduler) loadCache(ctx context.Context, node *Node) (string, bool) {
+	key, err := node.cacheKey(ctx)
+	if err != nil {
+		logger.Warn(ctx, "Failed to compute cache key", "step", node.data.Name(), "err", err)
+		return "", false
+	}
+
+	result, err := sc.cache.Load(ctx, key)
+	if err != nil {
+		logger.Warn(ctx, "Failed to load cached result", "step", node.data.Name(), "key", key, "err", err)
+		return key, false
+	}
+	if result == nil {
+		logger.Info(ctx, "Step cache miss", "step", node.data.Name(), "key", key)
+		return key, false
+	}
+
+	if err := node.restoreCache(result); err != nil {
+		logger.Warn(ctx, "Failed to restore cached result", "step", node.data.Name(), "key", key, "err", err)
+		return key, false
+	}
+	logger.Info(ctx, "Step cache hit", "step", node.data.Name(), "key", key)
+
+	return key, true
+}
+
+// saveCache stores the result of the node in the step cache if the step
+// succeeded without errors.
+func (sc *Scheduler) saveCache(ctx context.Context, node *Node, key string) {
+	state := node.State()
+	if state.Status != NodeStatusSuccess || state.Error != nil {
+		return
+	}
+
+	result, err := node.cacheResult()
+	if err == nil {
+		err = sc.cache.Save(ctx, key, result)
+	}
+	if err != nil {
+		logger.Warn(ctx, "Failed to save result to the step cache", "step", node.data.Name(), "key", key, "err", err)
+	}
+}
+
+// selectNode returns the index of the ready node to start next, or -1 if
+// no node can be started with the remaining concurrency. The node with the
+// highest priority is selected, and ties are broken by the order in which
+// the nodes became ready. A lighter node never overtakes the selected one
+// when the selected node does not fit, so heavy steps are not starved.
+func (sc *Scheduler) selectNode(ready []*Node, running, activeWeight int) int {
+	if sc.maxActiveRuns > 0 && running >= sc.maxActiveRuns {
+		return -1
+	}
+
+	idx := 0
+	for i := 1; i < len(ready); i++ {
+		if ready[i].data.Step().Priority > ready[idx].data.Step().Priority {
+			idx = i
+		}
+	}
+
+	if sc.maxActiveWeight > 0 && running > 0 &&
+		activeWeight+sc.weight(ready[idx]) > sc.maxActiveWeight {
+		return -1
+	}
+
+	return idx
+}
+
+// weight returns the number of concurrency slots the node occupies. It is
+// capped at maxActiveWeight so that a heavier step can still run alone.
+func (sc *Scheduler) weight(node *Node) int {
+	weight := node.data.Step().Weight
+	if weight < 1 {
+		weight = 1
+	}
+	if sc.maxActiveWeight > 0 && weight > sc.maxActiveWeight {
+		weight = sc.maxActiveWeight
+	}
+	return weight
+}
+
+// resolveReady evaluates the nodes whose upstream nodes have all finished
+// and returns the ones that can be started. Nodes that cannot run because
+// of their upstream results are marked as canceled or skipped, and the
+// evaluation continues with their downstream nodes.
+func (sc *Scheduler) resolveReady(ctx context.Context, graph *ExecutionGraph, candidates []*Node) []*Node {
+	var ready []*Node
+	for len(candidates) > 0 {
+		var node *Node
+		node, candidates = candidates[0], candidates[1:]
+		if status := node.State().Status; status != NodeStatusNone {
+			if isFinished(status) {
+				// skipped on request before it became ready
+				candidates = append(candidates, graph.finishNode(node)...)
+			}
+			continue
+		}
+		if isReady(ctx, graph, node) {
+			ready = append(ready, node)
+			continue
+		}
+		if isFinished(node.State().Status) {
+			candidates = append(candidates, graph.finishNode(node)...)
+		}
+	}
+	return ready
+}
+
 func (sc *Scheduler) setLastError(err error) {
 	sc.mu.Lock()
 	defer sc.mu.Unlock()
@@ -304,6 +719,150 @@ func (sc *Scheduler) setLastError(err error) {
 	sc.lastError = err
 }
 
+// acquirePool waits for a slot of the resource pool of the node. If the
+// slot cannot be acquired, the node status is settled and an error is
+// returned.
+func (sc *Scheduler) acquirePool(ctx context.Context, graph *ExecutionGraph, node *Node, done chan *Node) (func(), error) {
+	pool := node.data.Step().Pool
+	if sc.pools == nil {
+		err := fmt.Errorf("%w: %s", ErrPoolNotConfigured, pool)
+		node.data.MarkError(err)
+		sc.setLastError(err)
+		return nil, err
+	}
+
+	logger.Info(ctx, "Waiting for a pool slot", "step", node.data.Name(), "pool", pool)
+	release, err := node.acquirePool(ctx, sc.pools, func() {
+		// report the queued status
+		if done != nil {
+			done <- node
+		}
+	})
+	switch {
+	case err == nil:
+		logger.Info(ctx, "Pool slot acquired", "step", node.data.Name(), "pool", pool)
+		return release, nil
+
+	case node.State().Status == NodeStatusCancel:
+		// canceled while waiting for the slot
+
+	case sc.isTimeout(graph.startedAt):
+		logger.Info(ctx, "Step execution deadline exceeded while waiting for a pool slot", "step", node.data.Name(), "pool", pool)
+		node.data.SetStatus(NodeStatusCancel)
+		sc.setLastError(err)
+
+	default:
+		node.data.MarkError(err)
+		sc.setLastError(err)
+	}
+	return nil, err
+}
+
+// waitApproval waits for the manual approval of the node. A rejection or a
+// timeout of the approval marks the node as failed.
+func (sc *Scheduler) waitApproval(ctx context.Context, graph *ExecutionGraph, node *Node, done chan *Node) error {
+	logger.Info(ctx, "Waiting for approval", "step", node.data.Name(), "message", node.data.Step().Approval.Message)
+	err := node.waitApproval(ctx, func() {
+		// report the waiting status
+		if done != nil {
+			done <- node
+		}
+	})
+	switch {
+	case err == nil:
+		logger.Info(ctx, "Step approved", "step", node.data.Name(), "approver", node.State().Approver)
+		return nil
+
+	case node.State().Status == NodeStatusCancel:
+		// canceled while waiting for the approval
+
+	case sc.isTimeout(graph.startedAt):
+		logger.Info(ctx, "Step execution deadline exceeded while waiting for approval", "step", node.data.Name())
+		node.data.SetStatus(NodeStatusCancel)
+		sc.setLastError(err)
+
+	default:
+		logger.Info(ctx, "Step not approved", "step", node.data.Name(), "err", err)
+		node.data.MarkError(err)
+		sc.setLastError(err)
+	}
+	return err
+}
+
+// waitSensor waits for the condition of the sensor of the node. A timeout
+// marks the node as skipped if the sensor is set to soft-fail, and as failed
+// otherwise.
+func (sc *Scheduler) waitSensor(ctx context.Context, graph *ExecutionGraph, node *Node, done chan *Node) error {
+	sensor := node.data.Step().Sensor
+	logger.Info(ctx, "Waiting for sensor", "step", node.data.Name(), "kind", sensor.Kind)
+	err := node.waitSensor(ctx, func() {
+		// report the running status
+		if done != nil {
+			done <- node
+		}
+	})
+	switch {
+	case err == nil:
+		logger.Info(ctx, "Sensor condition met", "step", node.data.Name())
+		return nil
+
+	case node.State().Status == NodeStatusCancel:
+		// canceled while waiting for the condition
+
+	case sc.isTimeout(graph.startedAt):
+		logger.Info(ctx, "Step execution deadline exceeded while waiting for sensor", "step", node.data.Name())
+		node.data.SetStatus(NodeStatusCancel)
+		sc.setLastError(err)
+
+	case errors.Is(err, ErrSensorTimeout) && sensor.SoftFail:
+		logger.Info(ctx, "Sensor timed out; skipping the step", "step", node.data.Name())
+		node.data.SetStatus(NodeStatusSkipped)
+		node.data.SetError(err)
+
+	default:
+		logger.Info(ctx, "Sensor failed", "step", node.data.Name(), "err", err)
+		node.data.MarkError(err)
+		sc.setLastError(err)
+	}
+	return err
+}
+
+// finishWithoutCommand marks the node that only waits for an approval or a
+// sensor as succeeded.
+func (sc *Scheduler) finishWithoutCommand(ctx context.Context, node *Node, done chan *Node) {
+	if err := sc.setupNode(ctx, node); err == nil {
+		_ = sc.teardownNode(ctx, node)
+	}
+	node.data.SetStatus(NodeStatusSuccess)
+	if done != nil {
+		done <- node
+	}
+}
+
+// Approve approves the step waiting for a manual approval.
+func (sc *Scheduler) Approve(graph *ExecutionGraph, stepName, approver, comment string) error {
+	return sc.decide(graph, stepName, approvalDecision{approved: true, approver: approver, comment: comment})
+}
+
+// Reject rejects the step waiting for a manual approval.
+func (sc *Scheduler) Reject(graph *ExecutionGraph, stepName, approver, comment string) error {
+	return sc.decide(graph, stepName, approvalDecision{approved: false, approver: approver, comment: comment})
+}
+
+func (sc *Scheduler) decide(graph *ExecutionGraph, stepName string, decision approvalDecision) error {
+	node, err := graph.findStep(stepName)
+	if err != nil {
+		return err
+	}
+	return node.decide(decision)
+}
+
+// hasCommand returns true if the step has anything to execute.
+func hasCommand(step digraph.Step) bool {
+	return step.Command != "" || step.CmdWithArgs != "" || step.CmdArgsSys != "" ||
+		step.Script != "" || !step.ExecutorConfig.IsCommand()
+}
+
 func (sc *Scheduler) setupNode(ctx context.Context, node *Node) error {
 	if !sc.dry {
 		return node.Setup(ctx, sc.logDir, sc.requestID)
@@ -390,6 +949,8 @@ func (sc *Scheduler) Signal(
 			node.Signal(ctx, sig, allowOverride)
 		}
 	}
+	// The suspended steps receive the signal when they are resumed.
+	sc.resumeSuspended(ctx)
 
 	if done != nil {
 		defer func() {
@@ -408,6 +969,7 @@ func (sc *Scheduler) Cancel(ctx context.Context, g *ExecutionGraph) {
 	for _, node := range g.Nodes() {
 		node.Cancel(ctx)
 	}
+	sc.resumeSuspended(ctx)
 }
 
 // Status returns the status of the scheduler.
@@ -418,7 +980,11 @@ func (sc *Scheduler) Status(g *ExecutionGraph) Status {
 	if !g.IsStarted() {
 		return StatusNone
 	}
-	if g.IsRunning() {
+	if sc.isPaused() {
+		// The run is paused even if no step is running.
+		return StatusPaused
+	}
+	if g.IsRunning() || sc.isAwaitingRetry() {
 		return StatusRunning
 	}
 	if sc.isError() {
@@ -452,10 +1018,13 @@ func (sc *Scheduler) isCanceled() bool {
 func isReady(ctx context.Context, g *ExecutionGraph, node *Node) bool {
 	ready := true
 	for _, dep := range g.to[node.id] {
+		if g.reused[dep] {
+			continue
+		}
 		dep := g.node(dep)
 
 		switch dep.State().Status {
-		case NodeStatusSuccess:
+		case NodeStatusSuccess, NodeStatusCached:
 			continue
 
 		case NodeStatusError:
@@ -478,7 +1047,7 @@ func isReady(ctx context.Context, g *ExecutionGraph, node *Node) bool {
 			ready = false
 			node.data.SetStatus(NodeStatusCancel)
 
-		case NodeStatusNone, NodeStatusRunning:
+		case NodeStatusNone, NodeStatusRunning, NodeStatusQueued, NodeStatusWaiting:
 			ready = false
 
 		default:
@@ -504,7 +1073,11 @@ func (sc *Scheduler) runHandlerNode(ctx context.Context, graph *ExecutionGraph,
 			_ = node.Teardown(ctx)
 		}()
 
-		ctx = sc.buildStepContextForHandler(ctx, graph, node)
+		ctx, err := node.setupEnv(sc.buildStepContextForHandler(ctx, graph, node))
+		if err != nil {
+			node.data.MarkError(err)
+			return err
+		}
 		if err := node.Execute(ctx); err != nil {
 			node.data.SetStatus(NodeStatusError)
 			return err
@@ -518,9 +1091,26 @@ func (sc *Scheduler) runHandlerNode(ctx context.Context, graph *ExecutionGraph,
 	return nil
 }
 
-func (sc *Scheduler) setup(ctx context.Context) (err error) {
-	digraph.GetContext(ctx).ApplyEnvs()
+// RunHandler runs the handler step outside of a run of the DAG, e.g. when
+// the SLA of the DAG is missed. The context must have the DAG context. The
+// log of the step is written to the log directory.
+func RunHandler(ctx context.Context, step digraph.Step, logDir, requestID string) error {
+	node := &Node{data: newSafeData(NodeData{Step: step})}
+	if err := node.Setup(ctx, logDir, requestID); err != nil {
+		return err
+	}
+	defer func() {
+		_ = node.Teardown(ctx)
+	}()
+
+	ctx, err := node.setupEnv(digraph.WithStepContext(ctx, digraph.NewStepContext(ctx, step)))
+	if err != nil {
+		return err
+	}
+	return node.Execute(ctx)
+}
 
+func (sc *Scheduler) setup(ctx context.Context) (err error) {
 	if !sc.dry {
 		if err = os.MkdirAll(sc.logDir, 0755); err != nil {
 			err = fmt.Errorf("failed to create log directory: %w", err)
@@ -548,27 +1138,31 @@ func (sc *Scheduler) setup(ctx context.Context) (err error) {
 func (sc *Scheduler) setCanceled() {
 	sc.mu.Lock()
 	defer sc.mu.Unlock()
+	if sc.canceled == 0 {
+		close(sc.cancelCh)
+	}
 	sc.canceled = 1
 }
 
-func (*Scheduler) runningCount(g *ExecutionGraph) int {
-	count := 0
-	for _, node := range g.Nodes() {
-		if node.State().Status == NodeStatusRunning {
-			count++
-		}
-	}
-	return count
+// isAwaitingRetry returns true while the run waits for the failed steps to
+// be retried.
+func (sc *Scheduler) isAwaitingRetry() bool {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.awaitingRetry
 }
 
-func (*Scheduler) isFinished(g *ExecutionGraph) bool {
-	for _, node := range g.Nodes() {
-		if node.State().Status == NodeStatusRunning ||
-			node.State().Status == NodeStatusNone {
-			return false
-		}
-	}
-	return true
+func (sc *Scheduler) setAwaitingRetry(awaiting bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.awaitingRetry = awaiting
+}
+
+// isPaused returns true if the run is paused.
+func (sc *Scheduler) isPaused() bool {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.paused
 }
 
 func (sc *Scheduler) isSucceed(g *ExecutionGraph) bool {
@@ -576,7 +1170,7 @@ func (sc *Scheduler) isSucceed(g *ExecutionGraph) bool {
 	defer sc.mu.RUnlock()
 	for _, node := range g.Nodes() {
 		nodeStatus := node.State().Status
-		if nodeStatus == NodeStatusSuccess || nodeStatus == NodeStatusSkipped {
+		if nodeStatus == NodeStatusSuccess || nodeStatus == NodeStatusCached || nodeStatus == NodeStatusSkipped {
 			continue
 		}
 		return false
@@ -1,10 +1,15 @@
 package scheduler_test
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"path/filepath"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -12,6 +17,9 @@ import (
 	"github.com/dagu-org/dagu/internal/cmdutil"
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/pool"
+	"github.com/dagu-org/dagu/internal/stepcache"
 	"github.com/dagu-org/dagu/internal/test"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -92,6 +100,673 @@ func TestScheduler(t *testing.T) {
 		result.AssertNodeStatus(t, "3", scheduler.NodeStatusSuccess)
 		result.AssertNodeStatus(t, "4", scheduler.NodeStatusSuccess)
 	})
+	t.Run("PriorityOrder", func(t *testing.T) {
+		sc := setup(t, withMaxActiveRuns(1))
+
+		// 1 (low), 2 (high), 3 (middle) are ready at the same time
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("true"), withPriority(0)),
+			newStep("2", withCommand("true"), withPriority(10)),
+			newStep("3", withCommand("true"), withPriority(5)),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		// 2, 3, 1 should be executed in the order of priority
+		first, second, third := result.Node(t, "2").State(), result.Node(t, "3").State(), result.Node(t, "1").State()
+		require.False(t, second.StartedAt.Before(first.FinishedAt), "3 should start after 2")
+		require.False(t, third.StartedAt.Before(second.FinishedAt), "1 should start after 3")
+	})
+	t.Run("WeightedConcurrency", func(t *testing.T) {
+		sc := setup(t, withMaxActiveWeight(4))
+
+		// heavy (weight 4) must not run concurrently with 1 and 2
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 0.3")),
+			newStep("2", withCommand("sleep 0.3")),
+			newStep("heavy", withCommand("sleep 0.3"), withWeight(4)),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		heavy := result.Node(t, "heavy").State()
+		for _, name := range []string{"1", "2"} {
+			light := result.Node(t, name).State()
+			overlap := light.StartedAt.Before(heavy.FinishedAt) && heavy.StartedAt.Before(light.FinishedAt)
+			require.False(t, overlap, "step %s should not overlap with the heavy step", name)
+		}
+
+		// 1 and 2 should run concurrently
+		one, two := result.Node(t, "1").State(), result.Node(t, "2").State()
+		require.True(t, one.StartedAt.Before(two.FinishedAt) && two.StartedAt.Before(one.FinishedAt))
+	})
+	t.Run("ResourcePool", func(t *testing.T) {
+		pools := pool.New(t.TempDir(), map[string]int{"warehouse": 1}, pool.WithPollInterval(10*time.Millisecond))
+		sc := setup(t, withPools(pools))
+
+		// 1 and 2 share a pool with a single slot; 3 does not use the pool
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 0.3"), withPool("warehouse")),
+			newStep("2", withCommand("sleep 0.3"), withPool("warehouse")),
+			newStep("3", withCommand("sleep 0.3")),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		one, two := result.Node(t, "1").State(), result.Node(t, "2").State()
+		overlap := one.StartedAt.Before(two.FinishedAt) && two.StartedAt.Before(one.FinishedAt)
+		require.False(t, overlap, "steps in the same pool should not overlap")
+
+		three := result.Node(t, "3").State()
+		require.True(t, three.StartedAt.Before(one.FinishedAt) && three.StartedAt.Before(two.FinishedAt))
+	})
+	t.Run("ResourcePoolTimeout", func(t *testing.T) {
+		pools := pool.New(t.TempDir(), map[string]int{"warehouse": 1}, pool.WithPollInterval(10*time.Millisecond))
+
+		// the slot is held by another DAG run
+		release, err := pools.Acquire(context.Background(), "warehouse")
+		require.NoError(t, err)
+		defer release()
+
+		sc := setup(t, withPools(pools), withTimeout(time.Millisecond*300))
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("true"), withPool("warehouse")),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCancel)
+	})
+	t.Run("ResourcePoolQueuedReported", func(t *testing.T) {
+		pools := pool.New(t.TempDir(), map[string]int{"warehouse": 1}, pool.WithPollInterval(10*time.Millisecond))
+
+		// the slot is held by another DAG run
+		release, err := pools.Acquire(context.Background(), "warehouse")
+		require.NoError(t, err)
+
+		sc := setup(t, withPools(pools))
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("true"), withPool("warehouse")),
+		)
+
+		// The node is reported while it waits for the slot.
+		reported := make(chan *scheduler.Node, 10)
+		errCh := make(chan error, 1)
+		ctx := digraph.NewContext(sc.Context, &digraph.DAG{Name: "test_dag"}, nil, sc.Config.ReqID, "")
+		go func() {
+			errCh <- sc.Scheduler.Schedule(ctx, graph.ExecutionGraph, reported)
+		}()
+		select {
+		case node := <-reported:
+			require.Equal(t, scheduler.NodeStatusQueued, node.State().Status)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the queued node was not reported")
+		}
+
+		release()
+		require.NoError(t, <-errCh)
+		scheduleResult{graphHelper: graph}.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+	})
+	t.Run("StepCache", func(t *testing.T) {
+		cache := stepcache.New(t.TempDir())
+		dir := t.TempDir()
+		input := filepath.Join(dir, "input.txt")
+		require.NoError(t, os.WriteFile(input, []byte("v1"), 0600))
+
+		steps := []digraph.Step{
+			newStep("1", withCommand("echo hello"), withOutput("OUT"),
+				withWorkingDir(dir), withCache(&digraph.StepCache{Files: []string{"*.txt"}})),
+			newStep("2", withCommand("echo $OUT"), withDepends("1"), withOutput("RESULT")),
+		}
+
+		// the first run stores the result
+		result := setup(t, withStepCache(cache)).newGraph(t, steps...).Schedule(t, scheduler.StatusSuccess)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+
+		// the second run replays the stdout and the output variables
+		result = setup(t, withStepCache(cache)).newGraph(t, steps...).Schedule(t, scheduler.StatusSuccess)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCached)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+
+		log, err := os.ReadFile(result.Node(t, "1").State().Log)
+		require.NoError(t, err)
+		require.Equal(t, "hello\n", string(log))
+
+		output, ok := result.Node(t, "2").Data().Step.OutputVariables.Load("RESULT")
+		require.True(t, ok)
+		require.Equal(t, "RESULT=hello", output)
+
+		// a change of the input file invalidates the result
+		require.NoError(t, os.WriteFile(input, []byte("v2"), 0600))
+		result = setup(t, withStepCache(cache)).newGraph(t, steps...).Schedule(t, scheduler.StatusSuccess)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+	})
+	t.Run("StepCacheFailureNotStored", func(t *testing.T) {
+		cache := stepcache.New(t.TempDir())
+		steps := []digraph.Step{
+			newStep("1", withCommand("false"), withCache(&digraph.StepCache{})),
+		}
+
+		setup(t, withStepCache(cache)).newGraph(t, steps...).Schedule(t, scheduler.StatusError)
+
+		result := setup(t, withStepCache(cache)).newGraph(t, steps...).Schedule(t, scheduler.StatusError)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+	})
+	t.Run("Approval", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1 (approval only) -> 2 (approval before the command)
+		graph := sc.newGraph(t,
+			newStep("1", withApproval(&digraph.Approval{})),
+			newStep("2", withDepends("1"), withCommand("echo deploy"), withApproval(&digraph.Approval{})),
+		)
+
+		go func() {
+			waitForStatus(t, graph, "1", scheduler.NodeStatusWaiting)
+			assert.NoError(t, sc.Scheduler.Approve(graph.ExecutionGraph, "1", "alice", "looks good"))
+			waitForStatus(t, graph, "2", scheduler.NodeStatusWaiting)
+			assert.ErrorIs(t, sc.Scheduler.Approve(graph.ExecutionGraph, "1", "alice", ""), scheduler.ErrNotWaitingForApproval)
+			assert.NoError(t, sc.Scheduler.Approve(graph.ExecutionGraph, "2", "bob", ""))
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+
+		state := result.Node(t, "1").State()
+		require.Equal(t, "alice", state.Approver)
+		require.Equal(t, "looks good", state.ApprovalComment)
+		require.Equal(t, "bob", result.Node(t, "2").State().Approver)
+	})
+	t.Run("ApprovalRejected", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withApproval(&digraph.Approval{})),
+			successStep("2", "1"),
+		)
+
+		go func() {
+			waitForStatus(t, graph, "1", scheduler.NodeStatusWaiting)
+			assert.NoError(t, sc.Scheduler.Reject(graph.ExecutionGraph, "1", "alice", "not today"))
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusError)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusCancel)
+		require.ErrorIs(t, result.Node(t, "1").State().Error, scheduler.ErrApprovalRejected)
+		require.Equal(t, "not today", result.Node(t, "1").State().ApprovalComment)
+	})
+	t.Run("ApprovalTimeout", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withApproval(&digraph.Approval{Timeout: 100 * time.Millisecond})),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		require.ErrorIs(t, result.Node(t, "1").State().Error, scheduler.ErrApprovalTimeout)
+	})
+	t.Run("ApprovalCanceled", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withApproval(&digraph.Approval{})),
+		)
+
+		go func() {
+			waitForStatus(t, graph, "1", scheduler.NodeStatusWaiting)
+			graph.Cancel(t)
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusCancel)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCancel)
+	})
+	t.Run("CancelStep", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1 -> 2, 3
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 10")),
+			successStep("2", "1"),
+			successStep("3"),
+		)
+
+		go func() {
+			waitForStatus(t, graph, "1", scheduler.NodeStatusRunning)
+			assert.ErrorIs(t, sc.Scheduler.CancelStep(graph.ExecutionGraph, "2"), scheduler.ErrStepNotRunning)
+			assert.NoError(t, sc.Scheduler.CancelStep(graph.ExecutionGraph, "1"))
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusError)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCancel)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusCancel)
+		result.AssertNodeStatus(t, "3", scheduler.NodeStatusSuccess)
+		require.ErrorIs(t, result.Error, scheduler.ErrStepCanceled)
+
+		require.ErrorIs(t, sc.Scheduler.CancelStep(graph.ExecutionGraph, "1"), scheduler.ErrRunFinished)
+	})
+	t.Run("SkipStep", func(t *testing.T) {
+		sc := setup(t, withMaxActiveRuns(1))
+
+		// 1 -> 2 -> 3, 4 waits for the slot of 1
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 1")),
+			successStep("2", "1"),
+			successStep("3", "2"),
+			successStep("4"),
+		)
+
+		go func() {
+			waitForStatus(t, graph, "1", scheduler.NodeStatusRunning)
+			assert.ErrorIs(t, sc.Scheduler.SkipStep(graph.ExecutionGraph, "1"), scheduler.ErrStepNotPending)
+			assert.NoError(t, sc.Scheduler.SkipStep(graph.ExecutionGraph, "2"))
+			assert.NoError(t, sc.Scheduler.SkipStep(graph.ExecutionGraph, "4"))
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSkipped)
+		result.AssertNodeStatus(t, "3", scheduler.NodeStatusSkipped)
+		result.AssertNodeStatus(t, "4", scheduler.NodeStatusSkipped)
+		require.ErrorIs(t, result.Node(t, "2").State().Error, scheduler.ErrStepSkipped)
+	})
+	t.Run("RetryStep", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1 fails the first time -> 2, 3 keeps the run going
+		file := filepath.Join(t.TempDir(), "flag")
+		graph := sc.newGraph(t,
+			newStep("1", withScript("test -f "+file+" || { touch "+file+"; exit 1; }")),
+			successStep("2", "1"),
+			newStep("3", withCommand("sleep 1")),
+		)
+
+		go func() {
+			waitForStatus(t, graph, "2", scheduler.NodeStatusCancel)
+			assert.ErrorIs(t, sc.Scheduler.RetryStep(graph.ExecutionGraph, "3"), scheduler.ErrStepNotFailed)
+			assert.NoError(t, sc.Scheduler.RetryStep(graph.ExecutionGraph, "1"))
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "3", scheduler.NodeStatusSuccess)
+		require.False(t, result.Node(t, "1").State().RetriedAt.IsZero())
+	})
+	t.Run("RetryStepAfterOtherStepsFinished", func(t *testing.T) {
+		sc := setup(t, withRetryWindow(5*time.Second))
+
+		// 1 fails the first time -> 2, 3 finishes before 1 is retried
+		file := filepath.Join(t.TempDir(), "flag")
+		graph := sc.newGraph(t,
+			newStep("1", withScript("test -f "+file+" || { touch "+file+"; exit 1; }")),
+			successStep("2", "1"),
+			successStep("3"),
+		)
+
+		go func() {
+			waitForStatus(t, graph, "1", scheduler.NodeStatusError)
+			waitForStatus(t, graph, "3", scheduler.NodeStatusSuccess)
+			// The run is kept open for the failed step.
+			assert.Eventually(t, func() bool {
+				return sc.Scheduler.Status(graph.ExecutionGraph) == scheduler.StatusRunning
+			}, 5*time.Second, 10*time.Millisecond)
+			assert.NoError(t, sc.Scheduler.RetryStep(graph.ExecutionGraph, "1"))
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "3", scheduler.NodeStatusSuccess)
+	})
+	t.Run("RetryWindowElapsed", func(t *testing.T) {
+		sc := setup(t, withRetryWindow(100*time.Millisecond))
+
+		graph := sc.newGraph(t,
+			failStep("1"),
+			successStep("2"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+
+		require.ErrorIs(t, sc.Scheduler.RetryStep(graph.ExecutionGraph, "1"), scheduler.ErrRunFinished)
+	})
+	t.Run("SetMaxActiveRuns", func(t *testing.T) {
+		sc := setup(t, withMaxActiveRuns(1))
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 1")),
+			newStep("2", withCommand("sleep 1")),
+		)
+
+		go func() {
+			waitForStatus(t, graph, "1", scheduler.NodeStatusRunning)
+			assert.ErrorIs(t, sc.Scheduler.SetMaxActiveRuns(-1), scheduler.ErrInvalidMaxActiveRuns)
+			assert.NoError(t, sc.Scheduler.SetMaxActiveRuns(2))
+			// 2 starts without waiting for 1
+			waitForStatus(t, graph, "2", scheduler.NodeStatusRunning)
+			assert.Equal(t, scheduler.NodeStatusRunning, graph.Nodes()[0].State().Status)
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+	})
+	t.Run("PauseAndResume", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1 -> 2
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 0.5")),
+			successStep("2", "1"),
+		)
+
+		go func() {
+			waitForStatus(t, graph, "1", scheduler.NodeStatusRunning)
+			assert.ErrorIs(t, sc.Scheduler.Resume(), scheduler.ErrRunNotPaused)
+			assert.NoError(t, sc.Scheduler.Pause(false))
+			assert.ErrorIs(t, sc.Scheduler.Pause(false), scheduler.ErrRunPaused)
+			assert.Equal(t, scheduler.StatusPaused, sc.Scheduler.Status(graph.ExecutionGraph))
+
+			// 1 runs to completion, but 2 does not start while paused.
+			waitForStatus(t, graph, "1", scheduler.NodeStatusSuccess)
+			time.Sleep(time.Millisecond * 300)
+			assert.Equal(t, scheduler.NodeStatusNone, graph.Nodes()[1].State().Status)
+			assert.Equal(t, scheduler.StatusPaused, sc.Scheduler.Status(graph.ExecutionGraph))
+
+			assert.NoError(t, sc.Scheduler.Resume())
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+	})
+	t.Run("PauseSuspendSteps", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 0.5")),
+		)
+
+		go func() {
+			waitForStatus(t, graph, "1", scheduler.NodeStatusRunning)
+			assert.NoError(t, sc.Scheduler.Pause(true))
+
+			// The suspended step does not finish while paused.
+			time.Sleep(time.Second)
+			assert.Equal(t, scheduler.NodeStatusRunning, graph.Nodes()[0].State().Status)
+
+			assert.NoError(t, sc.Scheduler.Resume())
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+	})
+	t.Run("CancelPausedRun", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1 -> 2
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 10")),
+			successStep("2", "1"),
+		)
+
+		go func() {
+			waitForStatus(t, graph, "1", scheduler.NodeStatusRunning)
+			assert.NoError(t, sc.Scheduler.Pause(true))
+			// The suspended step is terminated when the run is canceled.
+			sc.Scheduler.Cancel(context.Background(), graph.ExecutionGraph)
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusCancel)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCancel)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusNone)
+	})
+	t.Run("StreamLog", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1 -> 2 -> 3
+		graph := sc.newGraph(t,
+			newStep("1", withScript("echo first\nsleep 1\necho second")),
+			newStep("2", withDepends("1"), withCommand("false")),
+			successStep("3", "2"),
+		)
+		nodes := scheduleResult{graphHelper: graph}
+
+		type streamed struct {
+			log string
+			err error
+		}
+		follow := func(stepName string, ch chan streamed) {
+			var buf bytes.Buffer
+			err := nodes.Node(t, stepName).StreamLog(context.Background(), &buf, true)
+			ch <- streamed{log: buf.String(), err: err}
+		}
+		// The step that has not started is followed until it runs or the
+		// run finishes.
+		notRun := make(chan streamed, 1)
+		go follow("3", notRun)
+
+		running := make(chan streamed, 1)
+		go func() {
+			waitForStatus(t, graph, "1", scheduler.NodeStatusRunning)
+			follow("1", running)
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusError)
+		result.AssertNodeStatus(t, "3", scheduler.NodeStatusCancel)
+
+		got := <-running
+		require.NoError(t, got.err)
+		require.Equal(t, "first\nsecond\n", got.log)
+
+		got = <-notRun
+		require.NoError(t, got.err)
+		require.Empty(t, got.log)
+
+		// The finished step returns the log without following it.
+		var buf bytes.Buffer
+		require.NoError(t, result.Node(t, "1").StreamLog(context.Background(), &buf, true))
+		require.Equal(t, "first\nsecond\n", buf.String())
+	})
+	t.Run("FileSensor", func(t *testing.T) {
+		sc := setup(t)
+
+		dir := t.TempDir()
+		graph := sc.newGraph(t,
+			newStep("1", withSensor(&digraph.Sensor{
+				Kind:         digraph.SensorKindFile,
+				Path:         "*.csv",
+				PokeInterval: 50 * time.Millisecond,
+			}), withWorkingDir(dir)),
+			successStep("2", "1"),
+		)
+
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, "input.csv"), []byte("a,b\n"), 0600))
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+	})
+	t.Run("HTTPSensor", func(t *testing.T) {
+		sc := setup(t)
+
+		var ready atomic.Bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if !ready.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"ready"}`))
+		}))
+		defer server.Close()
+
+		graph := sc.newGraph(t,
+			newStep("1", withSensor(&digraph.Sensor{
+				Kind:         digraph.SensorKindHTTP,
+				URL:          server.URL,
+				StatusCode:   http.StatusOK,
+				Body:         "ready",
+				PokeInterval: 50 * time.Millisecond,
+			}), withCommand("echo done")),
+		)
+
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			ready.Store(true)
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+	})
+	t.Run("CommandSensorTimeout", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withSensor(&digraph.Sensor{
+				Kind:         digraph.SensorKindCommand,
+				Command:      "false",
+				PokeInterval: 20 * time.Millisecond,
+				Timeout:      100 * time.Millisecond,
+			})),
+			successStep("2", "1"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusCancel)
+		require.ErrorIs(t, result.Node(t, "1").State().Error, scheduler.ErrSensorTimeout)
+	})
+	t.Run("SensorSoftFail", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withSensor(&digraph.Sensor{
+				Kind:         digraph.SensorKindCommand,
+				Command:      "false",
+				PokeInterval: 20 * time.Millisecond,
+				Timeout:      100 * time.Millisecond,
+				SoftFail:     true,
+			})),
+			successStep("2", "1"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSkipped)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSkipped)
+	})
+	t.Run("SensorCanceled", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withSensor(&digraph.Sensor{
+				Kind:         digraph.SensorKindCommand,
+				Command:      "false",
+				PokeInterval: 20 * time.Millisecond,
+			})),
+		)
+
+		go func() {
+			waitForStatus(t, graph, "1", scheduler.NodeStatusRunning)
+			graph.Signal(syscall.SIGTERM)
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusCancel)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusCancel)
+	})
+	t.Run("PartialRun", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1 -> 2 -> 3, 4
+		steps := []digraph.Step{
+			newStep("1", withCommand("echo new"), withOutput("OUT")),
+			newStep("2", withCommand("echo $OUT"), withDepends("1"), withOutput("RESULT")),
+			successStep("3", "2"),
+			successStep("4"),
+		}
+
+		// the previous run where 2 failed
+		outputs := &digraph.SyncMap{}
+		outputs.Store("OUT", "OUT=previous")
+		step1 := steps[0]
+		step1.OutputVariables = outputs
+		reference := []*scheduler.Node{
+			scheduler.NodeWithData(scheduler.NodeData{
+				Step:  step1,
+				State: scheduler.NodeState{Status: scheduler.NodeStatusSuccess},
+			}),
+			scheduler.NodeWithData(scheduler.NodeData{
+				Step:  steps[1],
+				State: scheduler.NodeState{Status: scheduler.NodeStatusError},
+			}),
+			scheduler.NodeWithData(scheduler.NodeData{
+				Step:  steps[2],
+				State: scheduler.NodeState{Status: scheduler.NodeStatusCancel},
+			}),
+			scheduler.NodeWithData(scheduler.NodeData{
+				Step:  steps[3],
+				State: scheduler.NodeState{Status: scheduler.NodeStatusSuccess},
+			}),
+		}
+
+		graph, err := scheduler.CreatePartialExecutionGraph(sc.Context, steps, reference, []string{"2"}, true)
+		require.NoError(t, err)
+
+		result := graphHelper{testHelper: sc, ExecutionGraph: graph}.Schedule(t, scheduler.StatusSuccess)
+
+		// 1 and 4 are not executed
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "3", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "4", scheduler.NodeStatusSuccess)
+		require.True(t, result.Node(t, "1").State().StartedAt.IsZero())
+		require.True(t, result.Node(t, "4").State().StartedAt.IsZero())
+
+		// 2 uses the output of 1 from the previous run
+		output, _ := result.Node(t, "2").Data().Step.OutputVariables.Load("RESULT")
+		require.Equal(t, "RESULT=previous", output)
+	})
+	t.Run("PartialRunIgnoresUnselectedUpstream", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1 (failed before) -> 2; only 2 is selected
+		steps := []digraph.Step{
+			failStep("1"),
+			successStep("2", "1"),
+		}
+		reference := []*scheduler.Node{
+			scheduler.NodeWithData(scheduler.NodeData{
+				Step:  steps[0],
+				State: scheduler.NodeState{Status: scheduler.NodeStatusError},
+			}),
+		}
+
+		graph, err := scheduler.CreatePartialExecutionGraph(sc.Context, steps, reference, []string{"2"}, false)
+		require.NoError(t, err)
+
+		result := graphHelper{testHelper: sc, ExecutionGraph: graph}.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+	})
+	t.Run("ResourcePoolNotConfigured", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("true"), withPool("warehouse")),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		require.ErrorIs(t, result.Node(t, "1").State().Error, scheduler.ErrPoolNotConfigured)
+	})
 	t.Run("ComplexCommand", func(t *testing.T) {
 		sc := setup(t, withMaxActiveRuns(1))
 
@@ -607,6 +1282,130 @@ func TestScheduler(t *testing.T) {
 
 		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
 	})
+	t.Run("RepeatLimit", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1",
+				withCommand("true"),
+				withRepeatPolicy(true, time.Millisecond*10),
+				withRepeatLimit(3),
+			),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+
+		node := result.Node(t, "1")
+		require.Equal(t, 3, node.State().DoneCount)
+		require.Len(t, node.State().Repetitions, 3)
+	})
+	t.Run("RepeatUntilCondition", func(t *testing.T) {
+		sc := setup(t)
+
+		// the script prints the number of executions
+		counter := filepath.Join(t.TempDir(), "counter")
+		script := fmt.Sprintf("n=$(cat %[1]s 2>/dev/null || echo 0); n=$((n+1)); echo $n > %[1]s; echo $n", counter)
+
+		graph := sc.newGraph(t,
+			newStep("1",
+				withScript(script),
+				withOutput("COUNT"),
+				withRepeatPolicy(true, time.Millisecond*10),
+				func(step *digraph.Step) {
+					step.RepeatPolicy.Until = []digraph.Condition{{Condition: "$COUNT", Expected: "3"}}
+				},
+			),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		node := result.Node(t, "1")
+		require.Equal(t, 3, node.State().DoneCount)
+	})
+	t.Run("RepeatUntilExitCode", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1",
+				withCommand("false"),
+				withRepeatPolicy(true, time.Millisecond*10),
+				withContinueOn(digraph.ContinueOn{Failure: true}),
+				func(step *digraph.Step) {
+					step.RepeatPolicy.ExitCode = []int{1}
+				},
+			),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		node := result.Node(t, "1")
+		require.Equal(t, 1, node.State().DoneCount)
+		require.Equal(t, 1, node.State().Repetitions[0].ExitCode)
+	})
+	t.Run("RepeatWhileCondition", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1",
+				withCommand("true"),
+				withRepeatPolicy(true, time.Millisecond*10),
+				func(step *digraph.Step) {
+					step.RepeatPolicy.While = []digraph.Condition{{Command: "false"}}
+				},
+			),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		node := result.Node(t, "1")
+		require.Equal(t, 1, node.State().DoneCount)
+	})
+	t.Run("CancelDuringRepeatInterval", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1",
+				withCommand("true"),
+				withRepeatPolicy(true, time.Hour),
+			),
+		)
+
+		go func() {
+			time.Sleep(time.Millisecond * 300)
+			graph.Signal(syscall.SIGTERM)
+		}()
+
+		startedAt := time.Now()
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		// the wait for the next repetition is interrupted
+		require.Less(t, time.Since(startedAt), time.Second*5)
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+	})
+	t.Run("StepEnv", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("echo hello"), withOutput("OUT")),
+			newStep("2",
+				withCommand("printenv GREETING"),
+				withDepends("1"),
+				withOutput("RESULT"),
+				func(step *digraph.Step) {
+					step.Env = []string{"NAME=world", "GREETING=${OUT} ${NAME}"}
+				},
+			),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		node := result.Node(t, "2")
+		output, ok := node.Data().Step.OutputVariables.Load("RESULT")
+		require.True(t, ok, "output variable not found")
+		require.Equal(t, "RESULT=hello world", output)
+	})
 	t.Run("NodeSetupFailure", func(t *testing.T) {
 		sc := setup(t)
 
@@ -826,6 +1625,48 @@ func withRepeatPolicy(repeat bool, interval time.Duration) stepOption {
 	}
 }
 
+func withRepeatLimit(limit int) stepOption {
+	return func(step *digraph.Step) {
+		step.RepeatPolicy.Limit = limit
+	}
+}
+
+func withPriority(priority int) stepOption {
+	return func(step *digraph.Step) {
+		step.Priority = priority
+	}
+}
+
+func withWeight(weight int) stepOption {
+	return func(step *digraph.Step) {
+		step.Weight = weight
+	}
+}
+
+func withPool(name string) stepOption {
+	return func(step *digraph.Step) {
+		step.Pool = name
+	}
+}
+
+func withCache(cache *digraph.StepCache) stepOption {
+	return func(step *digraph.Step) {
+		step.Cache = cache
+	}
+}
+
+func withApproval(approval *digraph.Approval) stepOption {
+	return func(step *digraph.Step) {
+		step.Approval = approval
+	}
+}
+
+func withSensor(sensor *digraph.Sensor) stepOption {
+	return func(step *digraph.Step) {
+		step.Sensor = sensor
+	}
+}
+
 func withPrecondition(condition digraph.Condition) stepOption {
 	return func(step *digraph.Step) {
 		step.Preconditions = []digraph.Condition{condition}
@@ -886,12 +1727,36 @@ func withTimeout(d time.Duration) schedulerOption {
 	}
 }
 
+func withRetryWindow(d time.Duration) schedulerOption {
+	return func(cfg *scheduler.Config) {
+		cfg.RetryWindow = d
+	}
+}
+
 func withMaxActiveRuns(n int) schedulerOption {
 	return func(cfg *scheduler.Config) {
 		cfg.MaxActiveRuns = n
 	}
 }
 
+func withMaxActiveWeight(n int) schedulerOption {
+	return func(cfg *scheduler.Config) {
+		cfg.MaxActiveWeight = n
+	}
+}
+
+func withPools(pools scheduler.ResourcePools) schedulerOption {
+	return func(cfg *scheduler.Config) {
+		cfg.Pools = pools
+	}
+}
+
+func withStepCache(cache scheduler.StepCache) schedulerOption {
+	return func(cfg *scheduler.Config) {
+		cfg.StepCache = cache
+	}
+}
+
 func withOnExit(step digraph.Step) schedulerOption {
 	return func(cfg *scheduler.Config) {
 		cfg.OnExit = &step
@@ -1014,6 +1879,20 @@ func (gh graphHelper) Cancel(t *testing.T) {
 	gh.Scheduler.Cancel(gh.Context, gh.ExecutionGraph)
 }
 
+// waitForStatus waits until the step reaches the status.
+func waitForStatus(t *testing.T, gh graphHelper, stepName string, status scheduler.NodeStatus) {
+	t.Helper()
+
+	assert.Eventually(t, func() bool {
+		for _, node := range gh.ExecutionGraph.Nodes() {
+			if node.Data().Step.Name == stepName {
+				return node.State().Status == status
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 type scheduleResult struct {
 	graphHelper
 	Done  []*scheduler.Node
@@ -1084,3 +1963,74 @@ func (sr scheduleResult) Node(t *testing.T, stepName string) *scheduler.Node {
 	t.Fatalf("step %s not found", stepName)
 	return nil
 }
+
+// BenchmarkSchedule measures the scheduling overhead per node. Steps are
+// executed in dry mode so that the result does not include the cost of
+// running the actual commands.
+func BenchmarkSchedule(b *testing.B) {
+	for _, bc := range []struct {
+		name  string
+		steps []digraph.Step
+	}{
+		{name: "Chain400", steps: benchChain(400)},
+		{name: "FanOut400", steps: benchFanOut(400)},
+		{name: "Layered20x20", steps: benchLayered(20, 20)},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			dag := &digraph.DAG{Name: "bench_dag"}
+			ctx := logger.WithLogger(context.Background(), logger.NewLogger(logger.WithQuiet()))
+			ctx = digraph.NewContext(ctx, dag, nil, "bench", "")
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				graph, err := scheduler.NewExecutionGraph(bc.steps...)
+				require.NoError(b, err)
+				sc := scheduler.New(&scheduler.Config{Dry: true, ReqID: "bench"})
+				b.StartTimer()
+
+				require.NoError(b, sc.Schedule(ctx, graph, nil))
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(bc.steps)), "ns/node")
+		})
+	}
+}
+
+// benchChain returns n steps where each step depends on the previous one.
+func benchChain(n int) []digraph.Step {
+	steps := make([]digraph.Step, 0, n)
+	for i := 0; i < n; i++ {
+		var depends []string
+		if i > 0 {
+			depends = append(depends, fmt.Sprintf("s%d", i-1))
+		}
+		steps = append(steps, newStep(fmt.Sprintf("s%d", i), withDepends(depends...)))
+	}
+	return steps
+}
+
+// benchFanOut returns a root step and n-1 steps depending on it.
+func benchFanOut(n int) []digraph.Step {
+	steps := []digraph.Step{newStep("s0")}
+	for i := 1; i < n; i++ {
+		steps = append(steps, newStep(fmt.Sprintf("s%d", i), withDepends("s0")))
+	}
+	return steps
+}
+
+// benchLayered returns layers of steps where each step depends on all steps
+// of the previous layer.
+func benchLayered(layers, width int) []digraph.Step {
+	var steps []digraph.Step
+	var prev []string
+	for l := 0; l < layers; l++ {
+		var curr []string
+		for w := 0; w < width; w++ {
+			name := fmt.Sprintf("s%d_%d", l, w)
+			steps = append(steps, newStep(name, withDepends(prev...)))
+			curr = append(curr, name)
+		}
+		prev = curr
+	}
+	return steps
+}
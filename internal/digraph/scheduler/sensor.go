@@ -0,0 +1,221 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/cmdutil"
+	"github.com/dagu-org/dagu/internal/digraph"
+)
+
+// sensor checks the condition a sensor step waits for.
+type sensor interface {
+	// poke returns true if the condition is met.
+	poke(ctx context.Context) (bool, error)
+}
+
+// newSensor creates the sensor for the configuration. The string fields
+// are evaluated with the step context.
+func newSensor(ctx context.Context, cfg digraph.Sensor, dir string) (sensor, error) {
+	stepContext := digraph.GetStepContext(ctx)
+	eval := func(field, value string) (string, error) {
+		v, err := stepContext.EvalString(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to evaluate sensor %s: %w", field, err)
+		}
+		return v, nil
+	}
+
+	switch cfg.Kind {
+	case digraph.SensorKindFile:
+		path, err := eval("path", cfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		return &fileSensor{pattern: path}, nil
+
+	case digraph.SensorKindHTTP:
+		url, err := eval("url", cfg.URL)
+		if err != nil {
+			return nil, err
+		}
+		body, err := eval("body", cfg.Body)
+		if err != nil {
+			return nil, err
+		}
+		method := cfg.Method
+		if method == "" {
+			method = http.MethodGet
+		}
+		return &httpSensor{
+			client:     &http.Client{Timeout: cfg.PokeInterval},
+			method:     strings.ToUpper(method),
+			url:        url,
+			statusCode: cfg.StatusCode,
+			body:       body,
+		}, nil
+
+	case digraph.SensorKindDAG:
+		name, err := eval("dag", cfg.DAG)
+		if err != nil {
+			return nil, err
+		}
+		date := stepContext.LogicalDate()
+		if date.IsZero() {
+			date = time.Now()
+		}
+		if cfg.Date != "" {
+			value, err := eval("date", cfg.Date)
+			if err != nil {
+				return nil, err
+			}
+			date, err = time.ParseInLocation(time.DateOnly, value, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sensor date %q: %w", value, err)
+			}
+		}
+		return &dagSensor{name: name, date: date}, nil
+
+	case digraph.SensorKindCommand:
+		command, err := eval("command", cfg.Command)
+		if err != nil {
+			return nil, err
+		}
+		return &commandSensor{
+			shell:   cmdutil.GetShellCommand(""),
+			command: command,
+			dir:     dir,
+			env:     stepContext.AllEnvs(),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown sensor kind: %s", cfg.Kind)
+	}
+}
+
+// fileSensor waits for the files matching a path or glob pattern to exist
+// with the same sizes on two consecutive pokes, so that a file still being
+// written is not taken.
+type fileSensor struct {
+	pattern string
+	sizes   map[string]int64
+}
+
+func (s *fileSensor) poke(_ context.Context) (bool, error) {
+	matches, err := filepath.Glob(s.pattern)
+	if err != nil {
+		return false, err
+	}
+
+	sizes := make(map[string]int64, len(matches))
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil {
+			continue
+		}
+		sizes[match] = info.Size()
+	}
+
+	prev := s.sizes
+	s.sizes = sizes
+	if len(sizes) == 0 || len(prev) != len(sizes) {
+		return false, nil
+	}
+	for path, size := range sizes {
+		if prevSize, ok := prev[path]; !ok || prevSize != size {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// httpSensor waits for an endpoint to return the expected status code and
+// a body containing the expected text.
+type httpSensor struct {
+	client     *http.Client
+	method     string
+	url        string
+	statusCode int
+	body       string
+}
+
+func (s *httpSensor) poke(ctx context.Context) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, s.method, s.url, nil)
+	if err != nil {
+		return false, err
+	}
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = rsp.Body.Close()
+	}()
+
+	if s.statusCode != 0 {
+		if rsp.StatusCode != s.statusCode {
+			return false, nil
+		}
+	} else if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return false, nil
+	}
+
+	if s.body == "" {
+		return true, nil
+	}
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return false, err
+	}
+	return strings.Contains(string(body), s.body), nil
+}
+
+// dagSensor waits for the latest run of a DAG on the logical date to
+// succeed.
+type dagSensor struct {
+	name string
+	date time.Time
+}
+
+func (s *dagSensor) poke(ctx context.Context) (bool, error) {
+	result, err := digraph.GetStepContext(ctx).GetLatestResult(s.name, s.date)
+	if errors.Is(err, digraph.ErrRunNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return result.Status == StatusSuccess.String(), nil
+}
+
+// commandSensor waits for a command to exit with zero.
+type commandSensor struct {
+	shell   string
+	command string
+	dir     string
+	env     []string
+}
+
+func (s *commandSensor) poke(ctx context.Context) (bool, error) {
+	// nolint: gosec
+	cmd := exec.CommandContext(ctx, s.shell, "-c", s.command)
+	cmd.Dir = s.dir
+	cmd.Env = s.env
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return false, nil
+	}
+	return err == nil, err
+}
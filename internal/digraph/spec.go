@@ -49,6 +49,8 @@ type definition struct {
 	Preconditions any
 	// MaxActiveRuns is the maximum number of concurrent steps.
 	MaxActiveRuns int
+	// MaxActiveWeight is the maximum total weight of concurrent steps.
+	MaxActiveWeight int
 	// Params is the default parameters for the steps.
 	Params any
 	// MaxCleanUpTimeSec is the maximum time in seconds to clean up the DAG.
@@ -57,6 +59,67 @@ type definition struct {
 	MaxCleanUpTimeSec *int
 	// Tags is the tags for the DAG.
 	Tags any
+	// Triggers is the DAGs to start when the DAG finishes
+	// (string, triggerDef or an array of them).
+	Triggers any
+	// DependsOn is the DAGs whose runs start the DAG when they finish
+	// (string, triggerDef or an array of them).
+	DependsOn any
+	// Workspace gives each run a fresh working directory
+	// (bool or workspaceDef).
+	Workspace any
+	// Catchup is the policy to run the scheduled runs missed while the
+	// scheduler was not running (latest, all or none).
+	Catchup string
+	// Timezone is the IANA time zone of the schedule, e.g. Asia/Tokyo.
+	Timezone string
+	// ExcludeCalendars is the names of the calendars defined in the config
+	// whose days and windows are skipped by the schedule.
+	ExcludeCalendars []string
+	// Concurrency is the policy for the starts while the DAG is running.
+	Concurrency *concurrencyDef
+	// SLA is the service level the runs are expected to meet.
+	SLA *slaDef
+	// OnAgentLost is the action for a run whose agent was lost (fail or
+	// retry).
+	OnAgentLost string
+	// RetryWindow is how long a run with failed steps is kept open for them
+	// to be retried after the other steps finished, e.g. 30m.
+	RetryWindow string
+}
+
+// slaDef defines the service level of the runs of the DAG.
+type slaDef struct {
+	// MaxDuration is the longest time a run may take, e.g. 2h30m.
+	MaxDuration string
+	// MustFinishBy is the time of the day the DAG must have finished by,
+	// e.g. 06:00.
+	MustFinishBy string
+}
+
+// concurrencyDef defines the policy for the starts while the DAG is running.
+type concurrencyDef struct {
+	// Max is the number of the runs running at the same time. It defaults
+	// to 1.
+	Max *int
+	// OnConflict is skip, queue or cancelPrevious.
+	OnConflict string
+}
+
+// workspaceDef defines the workspace directory of the runs.
+type workspaceDef struct {
+	// RetentionDays is the number of days to keep the workspace.
+	RetentionDays int
+}
+
+// triggerDef defines a link between a run of a DAG and another DAG.
+type triggerDef struct {
+	// DAG is the name of the other DAG.
+	DAG string
+	// On is the outcome of the upstream run: success, failure or always.
+	On string
+	// Params is the parameters passed to the downstream DAG.
+	Params string
 }
 
 // handlerOnDef defines the steps to be executed on different events.
@@ -65,6 +128,7 @@ type handlerOnDef struct {
 	Success *stepDef // Step to execute on success
 	Cancel  *stepDef // Step to execute on cancel
 	Exit    *stepDef // Step to execute on exit
+	SLAMiss *stepDef // Step to execute when the SLA is missed
 }
 
 // stepDef defines a step in the DAG.
@@ -113,6 +177,49 @@ type stepDef struct {
 	Run string
 	// Params is the parameters for the sub workflow
 	Params string
+	// Priority is the priority of the step among the ready steps.
+	Priority int
+	// Weight is the number of concurrency slots the step occupies.
+	Weight *int
+	// Pool is the name of the resource pool to take a slot from.
+	Pool string
+	// Cache enables caching of the step result (bool or cacheDef).
+	Cache any
+	// Approval makes the step wait for a manual approval (bool or approvalDef).
+	Approval any
+	// Sensor makes the step wait for an external condition.
+	Sensor *sensorDef
+	// Env is the environment variables of the step
+	// (map, array of maps or array of KEY=VALUE strings).
+	Env any
+}
+
+// sensorDef defines the condition a sensor step waits for.
+type sensorDef struct {
+	// Kind is one of file, http, dag and command.
+	Kind string
+	// Path is the path or glob pattern for the file sensor.
+	Path string
+	// URL is the endpoint for the http sensor.
+	URL string
+	// Method is the HTTP method for the http sensor.
+	Method string
+	// Status is the expected status code for the http sensor.
+	Status int
+	// Body is the expected substring of the body for the http sensor.
+	Body string
+	// DAG is the name of the DAG for the dag sensor.
+	DAG string
+	// Date is the logical date of the run for the dag sensor.
+	Date string
+	// Command is the command for the command sensor.
+	Command string
+	// PokeIntervalSec is the interval between the checks in seconds.
+	PokeIntervalSec int
+	// TimeoutSec is the time to wait for the condition in seconds.
+	TimeoutSec int
+	// SoftFail skips the step instead of failing it on timeout.
+	SoftFail bool
 }
 
 // funcDef defines a function in the DAG.
@@ -137,10 +244,29 @@ type continueOnDef struct {
 	MarkSuccess bool // Mark the step as success when the condition is met
 }
 
+// cacheDef defines the inputs of the step cache key.
+type cacheDef struct {
+	Env   any // Names of the environment variables (string or []string)
+	Files any // Paths or glob patterns of the input files (string or []string)
+}
+
+// approvalDef defines the manual approval of a step.
+type approvalDef struct {
+	Message    string // Message shown to the approvers
+	TimeoutSec int    // Time to wait for the approval in seconds
+}
+
 // repeatPolicyDef defines the repeat policy for a step.
 type repeatPolicyDef struct {
-	Repeat      bool // Flag to indicate if the step should be repeated
-	IntervalSec int  // Interval in seconds between repeats
+	Repeat         bool    // Flag to indicate if the step should be repeated
+	IntervalSec    int     // Interval in seconds between repeats
+	Limit          int     // Maximum number of executions
+	Until          any     // Conditions to stop repeating (same as precondition)
+	While          any     // Conditions to keep repeating (same as precondition)
+	ExitCode       any     // Exit codes to stop repeating (int or []int)
+	Backoff        float64 // Factor to multiply the interval by after each repeat
+	MaxIntervalSec int     // Maximum interval in seconds with backoff
+	Cron           string  // Cron expression of the repeats
 }
 
 // retryPolicyDef defines the retry policy for a step.
@@ -169,4 +295,5 @@ type mailConfigDef struct {
 type mailOnDef struct {
 	Failure bool // Send mail on failure
 	Success bool // Send mail on success
+	SLAMiss bool // Send mail when the SLA is missed
 }
@@ -2,6 +2,7 @@ package digraph
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
 )
@@ -57,6 +58,30 @@ type Step struct {
 	SignalOnStop string `json:"SignalOnStop,omitempty"`
 	// SubWorkflow contains the information about a sub DAG to be executed.
 	SubWorkflow *SubWorkflow `json:"SubWorkflow,omitempty"`
+	// Priority is the priority of the step. When several steps are ready to
+	// run, the ones with the higher priority are started first.
+	Priority int `json:"Priority,omitempty"`
+	// Weight is the number of concurrency slots the step occupies when the
+	// DAG sets MaxActiveWeight. The default is 1.
+	Weight int `json:"Weight,omitempty"`
+	// Pool is the name of the resource pool the step takes a slot from
+	// while it runs. Pools are defined in the server configuration and
+	// shared across DAG runs.
+	Pool string `json:"Pool,omitempty"`
+	// Cache enables caching of the result of the step. When the inputs of
+	// the step are the same as a previous successful run, the step is not
+	// executed and the cached result is used instead.
+	Cache *StepCache `json:"Cache,omitempty"`
+	// Approval makes the step wait for a manual approval before it runs.
+	// A step without a command only waits for the approval.
+	Approval *Approval `json:"Approval,omitempty"`
+	// Sensor makes the step wait for an external condition before it runs.
+	// A step without a command only waits for the condition.
+	Sensor *Sensor `json:"Sensor,omitempty"`
+	// Env is the environment variables of the step in the form of
+	// KEY=VALUE. The values are evaluated when the step starts, and take
+	// precedence over the environment variables of the DAG.
+	Env []string `json:"Env,omitempty"`
 }
 
 // setup sets the default values for the step.
@@ -123,12 +148,122 @@ type RetryPolicy struct {
 	IntervalSecStr string `json:"IntervalSecStr,omitempty"`
 }
 
+// StepCache contains the inputs of a step that are part of the cache key
+// in addition to the evaluated command and script.
+type StepCache struct {
+	// Env is the names of the environment variables and parameters.
+	Env []string `json:"Env,omitempty"`
+	// Files is the paths or glob patterns of the input files. The contents
+	// of the files are hashed.
+	Files []string `json:"Files,omitempty"`
+}
+
+// Approval contains the configuration of the manual approval of a step.
+type Approval struct {
+	// Message is shown to the approvers.
+	Message string `json:"Message,omitempty"`
+	// Timeout is the time to wait for the approval. The step is rejected
+	// when it expires. Zero means no timeout.
+	Timeout time.Duration `json:"Timeout,omitempty"`
+}
+
+// SensorKind is the kind of the condition a sensor waits for.
+type SensorKind string
+
+const (
+	// SensorKindFile waits for a file matching a path or glob to exist with
+	// a stable size.
+	SensorKindFile SensorKind = "file"
+	// SensorKindHTTP waits for an HTTP endpoint to return the expected
+	// status and body.
+	SensorKindHTTP SensorKind = "http"
+	// SensorKindDAG waits for the latest run of a DAG on a logical date to
+	// succeed.
+	SensorKindDAG SensorKind = "dag"
+	// SensorKindCommand waits for a command to exit with zero.
+	SensorKindCommand SensorKind = "command"
+)
+
+// Sensor contains the configuration of a sensor step. The condition is
+// checked (poked) every PokeInterval until it is met or Timeout expires.
+type Sensor struct {
+	// Kind is the kind of the condition.
+	Kind SensorKind `json:"Kind"`
+	// Path is the path or glob pattern of the file for the file sensor.
+	Path string `json:"Path,omitempty"`
+	// URL is the endpoint for the http sensor.
+	URL string `json:"URL,omitempty"`
+	// Method is the HTTP method for the http sensor. Defaults to GET.
+	Method string `json:"Method,omitempty"`
+	// StatusCode is the expected status code for the http sensor.
+	// Zero means any 2xx status.
+	StatusCode int `json:"StatusCode,omitempty"`
+	// Body is a substring the response body must contain for the http
+	// sensor.
+	Body string `json:"Body,omitempty"`
+	// DAG is the name of the DAG for the dag sensor.
+	DAG string `json:"DAG,omitempty"`
+	// Date is the logical date (YYYY-MM-DD) of the run for the dag sensor.
+	// Defaults to the logical date of the run of the sensor, or the current
+	// date if the run has none.
+	Date string `json:"Date,omitempty"`
+	// Command is the command for the command sensor.
+	Command string `json:"Command,omitempty"`
+	// PokeInterval is the interval between the checks.
+	PokeInterval time.Duration `json:"PokeInterval,omitempty"`
+	// Timeout is the time to wait for the condition. Zero means no timeout.
+	Timeout time.Duration `json:"Timeout,omitempty"`
+	// SoftFail marks the step as skipped instead of failed on timeout.
+	SoftFail bool `json:"SoftFail,omitempty"`
+}
+
 // RepeatPolicy contains the repeat policy for a step.
 type RepeatPolicy struct {
 	// Repeat determines if the step should be repeated.
 	Repeat bool `json:"Repeat,omitempty"`
 	// Interval is the time to wait between repeats.
 	Interval time.Duration `json:"Interval,omitempty"`
+	// Limit is the maximum number of executions. Zero means no limit.
+	Limit int `json:"Limit,omitempty"`
+	// Until stops the repetition once all the conditions are met.
+	Until []Condition `json:"Until,omitempty"`
+	// While continues the repetition as long as all the conditions are met.
+	While []Condition `json:"While,omitempty"`
+	// ExitCode stops the repetition when the step exits with one of the
+	// exit codes.
+	ExitCode []int `json:"ExitCode,omitempty"`
+	// Backoff is the factor the interval is multiplied by after each
+	// repetition. Values less than or equal to 1 keep the interval constant.
+	Backoff float64 `json:"Backoff,omitempty"`
+	// MaxInterval caps the interval growing with Backoff. Zero means no cap.
+	MaxInterval time.Duration `json:"MaxInterval,omitempty"`
+	// Cron is the cron expression of the repetitions. When it is set, the
+	// next repetition starts at the next time matching the expression
+	// instead of after Interval.
+	Cron string `json:"Cron,omitempty"`
+}
+
+// Delay returns the time to wait at now before the next repetition of a
+// step that has been executed done times.
+func (p RepeatPolicy) Delay(now time.Time, done int) time.Duration {
+	if p.Cron != "" {
+		if parsed, err := cronParser.Parse(p.Cron); err == nil {
+			return parsed.Next(now).Sub(now)
+		}
+	}
+
+	interval := p.Interval
+	if p.Backoff > 1 && done > 1 {
+		grown := float64(interval) * math.Pow(p.Backoff, float64(done-1))
+		interval = time.Duration(math.MaxInt64)
+		if grown < float64(math.MaxInt64) {
+			interval = time.Duration(grown)
+		}
+	}
+	if p.MaxInterval > 0 && interval > p.MaxInterval {
+		interval = p.MaxInterval
+	}
+	return interval
 }
 
 // ContinueOn contains the conditions to continue on failure or skipped.
@@ -0,0 +1,30 @@
+package digraph_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepeatPolicy_Delay(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 30, 0, time.UTC)
+
+	t.Run("Interval", func(t *testing.T) {
+		policy := digraph.RepeatPolicy{Interval: time.Second * 10}
+		require.Equal(t, time.Second*10, policy.Delay(now, 3))
+	})
+	t.Run("Backoff", func(t *testing.T) {
+		policy := digraph.RepeatPolicy{Interval: time.Second, Backoff: 2, MaxInterval: time.Second * 5}
+		require.Equal(t, time.Second, policy.Delay(now, 1))
+		require.Equal(t, time.Second*2, policy.Delay(now, 2))
+		require.Equal(t, time.Second*4, policy.Delay(now, 3))
+		require.Equal(t, time.Second*5, policy.Delay(now, 4))
+		require.Equal(t, time.Second*5, policy.Delay(now, 1000))
+	})
+	t.Run("Cron", func(t *testing.T) {
+		policy := digraph.RepeatPolicy{Interval: time.Hour, Cron: "*/5 * * * *"}
+		require.Equal(t, time.Second*30, policy.Delay(now, 1))
+	})
+}
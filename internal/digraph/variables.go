@@ -2,7 +2,6 @@ package digraph
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/dagu-org/dagu/internal/cmdutil"
 )
@@ -34,8 +33,10 @@ func loadVariables(ctx BuildContext, strVariables any) (
 		}
 	}
 
-	// Parse each key-value pair and set the environment variable.
+	// Parse each key-value pair. The values may refer to the variables
+	// defined before them, which are not set to the process environment.
 	vars := map[string]string{}
+	var envs []string
 	for _, pair := range pairs {
 		value := pair.val
 
@@ -44,17 +45,14 @@ func loadVariables(ctx BuildContext, strVariables any) (
 			// This also executes command substitution.
 			var err error
 
-			value, err = cmdutil.EvalString(ctx.ctx, value)
+			value, err = cmdutil.EvalString(ctx.ctx, value, cmdutil.WithEnv(envs))
 			if err != nil {
 				return nil, wrapError("env", pair.val, fmt.Errorf("%w: %s", ErrInvalidEnvValue, pair.val))
 			}
-
-			if err := os.Setenv(pair.key, value); err != nil {
-				return nil, wrapError("env", pair.key, err)
-			}
 		}
 
 		vars[pair.key] = value
+		envs = append(envs, pair.key+"="+value)
 	}
 	return vars, nil
 }
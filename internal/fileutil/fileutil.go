@@ -67,6 +67,29 @@ func createFile(file string) (*os.File, error) {
 	return outfile, nil
 }
 
+// WriteFileAtomic writes the data to a temporary file in the directory of
+// the file and renames it, so that the readers never see a partially
+// written file. The directory is created if it does not exist.
+func WriteFileAtomic(file string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".tmp_*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
 // MustTempDir returns temporary directory.
 // This function is used only for testing.
 func MustTempDir(pattern string) string {
@@ -95,6 +95,24 @@ func Test_MustTempDir(t *testing.T) {
 	})
 }
 
+func TestWriteFileAtomic(t *testing.T) {
+	t.Run("CreateDirectoryAndReplace", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "sub", "file.json")
+
+		require.NoError(t, WriteFileAtomic(file, []byte("first")))
+		require.NoError(t, WriteFileAtomic(file, []byte("second")))
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Equal(t, "second", string(data))
+
+		// No temporary file is left.
+		entries, err := os.ReadDir(filepath.Dir(file))
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+	})
+}
+
 func TestTruncString(t *testing.T) {
 	t.Run("Valid", func(t *testing.T) {
 		// Test empty string
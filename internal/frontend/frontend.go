@@ -1,19 +1,40 @@
 package frontend
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/dagu-org/dagu/internal/client"
 	"github.com/dagu-org/dagu/internal/config"
 	"github.com/dagu-org/dagu/internal/frontend/handlers"
 	"github.com/dagu-org/dagu/internal/frontend/server"
+	"github.com/dagu-org/dagu/internal/persistence/objstore"
+	"github.com/dagu-org/dagu/internal/persistence/sqlitedb"
+	"github.com/dagu-org/dagu/internal/queue"
+	"github.com/dagu-org/dagu/internal/scheduler/calendar"
+	"github.com/dagu-org/dagu/internal/scheduler/leader"
 )
 
-func New(cfg *config.Config, cli client.Client) *server.Server {
+func New(cfg *config.Config, cli client.Client, q *queue.Store, files *objstore.Files, historyDB *sqlitedb.DB) (*server.Server, error) {
 	var apiHandlers []server.Handler
 
-	dagAPIHandler := handlers.NewDAG(cli, cfg.UI.LogEncodingCharset, cfg.RemoteNodes, cfg.APIBaseURL)
+	calendars, err := calendar.NewRegistry(cfg.Calendars, cfg.Location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize calendars: %w", err)
+	}
+	location := cfg.Location
+	if location == nil {
+		location = time.Local
+	}
+
+	dagAPIHandler := handlers.NewDAG(cli, cfg.UI.LogEncodingCharset, cfg.RemoteNodes, cfg.APIBaseURL, calendars, location, q, files, historyDB)
 	apiHandlers = append(apiHandlers, dagAPIHandler)
 
-	systemAPIHandler := handlers.NewSystem()
+	var leaderBackend leader.Backend
+	if cfg.Scheduler.LeaderElection.Enabled {
+		leaderBackend = leader.NewFileBackend(cfg.Scheduler.LeaderElection.LeaseFile)
+	}
+	systemAPIHandler := handlers.NewSystem(leaderBackend, cfg.Scheduler.StatusFile)
 	apiHandlers = append(apiHandlers, systemAPIHandler)
 
 	pythonFilesHandler := handlers.NewPythonFiles()
@@ -53,5 +74,5 @@ func New(cfg *config.Config, cli client.Client) *server.Server {
 		}
 	}
 
-	return server.New(serverParams)
+	return server.New(serverParams), nil
 }
@@ -20,6 +20,9 @@ import (
 // swagger:model DAGStatusDetails
 type DAGStatusDetails struct {
 
+	// Runs of other DAGs triggered by this run.
+	Downstreams []*RunRef `json:"Downstreams"`
+
 	// Timestamp when the DAG finished.
 	// Required: true
 	FinishedAt *string `json:"FinishedAt"`
@@ -75,12 +78,19 @@ type DAGStatusDetails struct {
 	// status text
 	// Required: true
 	StatusText *string `json:"StatusText"`
+
+	// upstream
+	Upstream *RunRef `json:"Upstream,omitempty"`
 }
 
 // Validate validates this d a g status details
 func (m *DAGStatusDetails) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateDownstreams(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateFinishedAt(formats); err != nil {
 		res = append(res, err)
 	}
@@ -137,12 +147,42 @@ func (m *DAGStatusDetails) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateUpstream(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+func (m *DAGStatusDetails) validateDownstreams(formats strfmt.Registry) error {
+	if swag.IsZero(m.Downstreams) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Downstreams); i++ {
+		if swag.IsZero(m.Downstreams[i]) { // not required
+			continue
+		}
+
+		if m.Downstreams[i] != nil {
+			if err := m.Downstreams[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Downstreams" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Downstreams" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *DAGStatusDetails) validateFinishedAt(formats strfmt.Registry) error {
 
 	if err := validate.Required("FinishedAt", "body", m.FinishedAt); err != nil {
@@ -331,10 +371,33 @@ func (m *DAGStatusDetails) validateStatusText(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *DAGStatusDetails) validateUpstream(formats strfmt.Registry) error {
+	if swag.IsZero(m.Upstream) { // not required
+		return nil
+	}
+
+	if m.Upstream != nil {
+		if err := m.Upstream.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("Upstream")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("Upstream")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // ContextValidate validate this d a g status details based on the context it is used
 func (m *DAGStatusDetails) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.contextValidateDownstreams(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateNodes(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -355,12 +418,41 @@ func (m *DAGStatusDetails) ContextValidate(ctx context.Context, formats strfmt.R
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateUpstream(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+func (m *DAGStatusDetails) contextValidateDownstreams(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Downstreams); i++ {
+
+		if m.Downstreams[i] != nil {
+
+			if swag.IsZero(m.Downstreams[i]) { // not required
+				return nil
+			}
+
+			if err := m.Downstreams[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Downstreams" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Downstreams" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *DAGStatusDetails) contextValidateNodes(ctx context.Context, formats strfmt.Registry) error {
 
 	for i := 0; i < len(m.Nodes); i++ {
@@ -454,6 +546,27 @@ func (m *DAGStatusDetails) contextValidateOnSuccess(ctx context.Context, formats
 	return nil
 }
 
+func (m *DAGStatusDetails) contextValidateUpstream(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Upstream != nil {
+
+		if swag.IsZero(m.Upstream) { // not required
+			return nil
+		}
+
+		if err := m.Upstream.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("Upstream")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("Upstream")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *DAGStatusDetails) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -35,6 +35,9 @@ type DAGStatusFile struct {
 	// Required: true
 	File *string `json:"File"`
 
+	// Time the DAG is scheduled to start next in RFC3339 format; empty if it is not scheduled or suspended.
+	NextRun string `json:"NextRun,omitempty"`
+
 	// status
 	// Required: true
 	Status *DAGStatus `json:"Status"`
@@ -20,6 +20,9 @@ import (
 // swagger:model HealthResponse
 type HealthResponse struct {
 
+	// leader
+	Leader *SchedulerLeader `json:"leader,omitempty"`
+
 	// Overall health status of the server
 	// Required: true
 	// Enum: ["healthy","unhealthy"]
@@ -42,6 +45,10 @@ type HealthResponse struct {
 func (m *HealthResponse) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateLeader(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateStatus(formats); err != nil {
 		res = append(res, err)
 	}
@@ -64,6 +71,25 @@ func (m *HealthResponse) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *HealthResponse) validateLeader(formats strfmt.Registry) error {
+	if swag.IsZero(m.Leader) { // not required
+		return nil
+	}
+
+	if m.Leader != nil {
+		if err := m.Leader.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("leader")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("leader")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 var healthResponseTypeStatusPropEnum []interface{}
 
 func init() {
@@ -134,8 +160,38 @@ func (m *HealthResponse) validateVersion(formats strfmt.Registry) error {
 	return nil
 }
 
-// ContextValidate validates this health response based on context it is used
+// ContextValidate validate this health response based on the context it is used
 func (m *HealthResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateLeader(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *HealthResponse) contextValidateLeader(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Leader != nil {
+
+		if swag.IsZero(m.Leader) { // not required
+			return nil
+		}
+
+		if err := m.Leader.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("leader")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("leader")
+			}
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -19,6 +19,12 @@ import (
 // swagger:model Node
 type Node struct {
 
+	// Comment given with the approval or rejection
+	ApprovalComment string `json:"ApprovalComment,omitempty"`
+
+	// User who approved or rejected the step
+	Approver string `json:"Approver,omitempty"`
+
 	// Number of successful completions for repeating steps
 	// Required: true
 	DoneCount *int64 `json:"DoneCount"`
@@ -20,11 +20,20 @@ import (
 // swagger:model PostDAGActionRequest
 type PostDAGActionRequest struct {
 
-	// Action to be performed on the DAG.
+	// Action to be performed on the DAG. cancel-step, skip-step, retry-step, set-max-active-runs, pause and resume change the running run.
 	// Required: true
-	// Enum: ["start","suspend","stop","retry","mark-success","mark-failed","save","rename"]
+	// Enum: ["start","suspend","stop","retry","mark-success","mark-failed","save","rename","rerun-from-step","rerun-steps","approve","reject","cancel-step","skip-step","retry-step","set-max-active-runs","pause","resume"]
 	Action *string `json:"action"`
 
+	// Identity of the user who approves or rejects the step.
+	Approver string `json:"approver,omitempty"`
+
+	// Comment recorded with the approval or rejection.
+	Comment string `json:"comment,omitempty"`
+
+	// Whether to run the steps downstream of the selected steps as well.
+	Downstream bool `json:"downstream,omitempty"`
+
 	// Additional parameters for the action.
 	Params string `json:"params,omitempty"`
 
@@ -34,7 +43,13 @@ type PostDAGActionRequest struct {
 	// Step name if the action targets a specific step.
 	Step string `json:"step,omitempty"`
 
-	// Optional extra value for the action.
+	// Names of the steps to run for a partial run. The other steps are not executed.
+	Steps []string `json:"steps"`
+
+	// Whether to suspend the running steps in place when the run is paused. Otherwise they run to completion.
+	SuspendSteps bool `json:"suspendSteps,omitempty"`
+
+	// Optional extra value for the action, e.g. the new maximum number of the steps running at the same time for set-max-active-runs.
 	Value string `json:"value,omitempty"`
 }
 
@@ -56,7 +71,7 @@ var postDAGActionRequestTypeActionPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["start","suspend","stop","retry","mark-success","mark-failed","save","rename"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["start","suspend","stop","retry","mark-success","mark-failed","save","rename","rerun-from-step","rerun-steps","approve","reject","cancel-step","skip-step","retry-step","set-max-active-runs","pause","resume"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -89,6 +104,36 @@ const (
 
 	// PostDAGActionRequestActionRename captures enum value "rename"
 	PostDAGActionRequestActionRename string = "rename"
+
+	// PostDAGActionRequestActionRerunDashFromDashStep captures enum value "rerun-from-step"
+	PostDAGActionRequestActionRerunDashFromDashStep string = "rerun-from-step"
+
+	// PostDAGActionRequestActionRerunDashSteps captures enum value "rerun-steps"
+	PostDAGActionRequestActionRerunDashSteps string = "rerun-steps"
+
+	// PostDAGActionRequestActionApprove captures enum value "approve"
+	PostDAGActionRequestActionApprove string = "approve"
+
+	// PostDAGActionRequestActionReject captures enum value "reject"
+	PostDAGActionRequestActionReject string = "reject"
+
+	// PostDAGActionRequestActionCancelDashStep captures enum value "cancel-step"
+	PostDAGActionRequestActionCancelDashStep string = "cancel-step"
+
+	// PostDAGActionRequestActionSkipDashStep captures enum value "skip-step"
+	PostDAGActionRequestActionSkipDashStep string = "skip-step"
+
+	// PostDAGActionRequestActionRetryDashStep captures enum value "retry-step"
+	PostDAGActionRequestActionRetryDashStep string = "retry-step"
+
+	// PostDAGActionRequestActionSetDashMaxDashActiveDashRuns captures enum value "set-max-active-runs"
+	PostDAGActionRequestActionSetDashMaxDashActiveDashRuns string = "set-max-active-runs"
+
+	// PostDAGActionRequestActionPause captures enum value "pause"
+	PostDAGActionRequestActionPause string = "pause"
+
+	// PostDAGActionRequestActionResume captures enum value "resume"
+	PostDAGActionRequestActionResume string = "resume"
 )
 
 // prop value enum
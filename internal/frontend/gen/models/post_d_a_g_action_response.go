@@ -19,6 +19,9 @@ type PostDAGActionResponse struct {
 
 	// New DAG ID, if the action resulted in a new DAG.
 	NewDagID string `json:"NewDagID,omitempty"`
+
+	// ID of the queued run, if the DAG was running and the start was queued.
+	QueuedRunID string `json:"QueuedRunID,omitempty"`
 }
 
 // Validate validates this post d a g action response
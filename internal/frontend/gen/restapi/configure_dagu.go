@@ -40,6 +40,7 @@ func configureAPI(api *operations.DaguAPI) http.Handler {
 	api.JSONConsumer = runtime.JSONConsumer()
 
 	api.JSONProducer = runtime.JSONProducer()
+	api.TextEventStreamProducer = runtime.TextProducer()
 
 	if api.DagsCreateDAGHandler == nil {
 		api.DagsCreateDAGHandler = dags.CreateDAGHandlerFunc(func(params dags.CreateDAGParams) middleware.Responder {
@@ -244,6 +244,167 @@ func init() {
         }
       }
     },
+    "/dags/{dagId}/logs/stream": {
+      "get": {
+        "description": "Streams the log of the step of the running DAG as server-sent events, one event per line. Without a step, the logs of all the steps are streamed, each line prefixed with the step name. The stream ends with an ` + "`" + `end` + "`" + ` event.",
+        "produces": [
+          "text/event-stream"
+        ],
+        "tags": [
+          "dags"
+        ],
+        "summary": "Stream the logs of the running DAG",
+        "operationId": "streamDAGLogs",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The name of the step. All the steps if omitted.",
+            "name": "step",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Stream the output of the steps until they finish.",
+            "name": "follow",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A stream of server-sent events.",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/queue": {
+      "get": {
+        "description": "Returns the starts of the DAG queued while it is running, in the order they run.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "List queued runs of a DAG",
+        "operationId": "listQueuedRuns",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/ListQueuedRunsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/queue/{runId}": {
+      "delete": {
+        "description": "Removes a queued start of the DAG from the queue.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "Cancel a queued run",
+        "operationId": "cancelQueuedRun",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The ID of the queued run.",
+            "name": "runId",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response."
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/schedule/next": {
+      "get": {
+        "description": "Returns the next scheduled starts, stops and restarts of the DAG in its timezone.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "List upcoming runs of a DAG",
+        "operationId": "listUpcomingRuns",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "default": 10,
+            "description": "Number of upcoming runs to return.",
+            "name": "count",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/ListUpcomingRunsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
     "/health": {
       "get": {
         "description": "Returns the health status of the server and its dependencies",
@@ -425,6 +586,135 @@ func init() {
         }
       }
     },
+    "/runs": {
+      "get": {
+        "description": "Returns the runs of all the DAGs matching the filters, the latest first.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "Query the run history",
+        "operationId": "listRuns",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "RFC 3339 time from which the runs started (inclusive).",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "RFC 3339 time until which the runs started (exclusive).",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "integer"
+            },
+            "collectionFormat": "multi",
+            "description": "Status codes of the runs. Repeat the parameter for multiple statuses.",
+            "name": "status",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Name of the DAG of the runs.",
+            "name": "name",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Tag of the DAGs of the runs.",
+            "name": "tag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Substring of the parameters of the runs.",
+            "name": "params",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Page number (for pagination).",
+            "name": "page",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Number of runs to return per page.",
+            "name": "limit",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/ListRunsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/scheduler/metrics": {
+      "get": {
+        "description": "Returns the metrics reported by the scheduler in the Prometheus text format, including the delay of the scheduled starts and the SLA misses",
+        "produces": [
+          "text/plain"
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Get the scheduler metrics",
+        "operationId": "getSchedulerMetrics",
+        "responses": {
+          "200": {
+            "description": "A successful response",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/scheduler/status": {
+      "get": {
+        "description": "Returns the status reported by the scheduler, including the DAG files that failed to load and are not scheduled",
+        "tags": [
+          "system"
+        ],
+        "summary": "Get the scheduler status",
+        "operationId": "getSchedulerStatus",
+        "responses": {
+          "200": {
+            "description": "A successful response",
+            "schema": {
+              "$ref": "#/definitions/SchedulerStatusResponse"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
     "/search": {
       "get": {
         "description": "Searches for DAGs based on a query string.",
@@ -669,6 +959,29 @@ func init() {
         }
       }
     },
+    "DAGLoadError": {
+      "description": "Error of loading a DAG file",
+      "type": "object",
+      "required": [
+        "file",
+        "error",
+        "at"
+      ],
+      "properties": {
+        "at": {
+          "description": "Time the file failed to load",
+          "type": "string"
+        },
+        "error": {
+          "description": "Error message",
+          "type": "string"
+        },
+        "file": {
+          "description": "Path of the file relative to the DAGs directory",
+          "type": "string"
+        }
+      }
+    },
     "DAGLogData": {
       "type": "object",
       "required": [
@@ -795,6 +1108,13 @@ func init() {
         "Params"
       ],
       "properties": {
+        "Downstreams": {
+          "description": "Runs of other DAGs triggered by this run.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RunRef"
+          }
+        },
         "FinishedAt": {
           "description": "Timestamp when the DAG finished.",
           "type": "string"
@@ -841,6 +1161,9 @@ func init() {
         },
         "StatusText": {
           "type": "string"
+        },
+        "Upstream": {
+          "$ref": "#/definitions/RunRef"
         }
       }
     },
@@ -867,6 +1190,10 @@ func init() {
         "File": {
           "type": "string"
         },
+        "NextRun": {
+          "description": "Time the DAG is scheduled to start next in RFC3339 format; empty if it is not scheduled or suspended.",
+          "type": "string"
+        },
         "Status": {
           "$ref": "#/definitions/DAGStatus"
         },
@@ -1020,6 +1347,9 @@ func init() {
         "timestamp"
       ],
       "properties": {
+        "leader": {
+          "$ref": "#/definitions/SchedulerLeader"
+        },
         "status": {
           "description": "Overall health status of the server",
           "type": "string",
@@ -1076,32 +1406,105 @@ func init() {
         }
       }
     },
-    "ListTagResponse": {
-      "description": "Response object for listing all tags",
+    "ListQueuedRunsResponse": {
+      "description": "Response object for listing the queued runs of a DAG.",
       "type": "object",
       "required": [
-        "Tags",
-        "Errors"
+        "Runs"
       ],
       "properties": {
-        "Errors": {
-          "description": "List of errors encountered during the request",
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "Tags": {
-          "description": "List of unique tags",
+        "Runs": {
+          "description": "Queued runs in the order they run.",
           "type": "array",
           "items": {
-            "type": "string"
+            "$ref": "#/definitions/QueuedRun"
           }
         }
       }
     },
-    "Node": {
-      "description": "Execution status of an individual step within a DAG",
+    "ListRunsResponse": {
+      "description": "Response object for querying the run history.",
+      "type": "object",
+      "required": [
+        "Runs",
+        "Total",
+        "PageCount"
+      ],
+      "properties": {
+        "PageCount": {
+          "description": "Total number of pages available.",
+          "type": "integer"
+        },
+        "Runs": {
+          "description": "Runs on the page, the latest first.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RunStatusFile"
+          }
+        },
+        "Total": {
+          "description": "Number of the runs matching the filters in all the pages.",
+          "type": "integer"
+        }
+      }
+    },
+    "ListTagResponse": {
+      "description": "Response object for listing all tags",
+      "type": "object",
+      "required": [
+        "Tags",
+        "Errors"
+      ],
+      "properties": {
+        "Errors": {
+          "description": "List of errors encountered during the request",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Tags": {
+          "description": "List of unique tags",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "ListUpcomingRunsResponse": {
+      "description": "Response object for listing the upcoming runs of a DAG.",
+      "type": "object",
+      "required": [
+        "Suspended",
+        "Timezone",
+        "Duration",
+        "Runs"
+      ],
+      "properties": {
+        "Duration": {
+          "description": "Duration of the last successful run in seconds, used to find the overlapping runs.",
+          "type": "integer"
+        },
+        "Runs": {
+          "description": "Upcoming runs in ascending order of time.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/UpcomingRun"
+          }
+        },
+        "Suspended": {
+          "description": "Whether the DAG is suspended. A suspended DAG has no upcoming runs.",
+          "type": "boolean"
+        },
+        "Timezone": {
+          "description": "Timezone of the scheduled times.",
+          "type": "string"
+        }
+      }
+    },
+    "Node": {
+      "description": "Execution status of an individual step within a DAG",
       "type": "object",
       "required": [
         "Step",
@@ -1115,6 +1518,14 @@ func init() {
         "StatusText"
       ],
       "properties": {
+        "ApprovalComment": {
+          "description": "Comment given with the approval or rejection",
+          "type": "string"
+        },
+        "Approver": {
+          "description": "User who approved or rejected the step",
+          "type": "string"
+        },
         "DoneCount": {
           "description": "Number of successful completions for repeating steps",
           "type": "integer"
@@ -1160,7 +1571,7 @@ func init() {
       ],
       "properties": {
         "action": {
-          "description": "Action to be performed on the DAG.",
+          "description": "Action to be performed on the DAG. cancel-step, skip-step, retry-step, set-max-active-runs, pause and resume change the running run.",
           "type": "string",
           "enum": [
             "start",
@@ -1170,9 +1581,31 @@ func init() {
             "mark-success",
             "mark-failed",
             "save",
-            "rename"
+            "rename",
+            "rerun-from-step",
+            "rerun-steps",
+            "approve",
+            "reject",
+            "cancel-step",
+            "skip-step",
+            "retry-step",
+            "set-max-active-runs",
+            "pause",
+            "resume"
           ]
         },
+        "approver": {
+          "description": "Identity of the user who approves or rejects the step.",
+          "type": "string"
+        },
+        "comment": {
+          "description": "Comment recorded with the approval or rejection.",
+          "type": "string"
+        },
+        "downstream": {
+          "description": "Whether to run the steps downstream of the selected steps as well.",
+          "type": "boolean"
+        },
         "params": {
           "description": "Additional parameters for the action.",
           "type": "string"
@@ -1185,8 +1618,19 @@ func init() {
           "description": "Step name if the action targets a specific step.",
           "type": "string"
         },
+        "steps": {
+          "description": "Names of the steps to run for a partial run. The other steps are not executed.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "suspendSteps": {
+          "description": "Whether to suspend the running steps in place when the run is paused. Otherwise they run to completion.",
+          "type": "boolean"
+        },
         "value": {
-          "description": "Optional extra value for the action.",
+          "description": "Optional extra value for the action, e.g. the new maximum number of the steps running at the same time for set-max-active-runs.",
           "type": "string"
         }
       }
@@ -1198,6 +1642,10 @@ func init() {
         "NewDagID": {
           "description": "New DAG ID, if the action resulted in a new DAG.",
           "type": "string"
+        },
+        "QueuedRunID": {
+          "description": "ID of the queued run, if the DAG was running and the start was queued.",
+          "type": "string"
         }
       }
     },
@@ -1233,6 +1681,48 @@ func init() {
         }
       }
     },
+    "QueuedRun": {
+      "description": "Start of a DAG queued while it is running.",
+      "type": "object",
+      "required": [
+        "ID",
+        "Trigger",
+        "EnqueuedAt"
+      ],
+      "properties": {
+        "EnqueuedAt": {
+          "description": "Time the run was queued.",
+          "type": "string"
+        },
+        "ID": {
+          "description": "ID of the queued run.",
+          "type": "string"
+        },
+        "LogicalDate": {
+          "description": "Scheduled time of the run started by the schedule.",
+          "type": "string"
+        },
+        "Params": {
+          "description": "Parameters of the run.",
+          "type": "string"
+        },
+        "Steps": {
+          "description": "Steps to run. All the steps run if it is empty.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Trigger": {
+          "description": "What started the DAG.",
+          "type": "string",
+          "enum": [
+            "schedule",
+            "manual"
+          ]
+        }
+      }
+    },
     "RepeatPolicy": {
       "description": "Configuration for step retry behavior",
       "type": "object",
@@ -1247,6 +1737,82 @@ func init() {
         }
       }
     },
+    "RunRef": {
+      "description": "Reference to a run of a DAG",
+      "type": "object",
+      "required": [
+        "Name",
+        "RequestId"
+      ],
+      "properties": {
+        "Name": {
+          "description": "Name of the DAG",
+          "type": "string"
+        },
+        "RequestId": {
+          "description": "Request ID of the run",
+          "type": "string"
+        }
+      }
+    },
+    "RunStatusFile": {
+      "description": "Status of a run in the history.",
+      "type": "object",
+      "required": [
+        "File",
+        "Status"
+      ],
+      "properties": {
+        "File": {
+          "description": "Status file of the run, to read its logs with.",
+          "type": "string"
+        },
+        "Status": {
+          "$ref": "#/definitions/DAGStatus"
+        }
+      }
+    },
+    "SLAMiss": {
+      "description": "Miss of the SLA of a DAG",
+      "type": "object",
+      "required": [
+        "dag",
+        "sla",
+        "due",
+        "reason",
+        "at"
+      ],
+      "properties": {
+        "at": {
+          "description": "Time the miss was detected",
+          "type": "string"
+        },
+        "dag": {
+          "description": "Name of the DAG",
+          "type": "string"
+        },
+        "due": {
+          "description": "Time the run had to finish by",
+          "type": "string"
+        },
+        "reason": {
+          "description": "Description of the miss",
+          "type": "string"
+        },
+        "requestId": {
+          "description": "Request ID of the run that missed the SLA, if any",
+          "type": "string"
+        },
+        "sla": {
+          "description": "Kind of the SLA missed",
+          "type": "string",
+          "enum": [
+            "maxDuration",
+            "mustFinishBy"
+          ]
+        }
+      }
+    },
     "Schedule": {
       "type": "object",
       "required": [
@@ -1258,6 +1824,36 @@ func init() {
         }
       }
     },
+    "SchedulerLeader": {
+      "description": "Lease of the scheduler elected as the leader. Present only when the leader election is enabled.",
+      "type": "object",
+      "required": [
+        "id",
+        "token"
+      ],
+      "properties": {
+        "acquiredAt": {
+          "description": "Time the leader acquired the lease",
+          "type": "string"
+        },
+        "expiresAt": {
+          "description": "Time the lease expires unless it is renewed",
+          "type": "string"
+        },
+        "id": {
+          "description": "ID of the scheduler holding the lease; empty if no scheduler holds it",
+          "type": "string"
+        },
+        "renewedAt": {
+          "description": "Time the leader renewed the lease last",
+          "type": "string"
+        },
+        "token": {
+          "description": "Fencing token incremented every time the lease is acquired",
+          "type": "integer"
+        }
+      }
+    },
     "SchedulerLog": {
       "type": "object",
       "required": [
@@ -1273,6 +1869,40 @@ func init() {
         }
       }
     },
+    "SchedulerStatusResponse": {
+      "description": "Status reported by the scheduler when it loads or reloads the DAGs, starts a scheduled run or detects an SLA miss",
+      "type": "object",
+      "required": [
+        "updatedAt",
+        "dags",
+        "loadErrors",
+        "slaMisses"
+      ],
+      "properties": {
+        "dags": {
+          "description": "Number of the DAGs scheduled",
+          "type": "integer"
+        },
+        "loadErrors": {
+          "description": "DAG files that failed to load",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/DAGLoadError"
+          }
+        },
+        "slaMisses": {
+          "description": "Latest SLA misses of the DAGs, the newest first",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/SLAMiss"
+          }
+        },
+        "updatedAt": {
+          "description": "Time the scheduler updated the status last",
+          "type": "string"
+        }
+      }
+    },
     "SearchDAGsMatchItem": {
       "type": "object",
       "properties": {
@@ -1437,6 +2067,34 @@ func init() {
           "$ref": "#/definitions/Node"
         }
       }
+    },
+    "UpcomingRun": {
+      "description": "Scheduled start, stop or restart of a DAG.",
+      "type": "object",
+      "required": [
+        "Time",
+        "Type",
+        "Overlaps"
+      ],
+      "properties": {
+        "Overlaps": {
+          "description": "Whether the run starts while the previous run is expected to be running. The start is skipped, queued or stops the previous run by the concurrency policy of the DAG.",
+          "type": "boolean"
+        },
+        "Time": {
+          "description": "Scheduled time in RFC3339 format.",
+          "type": "string"
+        },
+        "Type": {
+          "description": "What the scheduler does at the time.",
+          "type": "string",
+          "enum": [
+            "start",
+            "stop",
+            "restart"
+          ]
+        }
+      }
     }
   },
   "tags": [
@@ -1681,6 +2339,167 @@ func init() {
         }
       }
     },
+    "/dags/{dagId}/logs/stream": {
+      "get": {
+        "description": "Streams the log of the step of the running DAG as server-sent events, one event per line. Without a step, the logs of all the steps are streamed, each line prefixed with the step name. The stream ends with an ` + "`" + `end` + "`" + ` event.",
+        "produces": [
+          "text/event-stream"
+        ],
+        "tags": [
+          "dags"
+        ],
+        "summary": "Stream the logs of the running DAG",
+        "operationId": "streamDAGLogs",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The name of the step. All the steps if omitted.",
+            "name": "step",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Stream the output of the steps until they finish.",
+            "name": "follow",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A stream of server-sent events.",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/queue": {
+      "get": {
+        "description": "Returns the starts of the DAG queued while it is running, in the order they run.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "List queued runs of a DAG",
+        "operationId": "listQueuedRuns",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/ListQueuedRunsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/queue/{runId}": {
+      "delete": {
+        "description": "Removes a queued start of the DAG from the queue.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "Cancel a queued run",
+        "operationId": "cancelQueuedRun",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The ID of the queued run.",
+            "name": "runId",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response."
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/schedule/next": {
+      "get": {
+        "description": "Returns the next scheduled starts, stops and restarts of the DAG in its timezone.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "List upcoming runs of a DAG",
+        "operationId": "listUpcomingRuns",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "default": 10,
+            "description": "Number of upcoming runs to return.",
+            "name": "count",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/ListUpcomingRunsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
     "/health": {
       "get": {
         "description": "Returns the health status of the server and its dependencies",
@@ -1812,19 +2631,123 @@ func init() {
             "required": true
           },
           {
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/PythonFile"
-            }
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/PythonFile"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/PythonFile"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "tags": [
+          "python_files"
+        ],
+        "summary": "Delete a Python file",
+        "operationId": "deletePythonFile",
+        "parameters": [
+          {
+            "type": "string",
+            "name": "name",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Deleted"
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/runs": {
+      "get": {
+        "description": "Returns the runs of all the DAGs matching the filters, the latest first.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "Query the run history",
+        "operationId": "listRuns",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "RFC 3339 time from which the runs started (inclusive).",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "RFC 3339 time until which the runs started (exclusive).",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "integer"
+            },
+            "collectionFormat": "multi",
+            "description": "Status codes of the runs. Repeat the parameter for multiple statuses.",
+            "name": "status",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Name of the DAG of the runs.",
+            "name": "name",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Tag of the DAGs of the runs.",
+            "name": "tag",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Substring of the parameters of the runs.",
+            "name": "params",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Page number (for pagination).",
+            "name": "page",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Number of runs to return per page.",
+            "name": "limit",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
             "description": "A successful response.",
             "schema": {
-              "$ref": "#/definitions/PythonFile"
+              "$ref": "#/definitions/ListRunsResponse"
             }
           },
           "default": {
@@ -1834,27 +2757,52 @@ func init() {
             }
           }
         }
-      },
-      "delete": {
+      }
+    },
+    "/scheduler/metrics": {
+      "get": {
+        "description": "Returns the metrics reported by the scheduler in the Prometheus text format, including the delay of the scheduled starts and the SLA misses",
+        "produces": [
+          "text/plain"
+        ],
         "tags": [
-          "python_files"
+          "system"
         ],
-        "summary": "Delete a Python file",
-        "operationId": "deletePythonFile",
-        "parameters": [
-          {
-            "type": "string",
-            "name": "name",
-            "in": "path",
-            "required": true
+        "summary": "Get the scheduler metrics",
+        "operationId": "getSchedulerMetrics",
+        "responses": {
+          "200": {
+            "description": "A successful response",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
           }
+        }
+      }
+    },
+    "/scheduler/status": {
+      "get": {
+        "description": "Returns the status reported by the scheduler, including the DAG files that failed to load and are not scheduled",
+        "tags": [
+          "system"
         ],
+        "summary": "Get the scheduler status",
+        "operationId": "getSchedulerStatus",
         "responses": {
-          "204": {
-            "description": "Deleted"
+          "200": {
+            "description": "A successful response",
+            "schema": {
+              "$ref": "#/definitions/SchedulerStatusResponse"
+            }
           },
           "default": {
-            "description": "Generic error response.",
+            "description": "Unexpected error",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -2106,6 +3054,29 @@ func init() {
         }
       }
     },
+    "DAGLoadError": {
+      "description": "Error of loading a DAG file",
+      "type": "object",
+      "required": [
+        "file",
+        "error",
+        "at"
+      ],
+      "properties": {
+        "at": {
+          "description": "Time the file failed to load",
+          "type": "string"
+        },
+        "error": {
+          "description": "Error message",
+          "type": "string"
+        },
+        "file": {
+          "description": "Path of the file relative to the DAGs directory",
+          "type": "string"
+        }
+      }
+    },
     "DAGLogData": {
       "type": "object",
       "required": [
@@ -2232,6 +3203,13 @@ func init() {
         "Params"
       ],
       "properties": {
+        "Downstreams": {
+          "description": "Runs of other DAGs triggered by this run.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RunRef"
+          }
+        },
         "FinishedAt": {
           "description": "Timestamp when the DAG finished.",
           "type": "string"
@@ -2278,6 +3256,9 @@ func init() {
         },
         "StatusText": {
           "type": "string"
+        },
+        "Upstream": {
+          "$ref": "#/definitions/RunRef"
         }
       }
     },
@@ -2304,6 +3285,10 @@ func init() {
         "File": {
           "type": "string"
         },
+        "NextRun": {
+          "description": "Time the DAG is scheduled to start next in RFC3339 format; empty if it is not scheduled or suspended.",
+          "type": "string"
+        },
         "Status": {
           "$ref": "#/definitions/DAGStatus"
         },
@@ -2457,6 +3442,9 @@ func init() {
         "timestamp"
       ],
       "properties": {
+        "leader": {
+          "$ref": "#/definitions/SchedulerLeader"
+        },
         "status": {
           "description": "Overall health status of the server",
           "type": "string",
@@ -2513,6 +3501,48 @@ func init() {
         }
       }
     },
+    "ListQueuedRunsResponse": {
+      "description": "Response object for listing the queued runs of a DAG.",
+      "type": "object",
+      "required": [
+        "Runs"
+      ],
+      "properties": {
+        "Runs": {
+          "description": "Queued runs in the order they run.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/QueuedRun"
+          }
+        }
+      }
+    },
+    "ListRunsResponse": {
+      "description": "Response object for querying the run history.",
+      "type": "object",
+      "required": [
+        "Runs",
+        "Total",
+        "PageCount"
+      ],
+      "properties": {
+        "PageCount": {
+          "description": "Total number of pages available.",
+          "type": "integer"
+        },
+        "Runs": {
+          "description": "Runs on the page, the latest first.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RunStatusFile"
+          }
+        },
+        "Total": {
+          "description": "Number of the runs matching the filters in all the pages.",
+          "type": "integer"
+        }
+      }
+    },
     "ListTagResponse": {
       "description": "Response object for listing all tags",
       "type": "object",
@@ -2537,6 +3567,37 @@ func init() {
         }
       }
     },
+    "ListUpcomingRunsResponse": {
+      "description": "Response object for listing the upcoming runs of a DAG.",
+      "type": "object",
+      "required": [
+        "Suspended",
+        "Timezone",
+        "Duration",
+        "Runs"
+      ],
+      "properties": {
+        "Duration": {
+          "description": "Duration of the last successful run in seconds, used to find the overlapping runs.",
+          "type": "integer"
+        },
+        "Runs": {
+          "description": "Upcoming runs in ascending order of time.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/UpcomingRun"
+          }
+        },
+        "Suspended": {
+          "description": "Whether the DAG is suspended. A suspended DAG has no upcoming runs.",
+          "type": "boolean"
+        },
+        "Timezone": {
+          "description": "Timezone of the scheduled times.",
+          "type": "string"
+        }
+      }
+    },
     "Node": {
       "description": "Execution status of an individual step within a DAG",
       "type": "object",
@@ -2552,6 +3613,14 @@ func init() {
         "StatusText"
       ],
       "properties": {
+        "ApprovalComment": {
+          "description": "Comment given with the approval or rejection",
+          "type": "string"
+        },
+        "Approver": {
+          "description": "User who approved or rejected the step",
+          "type": "string"
+        },
         "DoneCount": {
           "description": "Number of successful completions for repeating steps",
           "type": "integer"
@@ -2597,7 +3666,7 @@ func init() {
       ],
       "properties": {
         "action": {
-          "description": "Action to be performed on the DAG.",
+          "description": "Action to be performed on the DAG. cancel-step, skip-step, retry-step, set-max-active-runs, pause and resume change the running run.",
           "type": "string",
           "enum": [
             "start",
@@ -2607,9 +3676,31 @@ func init() {
             "mark-success",
             "mark-failed",
             "save",
-            "rename"
+            "rename",
+            "rerun-from-step",
+            "rerun-steps",
+            "approve",
+            "reject",
+            "cancel-step",
+            "skip-step",
+            "retry-step",
+            "set-max-active-runs",
+            "pause",
+            "resume"
           ]
         },
+        "approver": {
+          "description": "Identity of the user who approves or rejects the step.",
+          "type": "string"
+        },
+        "comment": {
+          "description": "Comment recorded with the approval or rejection.",
+          "type": "string"
+        },
+        "downstream": {
+          "description": "Whether to run the steps downstream of the selected steps as well.",
+          "type": "boolean"
+        },
         "params": {
           "description": "Additional parameters for the action.",
           "type": "string"
@@ -2622,8 +3713,19 @@ func init() {
           "description": "Step name if the action targets a specific step.",
           "type": "string"
         },
+        "steps": {
+          "description": "Names of the steps to run for a partial run. The other steps are not executed.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "suspendSteps": {
+          "description": "Whether to suspend the running steps in place when the run is paused. Otherwise they run to completion.",
+          "type": "boolean"
+        },
         "value": {
-          "description": "Optional extra value for the action.",
+          "description": "Optional extra value for the action, e.g. the new maximum number of the steps running at the same time for set-max-active-runs.",
           "type": "string"
         }
       }
@@ -2635,6 +3737,10 @@ func init() {
         "NewDagID": {
           "description": "New DAG ID, if the action resulted in a new DAG.",
           "type": "string"
+        },
+        "QueuedRunID": {
+          "description": "ID of the queued run, if the DAG was running and the start was queued.",
+          "type": "string"
         }
       }
     },
@@ -2670,6 +3776,48 @@ func init() {
         }
       }
     },
+    "QueuedRun": {
+      "description": "Start of a DAG queued while it is running.",
+      "type": "object",
+      "required": [
+        "ID",
+        "Trigger",
+        "EnqueuedAt"
+      ],
+      "properties": {
+        "EnqueuedAt": {
+          "description": "Time the run was queued.",
+          "type": "string"
+        },
+        "ID": {
+          "description": "ID of the queued run.",
+          "type": "string"
+        },
+        "LogicalDate": {
+          "description": "Scheduled time of the run started by the schedule.",
+          "type": "string"
+        },
+        "Params": {
+          "description": "Parameters of the run.",
+          "type": "string"
+        },
+        "Steps": {
+          "description": "Steps to run. All the steps run if it is empty.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Trigger": {
+          "description": "What started the DAG.",
+          "type": "string",
+          "enum": [
+            "schedule",
+            "manual"
+          ]
+        }
+      }
+    },
     "RepeatPolicy": {
       "description": "Configuration for step retry behavior",
       "type": "object",
@@ -2684,6 +3832,82 @@ func init() {
         }
       }
     },
+    "RunRef": {
+      "description": "Reference to a run of a DAG",
+      "type": "object",
+      "required": [
+        "Name",
+        "RequestId"
+      ],
+      "properties": {
+        "Name": {
+          "description": "Name of the DAG",
+          "type": "string"
+        },
+        "RequestId": {
+          "description": "Request ID of the run",
+          "type": "string"
+        }
+      }
+    },
+    "RunStatusFile": {
+      "description": "Status of a run in the history.",
+      "type": "object",
+      "required": [
+        "File",
+        "Status"
+      ],
+      "properties": {
+        "File": {
+          "description": "Status file of the run, to read its logs with.",
+          "type": "string"
+        },
+        "Status": {
+          "$ref": "#/definitions/DAGStatus"
+        }
+      }
+    },
+    "SLAMiss": {
+      "description": "Miss of the SLA of a DAG",
+      "type": "object",
+      "required": [
+        "dag",
+        "sla",
+        "due",
+        "reason",
+        "at"
+      ],
+      "properties": {
+        "at": {
+          "description": "Time the miss was detected",
+          "type": "string"
+        },
+        "dag": {
+          "description": "Name of the DAG",
+          "type": "string"
+        },
+        "due": {
+          "description": "Time the run had to finish by",
+          "type": "string"
+        },
+        "reason": {
+          "description": "Description of the miss",
+          "type": "string"
+        },
+        "requestId": {
+          "description": "Request ID of the run that missed the SLA, if any",
+          "type": "string"
+        },
+        "sla": {
+          "description": "Kind of the SLA missed",
+          "type": "string",
+          "enum": [
+            "maxDuration",
+            "mustFinishBy"
+          ]
+        }
+      }
+    },
     "Schedule": {
       "type": "object",
       "required": [
@@ -2695,6 +3919,36 @@ func init() {
         }
       }
     },
+    "SchedulerLeader": {
+      "description": "Lease of the scheduler elected as the leader. Present only when the leader election is enabled.",
+      "type": "object",
+      "required": [
+        "id",
+        "token"
+      ],
+      "properties": {
+        "acquiredAt": {
+          "description": "Time the leader acquired the lease",
+          "type": "string"
+        },
+        "expiresAt": {
+          "description": "Time the lease expires unless it is renewed",
+          "type": "string"
+        },
+        "id": {
+          "description": "ID of the scheduler holding the lease; empty if no scheduler holds it",
+          "type": "string"
+        },
+        "renewedAt": {
+          "description": "Time the leader renewed the lease last",
+          "type": "string"
+        },
+        "token": {
+          "description": "Fencing token incremented every time the lease is acquired",
+          "type": "integer"
+        }
+      }
+    },
     "SchedulerLog": {
       "type": "object",
       "required": [
@@ -2710,6 +3964,40 @@ func init() {
         }
       }
     },
+    "SchedulerStatusResponse": {
+      "description": "Status reported by the scheduler when it loads or reloads the DAGs, starts a scheduled run or detects an SLA miss",
+      "type": "object",
+      "required": [
+        "updatedAt",
+        "dags",
+        "loadErrors",
+        "slaMisses"
+      ],
+      "properties": {
+        "dags": {
+          "description": "Number of the DAGs scheduled",
+          "type": "integer"
+        },
+        "loadErrors": {
+          "description": "DAG files that failed to load",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/DAGLoadError"
+          }
+        },
+        "slaMisses": {
+          "description": "Latest SLA misses of the DAGs, the newest first",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/SLAMiss"
+          }
+        },
+        "updatedAt": {
+          "description": "Time the scheduler updated the status last",
+          "type": "string"
+        }
+      }
+    },
     "SearchDAGsMatchItem": {
       "type": "object",
       "properties": {
@@ -2874,6 +4162,34 @@ func init() {
           "$ref": "#/definitions/Node"
         }
       }
+    },
+    "UpcomingRun": {
+      "description": "Scheduled start, stop or restart of a DAG.",
+      "type": "object",
+      "required": [
+        "Time",
+        "Type",
+        "Overlaps"
+      ],
+      "properties": {
+        "Overlaps": {
+          "description": "Whether the run starts while the previous run is expected to be running. The start is skipped, queued or stops the previous run by the concurrency policy of the DAG.",
+          "type": "boolean"
+        },
+        "Time": {
+          "description": "Scheduled time in RFC3339 format.",
+          "type": "string"
+        },
+        "Type": {
+          "description": "What the scheduler does at the time.",
+          "type": "string",
+          "enum": [
+            "start",
+            "stop",
+            "restart"
+          ]
+        }
+      }
     }
   },
   "tags": [
@@ -7,6 +7,7 @@ package operations
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 
@@ -45,7 +46,14 @@ func NewDaguAPI(spec *loads.Document) *DaguAPI {
 		JSONConsumer: runtime.JSONConsumer(),
 
 		JSONProducer: runtime.JSONProducer(),
+		TextEventStreamProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+			return errors.NotImplemented("textEventStream producer has not yet been implemented")
+		}),
+		TxtProducer: runtime.TextProducer(),
 
+		DagsCancelQueuedRunHandler: dags.CancelQueuedRunHandlerFunc(func(params dags.CancelQueuedRunParams) middleware.Responder {
+			return middleware.NotImplemented("operation dags.CancelQueuedRun has not yet been implemented")
+		}),
 		DagsCreateDAGHandler: dags.CreateDAGHandlerFunc(func(params dags.CreateDAGParams) middleware.Responder {
 			return middleware.NotImplemented("operation dags.CreateDAG has not yet been implemented")
 		}),
@@ -67,21 +75,39 @@ func NewDaguAPI(spec *loads.Document) *DaguAPI {
 		PythonFilesGetPythonFileHandler: python_files.GetPythonFileHandlerFunc(func(params python_files.GetPythonFileParams) middleware.Responder {
 			return middleware.NotImplemented("operation python_files.GetPythonFile has not yet been implemented")
 		}),
+		SystemGetSchedulerMetricsHandler: system.GetSchedulerMetricsHandlerFunc(func(params system.GetSchedulerMetricsParams) middleware.Responder {
+			return middleware.NotImplemented("operation system.GetSchedulerMetrics has not yet been implemented")
+		}),
+		SystemGetSchedulerStatusHandler: system.GetSchedulerStatusHandlerFunc(func(params system.GetSchedulerStatusParams) middleware.Responder {
+			return middleware.NotImplemented("operation system.GetSchedulerStatus has not yet been implemented")
+		}),
 		DagsListDAGsHandler: dags.ListDAGsHandlerFunc(func(params dags.ListDAGsParams) middleware.Responder {
 			return middleware.NotImplemented("operation dags.ListDAGs has not yet been implemented")
 		}),
 		PythonFilesListPythonFilesHandler: python_files.ListPythonFilesHandlerFunc(func(params python_files.ListPythonFilesParams) middleware.Responder {
 			return middleware.NotImplemented("operation python_files.ListPythonFiles has not yet been implemented")
 		}),
+		DagsListQueuedRunsHandler: dags.ListQueuedRunsHandlerFunc(func(params dags.ListQueuedRunsParams) middleware.Responder {
+			return middleware.NotImplemented("operation dags.ListQueuedRuns has not yet been implemented")
+		}),
+		DagsListRunsHandler: dags.ListRunsHandlerFunc(func(params dags.ListRunsParams) middleware.Responder {
+			return middleware.NotImplemented("operation dags.ListRuns has not yet been implemented")
+		}),
 		DagsListTagsHandler: dags.ListTagsHandlerFunc(func(params dags.ListTagsParams) middleware.Responder {
 			return middleware.NotImplemented("operation dags.ListTags has not yet been implemented")
 		}),
+		DagsListUpcomingRunsHandler: dags.ListUpcomingRunsHandlerFunc(func(params dags.ListUpcomingRunsParams) middleware.Responder {
+			return middleware.NotImplemented("operation dags.ListUpcomingRuns has not yet been implemented")
+		}),
 		DagsPostDAGActionHandler: dags.PostDAGActionHandlerFunc(func(params dags.PostDAGActionParams) middleware.Responder {
 			return middleware.NotImplemented("operation dags.PostDAGAction has not yet been implemented")
 		}),
 		DagsSearchDAGsHandler: dags.SearchDAGsHandlerFunc(func(params dags.SearchDAGsParams) middleware.Responder {
 			return middleware.NotImplemented("operation dags.SearchDAGs has not yet been implemented")
 		}),
+		DagsStreamDAGLogsHandler: dags.StreamDAGLogsHandlerFunc(func(params dags.StreamDAGLogsParams) middleware.Responder {
+			return middleware.NotImplemented("operation dags.StreamDAGLogs has not yet been implemented")
+		}),
 		PythonFilesUpdatePythonFileHandler: python_files.UpdatePythonFileHandlerFunc(func(params python_files.UpdatePythonFileParams) middleware.Responder {
 			return middleware.NotImplemented("operation python_files.UpdatePythonFile has not yet been implemented")
 		}),
@@ -121,7 +147,15 @@ type DaguAPI struct {
 	// JSONProducer registers a producer for the following mime types:
 	//   - application/json
 	JSONProducer runtime.Producer
-
+	// TextEventStreamProducer registers a producer for the following mime types:
+	//   - text/event-stream
+	TextEventStreamProducer runtime.Producer
+	// TxtProducer registers a producer for the following mime types:
+	//   - text/plain
+	TxtProducer runtime.Producer
+
+	// DagsCancelQueuedRunHandler sets the operation handler for the cancel queued run operation
+	DagsCancelQueuedRunHandler dags.CancelQueuedRunHandler
 	// DagsCreateDAGHandler sets the operation handler for the create d a g operation
 	DagsCreateDAGHandler dags.CreateDAGHandler
 	// PythonFilesCreatePythonFileHandler sets the operation handler for the create python file operation
@@ -136,16 +170,28 @@ type DaguAPI struct {
 	SystemGetHealthHandler system.GetHealthHandler
 	// PythonFilesGetPythonFileHandler sets the operation handler for the get python file operation
 	PythonFilesGetPythonFileHandler python_files.GetPythonFileHandler
+	// SystemGetSchedulerMetricsHandler sets the operation handler for the get scheduler metrics operation
+	SystemGetSchedulerMetricsHandler system.GetSchedulerMetricsHandler
+	// SystemGetSchedulerStatusHandler sets the operation handler for the get scheduler status operation
+	SystemGetSchedulerStatusHandler system.GetSchedulerStatusHandler
 	// DagsListDAGsHandler sets the operation handler for the list d a gs operation
 	DagsListDAGsHandler dags.ListDAGsHandler
 	// PythonFilesListPythonFilesHandler sets the operation handler for the list python files operation
 	PythonFilesListPythonFilesHandler python_files.ListPythonFilesHandler
+	// DagsListQueuedRunsHandler sets the operation handler for the list queued runs operation
+	DagsListQueuedRunsHandler dags.ListQueuedRunsHandler
+	// DagsListRunsHandler sets the operation handler for the list runs operation
+	DagsListRunsHandler dags.ListRunsHandler
 	// DagsListTagsHandler sets the operation handler for the list tags operation
 	DagsListTagsHandler dags.ListTagsHandler
+	// DagsListUpcomingRunsHandler sets the operation handler for the list upcoming runs operation
+	DagsListUpcomingRunsHandler dags.ListUpcomingRunsHandler
 	// DagsPostDAGActionHandler sets the operation handler for the post d a g action operation
 	DagsPostDAGActionHandler dags.PostDAGActionHandler
 	// DagsSearchDAGsHandler sets the operation handler for the search d a gs operation
 	DagsSearchDAGsHandler dags.SearchDAGsHandler
+	// DagsStreamDAGLogsHandler sets the operation handler for the stream d a g logs operation
+	DagsStreamDAGLogsHandler dags.StreamDAGLogsHandler
 	// PythonFilesUpdatePythonFileHandler sets the operation handler for the update python file operation
 	PythonFilesUpdatePythonFileHandler python_files.UpdatePythonFileHandler
 
@@ -224,7 +270,16 @@ func (o *DaguAPI) Validate() error {
 	if o.JSONProducer == nil {
 		unregistered = append(unregistered, "JSONProducer")
 	}
+	if o.TextEventStreamProducer == nil {
+		unregistered = append(unregistered, "TextEventStreamProducer")
+	}
+	if o.TxtProducer == nil {
+		unregistered = append(unregistered, "TxtProducer")
+	}
 
+	if o.DagsCancelQueuedRunHandler == nil {
+		unregistered = append(unregistered, "dags.CancelQueuedRunHandler")
+	}
 	if o.DagsCreateDAGHandler == nil {
 		unregistered = append(unregistered, "dags.CreateDAGHandler")
 	}
@@ -246,21 +301,39 @@ func (o *DaguAPI) Validate() error {
 	if o.PythonFilesGetPythonFileHandler == nil {
 		unregistered = append(unregistered, "python_files.GetPythonFileHandler")
 	}
+	if o.SystemGetSchedulerMetricsHandler == nil {
+		unregistered = append(unregistered, "system.GetSchedulerMetricsHandler")
+	}
+	if o.SystemGetSchedulerStatusHandler == nil {
+		unregistered = append(unregistered, "system.GetSchedulerStatusHandler")
+	}
 	if o.DagsListDAGsHandler == nil {
 		unregistered = append(unregistered, "dags.ListDAGsHandler")
 	}
 	if o.PythonFilesListPythonFilesHandler == nil {
 		unregistered = append(unregistered, "python_files.ListPythonFilesHandler")
 	}
+	if o.DagsListQueuedRunsHandler == nil {
+		unregistered = append(unregistered, "dags.ListQueuedRunsHandler")
+	}
+	if o.DagsListRunsHandler == nil {
+		unregistered = append(unregistered, "dags.ListRunsHandler")
+	}
 	if o.DagsListTagsHandler == nil {
 		unregistered = append(unregistered, "dags.ListTagsHandler")
 	}
+	if o.DagsListUpcomingRunsHandler == nil {
+		unregistered = append(unregistered, "dags.ListUpcomingRunsHandler")
+	}
 	if o.DagsPostDAGActionHandler == nil {
 		unregistered = append(unregistered, "dags.PostDAGActionHandler")
 	}
 	if o.DagsSearchDAGsHandler == nil {
 		unregistered = append(unregistered, "dags.SearchDAGsHandler")
 	}
+	if o.DagsStreamDAGLogsHandler == nil {
+		unregistered = append(unregistered, "dags.StreamDAGLogsHandler")
+	}
 	if o.PythonFilesUpdatePythonFileHandler == nil {
 		unregistered = append(unregistered, "python_files.UpdatePythonFileHandler")
 	}
@@ -312,6 +385,10 @@ func (o *DaguAPI) ProducersFor(mediaTypes []string) map[string]runtime.Producer
 		switch mt {
 		case "application/json":
 			result["application/json"] = o.JSONProducer
+		case "text/event-stream":
+			result["text/event-stream"] = o.TextEventStreamProducer
+		case "text/plain":
+			result["text/plain"] = o.TxtProducer
 		}
 
 		if p, ok := o.customProducers[mt]; ok {
@@ -352,6 +429,10 @@ func (o *DaguAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/dags/{dagId}/queue/{runId}"] = dags.NewCancelQueuedRun(o.context, o.DagsCancelQueuedRunHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -383,6 +464,14 @@ func (o *DaguAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/scheduler/metrics"] = system.NewGetSchedulerMetrics(o.context, o.SystemGetSchedulerMetricsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/scheduler/status"] = system.NewGetSchedulerStatus(o.context, o.SystemGetSchedulerStatusHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/dags"] = dags.NewListDAGs(o.context, o.DagsListDAGsHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -391,7 +480,19 @@ func (o *DaguAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/dags/{dagId}/queue"] = dags.NewListQueuedRuns(o.context, o.DagsListQueuedRunsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/runs"] = dags.NewListRuns(o.context, o.DagsListRunsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/tags"] = dags.NewListTags(o.context, o.DagsListTagsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/dags/{dagId}/schedule/next"] = dags.NewListUpcomingRuns(o.context, o.DagsListUpcomingRunsHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -400,6 +501,10 @@ func (o *DaguAPI) initHandlerCache() {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/search"] = dags.NewSearchDAGs(o.context, o.DagsSearchDAGsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/dags/{dagId}/logs/stream"] = dags.NewStreamDAGLogs(o.context, o.DagsStreamDAGLogsHandler)
 	if o.handlers["PUT"] == nil {
 		o.handlers["PUT"] = make(map[string]http.Handler)
 	}
@@ -41,6 +41,12 @@ func convertToStatusDetails(s model.Status) *models.DAGStatusDetails {
 	for _, n := range s.Nodes {
 		status.Nodes = append(status.Nodes, convertToNode(n))
 	}
+	if s.Upstream != nil {
+		status.Upstream = convertToRunRef(*s.Upstream)
+	}
+	for _, ref := range s.Downstreams {
+		status.Downstreams = append(status.Downstreams, convertToRunRef(ref))
+	}
 	if s.OnSuccess != nil {
 		status.OnSuccess = convertToNode(s.OnSuccess)
 	}
@@ -56,6 +62,13 @@ func convertToStatusDetails(s model.Status) *models.DAGStatusDetails {
 	return status
 }
 
+func convertToRunRef(ref model.RunRef) *models.RunRef {
+	return &models.RunRef{
+		Name:      swag.String(ref.Name),
+		RequestID: swag.String(ref.RequestID),
+	}
+}
+
 func convertToNode(node *model.Node) *models.Node {
 	return &models.Node{
 		DoneCount:  swag.Int64(int64(node.DoneCount)),
@@ -67,6 +80,9 @@ func convertToNode(node *model.Node) *models.Node {
 		Status:     swag.Int64(int64(node.Status)),
 		StatusText: swag.String(node.StatusText),
 		Step:       convertToStepObject(node.Step),
+
+		Approver:        node.Approver,
+		ApprovalComment: node.ApprovalComment,
 	}
 }
 
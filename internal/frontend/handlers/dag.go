@@ -1,14 +1,18 @@
 package handlers
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,8 +24,15 @@ import (
 	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations"
 	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/dags"
 	"github.com/dagu-org/dagu/internal/frontend/server"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence"
 	"github.com/dagu-org/dagu/internal/persistence/jsondb"
 	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/persistence/objstore"
+	"github.com/dagu-org/dagu/internal/persistence/sqlitedb"
+	"github.com/dagu-org/dagu/internal/queue"
+	schedule "github.com/dagu-org/dagu/internal/scheduler"
+	"github.com/dagu-org/dagu/internal/scheduler/calendar"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
@@ -47,6 +58,13 @@ type DAG struct {
 	logEncodingCharset string
 	remoteNodes        map[string]config.RemoteNode
 	apiBasePath        string
+	calendars          *calendar.Registry
+	location           *time.Location
+	queue              *queue.Store
+	files              *objstore.Files
+	// historyDB is the database of the history, or nil if the history is
+	// stored in the JSON files.
+	historyDB *sqlitedb.DB
 }
 
 func NewDAG(
@@ -54,6 +72,11 @@ func NewDAG(
 	logEncodingCharset string,
 	remoteNodeConfigs []config.RemoteNode,
 	apiBasePath string,
+	calendars *calendar.Registry,
+	location *time.Location,
+	q *queue.Store,
+	files *objstore.Files,
+	historyDB *sqlitedb.DB,
 ) server.Handler {
 	remoteNodes := make(map[string]config.RemoteNode)
 	for _, node := range remoteNodeConfigs {
@@ -64,6 +87,11 @@ func NewDAG(
 		logEncodingCharset: logEncodingCharset,
 		remoteNodes:        remoteNodes,
 		apiBasePath:        apiBasePath,
+		calendars:          calendars,
+		location:           location,
+		queue:              q,
+		files:              files,
+		historyDB:          historyDB,
 	}
 }
 
@@ -165,6 +193,72 @@ func (h *DAG) Configure(api *operations.DaguAPI) {
 			}
 			return dags.NewListTagsOK().WithPayload(tags)
 		})
+
+	api.DagsListUpcomingRunsHandler = dags.ListUpcomingRunsHandlerFunc(
+		func(params dags.ListUpcomingRunsParams) middleware.Responder {
+			if resp := h.handleRemoteNodeProxy(nil, params.HTTPRequest); resp != nil {
+				return resp
+			}
+			ctx := params.HTTPRequest.Context()
+			resp, err := h.listUpcomingRuns(ctx, params)
+			if err != nil {
+				return dags.NewListUpcomingRunsDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return dags.NewListUpcomingRunsOK().WithPayload(resp)
+		})
+
+	api.DagsListQueuedRunsHandler = dags.ListQueuedRunsHandlerFunc(
+		func(params dags.ListQueuedRunsParams) middleware.Responder {
+			if resp := h.handleRemoteNodeProxy(nil, params.HTTPRequest); resp != nil {
+				return resp
+			}
+			ctx := params.HTTPRequest.Context()
+			resp, err := h.listQueuedRuns(ctx, params)
+			if err != nil {
+				return dags.NewListQueuedRunsDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return dags.NewListQueuedRunsOK().WithPayload(resp)
+		})
+
+	api.DagsListRunsHandler = dags.ListRunsHandlerFunc(
+		func(params dags.ListRunsParams) middleware.Responder {
+			if resp := h.handleRemoteNodeProxy(nil, params.HTTPRequest); resp != nil {
+				return resp
+			}
+			ctx := params.HTTPRequest.Context()
+			resp, err := h.listRuns(ctx, params)
+			if err != nil {
+				return dags.NewListRunsDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return dags.NewListRunsOK().WithPayload(resp)
+		})
+
+	api.DagsCancelQueuedRunHandler = dags.CancelQueuedRunHandlerFunc(
+		func(params dags.CancelQueuedRunParams) middleware.Responder {
+			if resp := h.handleRemoteNodeProxy(nil, params.HTTPRequest); resp != nil {
+				return resp
+			}
+			ctx := params.HTTPRequest.Context()
+			if err := h.cancelQueuedRun(ctx, params); err != nil {
+				return dags.NewCancelQueuedRunDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return dags.NewCancelQueuedRunOK()
+		})
+
+	api.DagsStreamDAGLogsHandler = dags.StreamDAGLogsHandlerFunc(
+		func(params dags.StreamDAGLogsParams) middleware.Responder {
+			ctx := params.HTTPRequest.Context()
+			stream, err := h.openLogStream(ctx, params)
+			if err != nil {
+				return dags.NewStreamDAGLogsDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return logStreamResponder(ctx, stream)
+		})
 }
 
 // handleRemoteNodeProxy checks if 'remoteNode' is present in the query parameters.
@@ -403,12 +497,211 @@ func (h *DAG) getList(ctx context.Context, params dags.ListDAGsParams) (*models.
 			item.Error = swag.String(dagStatus.Error.Error())
 		}
 
+		if !dagStatus.Suspended && dagStatus.DAG != nil {
+			if calendars, err := h.calendars.Resolve(dagStatus.DAG.ExcludeCalendars); err == nil {
+				if next := schedule.NextStart(dagStatus.DAG, calendars, time.Now().In(h.location)); !next.IsZero() {
+					item.NextRun = next.Format(time.RFC3339)
+				}
+			}
+		}
+
 		resp.DAGs = append(resp.DAGs, item)
 	}
 
 	return resp, nil
 }
 
+func (h *DAG) listUpcomingRuns(
+	ctx context.Context, params dags.ListUpcomingRunsParams,
+) (*models.ListUpcomingRunsResponse, *codedError) {
+	dagStatus, err := h.client.GetStatus(ctx, params.DagID)
+	if err != nil {
+		return nil, newNotFoundError(err)
+	}
+	dag := dagStatus.DAG
+
+	calendars, err := h.calendars.Resolve(dag.ExcludeCalendars)
+	if err != nil {
+		return nil, newBadRequestError(err)
+	}
+
+	count := int(swag.Int64Value(params.Count))
+	upcoming := schedule.PreviewRuns(ctx, h.client, dag, calendars, time.Now().In(h.location), count)
+
+	timezone := h.location.String()
+	if dag.Timezone != "" {
+		timezone = dag.Timezone
+	}
+	resp := &models.ListUpcomingRunsResponse{
+		Suspended: swag.Bool(upcoming.Suspended),
+		Timezone:  swag.String(timezone),
+		Duration:  swag.Int64(int64(upcoming.Duration.Seconds())),
+		Runs:      []*models.UpcomingRun{},
+	}
+	for _, run := range upcoming.Runs {
+		resp.Runs = append(resp.Runs, &models.UpcomingRun{
+			Time:     swag.String(run.Time.Format(time.RFC3339)),
+			Type:     swag.String(strings.ToLower(run.Type.String())),
+			Overlaps: swag.Bool(run.Overlaps),
+		})
+	}
+	return resp, nil
+}
+
+func (h *DAG) listQueuedRuns(
+	ctx context.Context, params dags.ListQueuedRunsParams,
+) (*models.ListQueuedRunsResponse, *codedError) {
+	dagStatus, err := h.client.GetStatus(ctx, params.DagID)
+	if err != nil {
+		return nil, newNotFoundError(err)
+	}
+
+	items, err := h.queue.List(ctx, queueName(dagStatus.DAG))
+	if err != nil {
+		return nil, newInternalError(err)
+	}
+	resp := &models.ListQueuedRunsResponse{Runs: []*models.QueuedRun{}}
+	for _, item := range items {
+		run := &models.QueuedRun{
+			ID:         swag.String(item.ID),
+			Trigger:    swag.String(string(item.Trigger)),
+			Params:     item.Params,
+			Steps:      item.Steps,
+			EnqueuedAt: swag.String(item.EnqueuedAt.Format(time.RFC3339)),
+		}
+		if !item.LogicalDate.IsZero() {
+			run.LogicalDate = item.LogicalDate.Format(time.RFC3339)
+		}
+		resp.Runs = append(resp.Runs, run)
+	}
+	return resp, nil
+}
+
+func (h *DAG) listRuns(ctx context.Context, params dags.ListRunsParams) (*models.ListRunsResponse, *codedError) {
+	query := client.HistoryQuery{
+		HistoryQuery: persistence.HistoryQuery{
+			Name:   fromPtr(params.Name),
+			Params: fromPtr(params.Params),
+			Page:   1,
+			Limit:  100,
+		},
+		Tag: fromPtr(params.Tag),
+	}
+	if params.Page != nil {
+		query.Page = int(*params.Page)
+	}
+	if params.Limit != nil {
+		query.Limit = int(*params.Limit)
+	}
+	if query.Page < 1 || query.Limit < 1 {
+		return nil, newBadRequestError(fmt.Errorf("invalid page %d or limit %d", query.Page, query.Limit))
+	}
+	for _, status := range params.Status {
+		query.Statuses = append(query.Statuses, scheduler.Status(status))
+	}
+	var err error
+	if params.From != nil {
+		if query.From, err = time.Parse(time.RFC3339, *params.From); err != nil {
+			return nil, newBadRequestError(fmt.Errorf("invalid from: %w", err))
+		}
+	}
+	if params.To != nil {
+		if query.To, err = time.Parse(time.RFC3339, *params.To); err != nil {
+			return nil, newBadRequestError(fmt.Errorf("invalid to: %w", err))
+		}
+	}
+
+	result, err := h.client.QueryHistory(ctx, query)
+	if err != nil {
+		return nil, newInternalError(err)
+	}
+
+	resp := &models.ListRunsResponse{
+		Runs:      []*models.RunStatusFile{},
+		Total:     swag.Int64(int64(result.Total)),
+		PageCount: swag.Int64(int64((result.Total-1)/query.Limit + 1)),
+	}
+	for _, run := range result.Runs {
+		s := run.Status
+		resp.Runs = append(resp.Runs, &models.RunStatusFile{
+			File: swag.String(run.File),
+			Status: &models.DAGStatus{
+				Log:        swag.String(s.Log),
+				Name:       swag.String(s.Name),
+				Params:     swag.String(s.Params),
+				Pid:        swag.Int64(int64(s.PID)),
+				RequestID:  swag.String(s.RequestID),
+				StartedAt:  swag.String(s.StartedAt),
+				FinishedAt: swag.String(s.FinishedAt),
+				Status:     swag.Int64(int64(s.Status)),
+				StatusText: swag.String(s.StatusText),
+			},
+		})
+	}
+	return resp, nil
+}
+
+func (h *DAG) cancelQueuedRun(ctx context.Context, params dags.CancelQueuedRunParams) *codedError {
+	dagStatus, err := h.client.GetStatus(ctx, params.DagID)
+	if err != nil {
+		return newNotFoundError(err)
+	}
+
+	if err := h.queue.Cancel(ctx, queueName(dagStatus.DAG), params.RunID); err != nil {
+		if errors.Is(err, queue.ErrItemNotFound) {
+			return newNotFoundError(err)
+		}
+		return newInternalError(err)
+	}
+	return nil
+}
+
+// startOnConflict starts the DAG while it is running by its concurrency
+// policy: the start is queued, the running run is stopped first, or the
+// start is rejected.
+func (h *DAG) startOnConflict(
+	ctx context.Context, dagID string, dag *digraph.DAG, opts client.StartOptions,
+) (*models.PostDAGActionResponse, *codedError) {
+	switch dag.Concurrency.OnConflict {
+	case digraph.OnConflictQueue:
+		item, err := h.queue.Enqueue(ctx, queue.Item{
+			DAG:        queueName(dag),
+			Trigger:    queue.TriggerManual,
+			Params:     opts.Params,
+			Steps:      opts.Steps,
+			Downstream: opts.Downstream,
+		})
+		if err != nil {
+			return nil, newInternalError(err)
+		}
+		return &models.PostDAGActionResponse{QueuedRunID: item.ID}, nil
+
+	case digraph.OnConflictCancelPrevious:
+		// The run outlives the request.
+		ctx := context.WithoutCancel(ctx)
+		go func() {
+			if err := schedule.StopRunning(ctx, h.client, dag); err != nil {
+				logger.Error(ctx, "Failed to stop the previous run", "dag", dagID, "err", err)
+				return
+			}
+			if err := h.client.Start(ctx, dag, opts); err != nil {
+				logger.Error(ctx, "DAG start operation failed", "err", err)
+			}
+		}()
+		return &models.PostDAGActionResponse{}, nil
+
+	default:
+		return nil, newBadRequestError(fmt.Errorf("the DAG %q is already running", dagID))
+
+	}
+}
+
+// queueName returns the name of the queue of the DAG, which is the name of
+// the DAG file without the extension.
+func queueName(dag *digraph.DAG) string {
+	return strings.TrimSuffix(filepath.Base(dag.Location), filepath.Ext(dag.Location))
+}
+
 func (h *DAG) getDetail(
 	ctx context.Context, params dags.GetDAGDetailsParams,
 ) (*models.GetDAGDetailsResponse, *codedError) {
@@ -533,7 +826,7 @@ func (h *DAG) processSchedulerLogRequest(
 	var logFile string
 
 	if params.File != nil {
-		status, err := jsondb.ParseStatusFile(*params.File)
+		status, err := h.parseStatusFile(ctx, *params.File)
 		if err != nil {
 			return nil, newBadRequestError(err)
 		}
@@ -548,7 +841,7 @@ func (h *DAG) processSchedulerLogRequest(
 		logFile = lastStatus.Log
 	}
 
-	content, err := readFileContent(logFile, nil)
+	content, err := h.readFileContent(ctx, logFile, nil)
 	if err != nil {
 		return nil, newInternalError(err)
 	}
@@ -574,7 +867,7 @@ func (h *DAG) processStepLogRequest(
 	}
 
 	if params.File != nil {
-		parsedStatus, err := jsondb.ParseStatusFile(*params.File)
+		parsedStatus, err := h.parseStatusFile(ctx, *params.File)
 		if err != nil {
 			return nil, newBadRequestError(err)
 		}
@@ -622,7 +915,7 @@ func (h *DAG) processStepLogRequest(
 		decoder = japanese.EUCJP.NewDecoder()
 	}
 
-	logContent, err := readFileContent(node.Log, decoder)
+	logContent, err := h.readFileContent(ctx, node.Log, decoder)
 	if err != nil {
 		return nil, newInternalError(err)
 	}
@@ -771,12 +1064,19 @@ func (h *DAG) postAction(
 
 	switch *params.Body.Action {
 	case "start":
-		if dagStatus.Status.Status == scheduler.StatusRunning {
-			return nil, newBadRequestError(fmt.Errorf("the DAG %q is already running", params.DagID))
+		opts := client.StartOptions{
+			Params:     params.Body.Params,
+			Steps:      params.Body.Steps,
+			Downstream: params.Body.Downstream,
 		}
-		h.client.StartAsync(ctx, dagStatus.DAG, client.StartOptions{
-			Params: params.Body.Params,
-		})
+		full, err := schedule.IsRunningMax(ctx, h.client, dagStatus.DAG)
+		if err != nil {
+			return nil, newInternalError(err)
+		}
+		if full {
+			return h.startOnConflict(ctx, params.DagID, dagStatus.DAG, opts)
+		}
+		h.client.StartAsync(ctx, dagStatus.DAG, opts)
 		return &models.PostDAGActionResponse{}, nil
 
 	case "suspend":
@@ -784,7 +1084,7 @@ func (h *DAG) postAction(
 		return &models.PostDAGActionResponse{}, nil
 
 	case "stop":
-		if dagStatus.Status.Status != scheduler.StatusRunning {
+		if !dagStatus.Status.Status.IsActive() {
 			return nil, newBadRequestError(
 				fmt.Errorf("the DAG %q is not running", params.DagID),
 			)
@@ -802,13 +1102,80 @@ func (h *DAG) postAction(
 				fmt.Errorf("request-id is required"),
 			)
 		}
-		if err := h.client.Retry(ctx, dagStatus.DAG, params.Body.RequestID); err != nil {
+		if err := h.client.Retry(ctx, dagStatus.DAG, params.Body.RequestID, client.RetryOptions{
+			Steps:      params.Body.Steps,
+			Downstream: params.Body.Downstream,
+		}); err != nil {
 			return nil, newInternalError(
 				fmt.Errorf("error trying to retry the DAG: %w", err),
 			)
 		}
 		return &models.PostDAGActionResponse{}, nil
 
+	case "rerun-from-step":
+		if params.Body.Step == "" {
+			return nil, newBadRequestError(fmt.Errorf("step name is required"))
+		}
+		return h.processRerun(ctx, params, dagStatus, []string{params.Body.Step}, true)
+
+	case "rerun-steps":
+		if len(params.Body.Steps) == 0 {
+			return nil, newBadRequestError(fmt.Errorf("steps are required"))
+		}
+		return h.processRerun(ctx, params, dagStatus, params.Body.Steps, params.Body.Downstream)
+
+	case "approve":
+		return h.processApproval(ctx, params, dagStatus, true)
+
+	case "reject":
+		return h.processApproval(ctx, params, dagStatus, false)
+
+	case "cancel-step":
+		return h.processStepControl(ctx, params, dagStatus, h.client.CancelStep)
+
+	case "skip-step":
+		return h.processStepControl(ctx, params, dagStatus, h.client.SkipStep)
+
+	case "retry-step":
+		return h.processStepControl(ctx, params, dagStatus, h.client.RetryStep)
+
+	case "set-max-active-runs":
+		maxActiveRuns, err := strconv.Atoi(params.Body.Value)
+		if err != nil {
+			return nil, newBadRequestError(fmt.Errorf("the value (max active runs) must be a number"))
+		}
+		if !dagStatus.Status.Status.IsActive() {
+			return nil, newBadRequestError(
+				fmt.Errorf("the DAG %q is not running", params.DagID),
+			)
+		}
+		if err := h.client.SetMaxActiveRuns(ctx, dagStatus.DAG, maxActiveRuns); err != nil {
+			return nil, newBadRequestError(err)
+		}
+		return &models.PostDAGActionResponse{}, nil
+
+	case "pause":
+		if !dagStatus.Status.Status.IsActive() {
+			return nil, newBadRequestError(
+				fmt.Errorf("the DAG %q is not running", params.DagID),
+			)
+		}
+		if err := h.client.Pause(ctx, dagStatus.DAG, params.Body.SuspendSteps); err != nil {
+			return nil, newBadRequestError(err)
+		}
+		return &models.PostDAGActionResponse{}, nil
+
+	case "resume":
+		if !dagStatus.Status.Status.IsActive() {
+			return nil, newBadRequestError(
+				fmt.Errorf("the DAG %q is not running", params.DagID),
+			)
+		}
+		if err := h.client.Resume(ctx, dagStatus.DAG); err != nil {
+			return nil, newBadRequestError(err)
+		}
+		return &models.PostDAGActionResponse{}, nil
+
 	case "mark-success":
 		return h.processUpdateStatus(ctx, params, dagStatus, scheduler.NodeStatusSuccess)
 
@@ -840,6 +1207,112 @@ func (h *DAG) postAction(
 	}
 }
 
+func (h *DAG) processApproval(
+	ctx context.Context,
+	params dags.PostDAGActionParams,
+	dagStatus client.DAGStatus, approved bool,
+) (*models.PostDAGActionResponse, *codedError) {
+	if params.Body.Step == "" {
+		return nil, newBadRequestError(fmt.Errorf("step name is required"))
+	}
+
+	if !dagStatus.Status.Status.IsActive() {
+		return nil, newBadRequestError(
+			fmt.Errorf("the DAG %q is not running", params.DagID),
+		)
+	}
+
+	approver := params.Body.Approver
+	if approver == "" && params.HTTPRequest != nil {
+		// use the user name of the basic authentication if any
+		if username, _, ok := params.HTTPRequest.BasicAuth(); ok {
+			approver = username
+		}
+	}
+
+	opts := client.ApprovalOptions{
+		Step:     params.Body.Step,
+		Approver: approver,
+		Comment:  params.Body.Comment,
+	}
+	decide := h.client.Approve
+	if !approved {
+		decide = h.client.Reject
+	}
+	if err := decide(ctx, dagStatus.DAG, opts); err != nil {
+		return nil, newBadRequestError(err)
+	}
+	return &models.PostDAGActionResponse{}, nil
+}
+
+// processStepControl applies the control to the step of the running run.
+func (h *DAG) processStepControl(
+	ctx context.Context,
+	params dags.PostDAGActionParams,
+	dagStatus client.DAGStatus,
+	control func(ctx context.Context, dag *digraph.DAG, step string) error,
+) (*models.PostDAGActionResponse, *codedError) {
+	if params.Body.Step == "" {
+		return nil, newBadRequestError(fmt.Errorf("step name is required"))
+	}
+
+	if !dagStatus.Status.Status.IsActive() {
+		return nil, newBadRequestError(
+			fmt.Errorf("the DAG %q is not running", params.DagID),
+		)
+	}
+
+	if err := control(ctx, dagStatus.DAG, params.Body.Step); err != nil {
+		return nil, newBadRequestError(err)
+	}
+	return &models.PostDAGActionResponse{}, nil
+}
+
+func (h *DAG) processRerun(
+	ctx context.Context,
+	params dags.PostDAGActionParams,
+	dagStatus client.DAGStatus, steps []string, downstream bool,
+) (*models.PostDAGActionResponse, *codedError) {
+	if params.Body.RequestID == "" {
+		return nil, newBadRequestError(fmt.Errorf("request-id is required"))
+	}
+
+	full, err := schedule.IsRunningMax(ctx, h.client, dagStatus.DAG)
+	if err != nil {
+		return nil, newInternalError(err)
+	}
+	if full {
+		return nil, newBadRequestError(
+			fmt.Errorf("the DAG %q is already running", params.DagID),
+		)
+	}
+
+	for _, name := range steps {
+		if !hasStep(dagStatus.DAG, name) {
+			return nil, newBadRequestError(fmt.Errorf("step %q not found", name))
+		}
+	}
+
+	if err := h.client.Retry(ctx, dagStatus.DAG, params.Body.RequestID, client.RetryOptions{
+		Steps:      steps,
+		Downstream: downstream,
+	}); err != nil {
+		return nil, newInternalError(
+			fmt.Errorf("error trying to re-run the DAG: %w", err),
+		)
+	}
+	return &models.PostDAGActionResponse{}, nil
+}
+
+func hasStep(dag *digraph.DAG, name string) bool {
+	for _, step := range dag.Steps {
+		if step.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *DAG) processUpdateStatus(
 	ctx context.Context,
 	params dags.PostDAGActionParams,
@@ -854,7 +1327,7 @@ func (h *DAG) processUpdateStatus(
 	}
 
 	// Do not allow updating the status if the DAG is still running.
-	if dagStatus.Status.Status == scheduler.StatusRunning {
+	if dagStatus.Status.Status.IsActive() {
 		return nil, newBadRequestError(
 			fmt.Errorf("the DAG %q is still running", dagStatus.DAG.Name),
 		)
@@ -927,19 +1400,35 @@ func (h *DAG) searchDAGs(ctx context.Context, params dags.SearchDAGsParams) (
 	}, nil
 }
 
-func readFileContent(f string, decoder *encoding.Decoder) ([]byte, error) {
-	if decoder == nil {
-		return os.ReadFile(f)
+// parseStatusFile reads the status of the run from the file of the status
+// stored by either of the history backends. The files of the JSON backend
+// may have been moved to the remote storage. The runs in SQLite are only
+// read from the database of the history.
+func (h *DAG) parseStatusFile(ctx context.Context, file string) (*model.Status, error) {
+	if sqlitedb.IsStatusFile(file) {
+		if h.historyDB == nil {
+			return nil, fmt.Errorf("the history is not stored in SQLite: %s", file)
+		}
+		return h.historyDB.ReadStatusFile(ctx, file)
+	}
+	data, err := h.files.ReadFile(ctx, file)
+	if err != nil {
+		return nil, err
 	}
+	return jsondb.ReadStatus(bytes.NewReader(data))
+}
 
-	r, err := os.Open(f)
+// readFileContent reads the log file either locally or from the remote
+// storage.
+func (h *DAG) readFileContent(ctx context.Context, f string, decoder *encoding.Decoder) ([]byte, error) {
+	data, err := h.files.ReadFile(ctx, f)
 	if err != nil {
 		return nil, fmt.Errorf("error reading %s: %w", f, err)
 	}
-	defer func() {
-		_ = r.Close()
-	}()
-	tr := transform.NewReader(r, decoder)
+	if decoder == nil {
+		return data, nil
+	}
+	tr := transform.NewReader(bytes.NewReader(data), decoder)
 	ret, err := io.ReadAll(tr)
 	return ret, err
 }
@@ -962,3 +1451,64 @@ func fromPtr[T any](v *T) T {
 	}
 	return *v
 }
+
+// openLogStream opens the stream of the logs from the agent of the running
+// DAG.
+func (h *DAG) openLogStream(
+	ctx context.Context, params dags.StreamDAGLogsParams,
+) (io.ReadCloser, *codedError) {
+	dagStatus, err := h.client.GetStatus(ctx, params.DagID)
+	if err != nil {
+		return nil, newBadRequestError(err)
+	}
+	if !dagStatus.Status.Status.IsActive() {
+		return nil, newBadRequestError(
+			fmt.Errorf("the DAG %q is not running", params.DagID),
+		)
+	}
+
+	stream, err := h.client.StreamLog(ctx, dagStatus.DAG, fromPtr(params.Step), fromPtr(params.Follow))
+	if err != nil {
+		if errors.Is(err, client.ErrDAGNotRunning) {
+			return nil, newBadRequestError(
+				fmt.Errorf("the DAG %q is not running", params.DagID),
+			)
+		}
+		return nil, newBadRequestError(err)
+	}
+	return stream, nil
+}
+
+// logStreamResponder sends the lines of the stream as server-sent events.
+// The "end" event is sent when the stream ends.
+func logStreamResponder(ctx context.Context, stream io.ReadCloser) middleware.Responder {
+	return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+		defer stream.Close()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+
+		rc := http.NewResponseController(w)
+		reader := bufio.NewReader(stream)
+		for {
+			line, err := reader.ReadString('\n')
+			if line != "" {
+				line = strings.TrimRight(line, "\r\n")
+				if _, err := fmt.Fprintf(w, "data: %s\n\n", line); err != nil {
+					return
+				}
+				_ = rc.Flush()
+			}
+			if err != nil {
+				if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+					logger.Error(ctx, "Failed to read the log stream", "err", err)
+				}
+				break
+			}
+		}
+		_, _ = io.WriteString(w, "event: end\ndata: \n\n")
+		_ = rc.Flush()
+	})
+}
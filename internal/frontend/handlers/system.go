@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"bytes"
+	"errors"
+	"io/fs"
 	"net/http"
 	"time"
 
@@ -10,6 +13,8 @@ import (
 	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/system"
 	"github.com/dagu-org/dagu/internal/frontend/metrics"
 	"github.com/dagu-org/dagu/internal/frontend/server"
+	schedule "github.com/dagu-org/dagu/internal/scheduler"
+	"github.com/dagu-org/dagu/internal/scheduler/leader"
 	"github.com/dagu-org/dagu/internal/stringutil"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
@@ -18,7 +23,10 @@ import (
 var _ server.Handler = (*System)(nil)
 
 // System is a handler for system related operations.
-type System struct{}
+type System struct {
+	leaderBackend leader.Backend
+	statusFile    string
+}
 
 // Configure implements server.Handler.
 func (s *System) Configure(api *operations.DaguAPI) {
@@ -29,17 +37,115 @@ func (s *System) Configure(api *operations.DaguAPI) {
 		}
 		return system.NewGetHealthOK().WithPayload(resp)
 	})
+
+	api.SystemGetSchedulerStatusHandler = system.GetSchedulerStatusHandlerFunc(func(params system.GetSchedulerStatusParams) middleware.Responder {
+		resp, err := s.getSchedulerStatus()
+		if err != nil {
+			return system.NewGetSchedulerStatusDefault(err.HTTPCode).WithPayload(err.APIError)
+		}
+		return system.NewGetSchedulerStatusOK().WithPayload(resp)
+	})
+
+	api.SystemGetSchedulerMetricsHandler = system.GetSchedulerMetricsHandlerFunc(func(params system.GetSchedulerMetricsParams) middleware.Responder {
+		resp, err := s.getSchedulerMetrics()
+		if err != nil {
+			return system.NewGetSchedulerMetricsDefault(err.HTTPCode).WithPayload(err.APIError)
+		}
+		return system.NewGetSchedulerMetricsOK().WithPayload(resp)
+	})
 }
 
-func NewSystem() server.Handler {
-	return &System{}
+// NewSystem creates a new System handler. The leader backend is the backend
+// of the scheduler leader election, or nil if it is disabled. The status file
+// is the file the scheduler reports its status to.
+func NewSystem(leaderBackend leader.Backend, statusFile string) server.Handler {
+	return &System{leaderBackend: leaderBackend, statusFile: statusFile}
 }
 
-func (s *System) GetHealth(_ system.GetHealthParams) (*models.HealthResponse, error) {
-	return &models.HealthResponse{
+func (s *System) GetHealth(params system.GetHealthParams) (*models.HealthResponse, error) {
+	resp := &models.HealthResponse{
 		Status:    swag.String(models.HealthResponseStatusHealthy),
 		Version:   &build.Version,
 		Uptime:    swag.Int64(metrics.GetUptime()),
 		Timestamp: swag.String(stringutil.FormatTime(time.Now())),
-	}, nil
+	}
+	if s.leaderBackend != nil {
+		lease, err := s.leaderBackend.Get(params.HTTPRequest.Context())
+		if err != nil {
+			return nil, err
+		}
+		resp.Leader = convertToSchedulerLeader(lease)
+	}
+	return resp, nil
+}
+
+func (s *System) getSchedulerStatus() (*models.SchedulerStatusResponse, *codedError) {
+	status, cerr := s.readSchedulerStatus()
+	if cerr != nil {
+		return nil, cerr
+	}
+	resp := &models.SchedulerStatusResponse{
+		UpdatedAt:  swag.String(stringutil.FormatTime(status.UpdatedAt)),
+		Dags:       swag.Int64(int64(status.DAGs)),
+		LoadErrors: []*models.DAGLoadError{},
+		SLAMisses:  []*models.SLAMiss{},
+	}
+	for _, e := range status.LoadErrors {
+		resp.LoadErrors = append(resp.LoadErrors, &models.DAGLoadError{
+			File:  swag.String(e.File),
+			Error: swag.String(e.Error),
+			At:    swag.String(stringutil.FormatTime(e.At)),
+		})
+	}
+	for _, miss := range status.SLAMisses {
+		resp.SLAMisses = append(resp.SLAMisses, &models.SLAMiss{
+			Dag:       swag.String(miss.DAG),
+			SLA:       swag.String(string(miss.SLA)),
+			RequestID: miss.RequestID,
+			Due:       swag.String(stringutil.FormatTime(miss.Due)),
+			Reason:    swag.String(miss.Reason),
+			At:        swag.String(stringutil.FormatTime(miss.At)),
+		})
+	}
+	return resp, nil
+}
+
+func (s *System) getSchedulerMetrics() (string, *codedError) {
+	status, cerr := s.readSchedulerStatus()
+	if cerr != nil {
+		return "", cerr
+	}
+	var buf bytes.Buffer
+	if err := schedule.WriteMetrics(&buf, status); err != nil {
+		return "", newInternalError(err)
+	}
+	return buf.String(), nil
+}
+
+// readSchedulerStatus reads the status the scheduler reported last.
+func (s *System) readSchedulerStatus() (schedule.Status, *codedError) {
+	status, err := schedule.ReadStatus(s.statusFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return schedule.Status{}, newNotFoundError(errors.New("the scheduler has not reported its status"))
+	}
+	if err != nil {
+		return schedule.Status{}, newInternalError(err)
+	}
+	return status, nil
+}
+
+// convertToSchedulerLeader converts the lease of the scheduler leader. The
+// ID is empty if the lease is released or expired.
+func convertToSchedulerLeader(lease leader.Lease) *models.SchedulerLeader {
+	ret := &models.SchedulerLeader{
+		ID:    swag.String(""),
+		Token: swag.Int64(int64(lease.Token)),
+	}
+	if lease.Held(time.Now()) {
+		ret.ID = swag.String(lease.Holder)
+		ret.AcquiredAt = stringutil.FormatTime(lease.AcquiredAt)
+		ret.RenewedAt = stringutil.FormatTime(lease.RenewedAt)
+		ret.ExpiresAt = stringutil.FormatTime(lease.ExpiresAt)
+	}
+	return ret
 }
@@ -3,9 +3,12 @@ package persistence
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
 	"github.com/dagu-org/dagu/internal/persistence/grep"
 	"github.com/dagu-org/dagu/internal/persistence/model"
 )
@@ -27,6 +30,59 @@ type HistoryStore interface {
 	RemoveAll(ctx context.Context, key string) error
 	RemoveOld(ctx context.Context, key string, retentionDays int) error
 	Rename(ctx context.Context, oldKey, newKey string) error
+	// Query returns the runs of all the DAGs matching the query, the latest
+	// first.
+	Query(ctx context.Context, query HistoryQuery) (*HistoryQueryResult, error)
+}
+
+// HistoryQuery filters the runs in the history. The zero value of a field
+// does not filter the runs.
+type HistoryQuery struct {
+	// From and To are the range of the start times of the runs. To is
+	// exclusive.
+	From time.Time
+	To   time.Time
+	// Statuses are the statuses of the runs.
+	Statuses []scheduler.Status
+	// Name is the name of the DAG of the runs.
+	Name string
+	// Keys are the keys of the DAGs of the runs, e.g. the DAGs with a tag.
+	// If it is not nil, the runs of the other DAGs are excluded even if it
+	// is empty.
+	Keys []string
+	// Params is a substring of the parameters of the runs.
+	Params string
+	// Page is the page of the runs, starting at 1, and Limit is the number
+	// of the runs per page. All the runs are returned if Limit is 0.
+	Page  int
+	Limit int
+}
+
+// Offset returns the number of the runs before the page.
+func (q HistoryQuery) Offset() int {
+	if q.Page < 1 || q.Limit < 1 {
+		return 0
+	}
+	return (q.Page - 1) * q.Limit
+}
+
+// Match returns true if the status of the run matches the statuses, the
+// name and the parameters of the query.
+func (q HistoryQuery) Match(status *model.Status) bool {
+	if len(q.Statuses) > 0 && !slices.Contains(q.Statuses, status.Status) {
+		return false
+	}
+	if q.Name != "" && status.Name != q.Name {
+		return false
+	}
+	return q.Params == "" || strings.Contains(status.Params, q.Params)
+}
+
+// HistoryQueryResult is the page of the runs matching a query.
+type HistoryQueryResult struct {
+	Runs []model.StatusFile
+	// Total is the number of the runs matching the query in all the pages.
+	Total int
 }
 
 type DAGStore interface {
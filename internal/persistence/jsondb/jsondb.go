@@ -2,6 +2,7 @@ package jsondb
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 
 	// nolint: gosec
@@ -23,6 +24,7 @@ import (
 	"github.com/dagu-org/dagu/internal/persistence"
 	"github.com/dagu-org/dagu/internal/persistence/filecache"
 	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/persistence/objstore"
 	"github.com/dagu-org/dagu/internal/stringutil"
 )
 
@@ -51,11 +53,13 @@ const (
 
 var _ persistence.HistoryStore = (*JSONDB)(nil)
 
-// JSONDB manages DAGs status files in local storage.
+// JSONDB manages DAGs status files in local storage. The status files of
+// the finished runs are moved to the remote storage if it's configured.
 type JSONDB struct {
 	baseDir           string
 	latestStatusToday bool
 	fileCache         *filecache.Cache[*model.Status]
+	files             *objstore.Files
 	writer            *writer
 }
 
@@ -64,6 +68,7 @@ type Option func(*Options)
 type Options struct {
 	FileCache         *filecache.Cache[*model.Status]
 	LatestStatusToday bool
+	Files             *objstore.Files
 }
 
 func WithFileCache(cache *filecache.Cache[*model.Status]) Option {
@@ -78,6 +83,14 @@ func WithLatestStatusToday(latestStatusToday bool) Option {
 	}
 }
 
+// WithFiles sets the files to move the status files of the finished runs
+// to the remote storage.
+func WithFiles(files *objstore.Files) Option {
+	return func(o *Options) {
+		o.Files = files
+	}
+}
+
 // New creates a new JSONDB instance.
 func New(baseDir string, opts ...Option) *JSONDB {
 	options := &Options{
@@ -90,6 +103,7 @@ func New(baseDir string, opts ...Option) *JSONDB {
 		baseDir:           baseDir,
 		latestStatusToday: options.LatestStatusToday,
 		fileCache:         options.FileCache,
+		files:             options.Files,
 	}
 }
 
@@ -99,13 +113,16 @@ func (db *JSONDB) Update(ctx context.Context, key, requestID string, status mode
 		return err
 	}
 
+	// The status is appended to the status file, so the file moved to the
+	// remote storage is downloaded to be uploaded again with the status.
+	if err := db.files.Download(ctx, statusFile.File); err != nil {
+		return err
+	}
+
 	writer := newWriter(statusFile.File)
 	if err := writer.open(); err != nil {
 		return err
 	}
-	defer func() {
-		_ = writer.close()
-	}()
 
 	if db.fileCache != nil {
 		defer func() {
@@ -113,7 +130,14 @@ func (db *JSONDB) Update(ctx context.Context, key, requestID string, status mode
 		}()
 	}
 
-	return writer.write(status)
+	if err := writer.write(status); err != nil {
+		_ = writer.close()
+		return err
+	}
+	if err := writer.close(); err != nil {
+		return err
+	}
+	return db.files.Upload(ctx, statusFile.File)
 }
 
 func (db *JSONDB) Open(ctx context.Context, key string, timestamp time.Time, requestID string) error {
@@ -154,15 +178,20 @@ func (db *JSONDB) Close(ctx context.Context) error {
 	if db.fileCache != nil {
 		db.fileCache.Invalidate(db.writer.target)
 	}
-	return db.writer.close()
+	if err := db.writer.close(); err != nil {
+		return err
+	}
+
+	// The status file is kept locally only while the run is running.
+	return db.files.Upload(ctx, db.writer.target)
 }
 
-func (db *JSONDB) ReadStatusRecent(_ context.Context, key string, itemLimit int) []model.StatusFile {
+func (db *JSONDB) ReadStatusRecent(ctx context.Context, key string, itemLimit int) []model.StatusFile {
 	var ret []model.StatusFile
 
-	files := db.getLatestMatches(db.globPattern(key), itemLimit)
+	files := db.getLatestMatches(ctx, db.globPattern(key), itemLimit)
 	for _, file := range files {
-		status, err := db.parseStatusFile(file)
+		status, err := db.parseStatusFile(ctx, file)
 		if err != nil {
 			continue
 		}
@@ -175,27 +204,27 @@ func (db *JSONDB) ReadStatusRecent(_ context.Context, key string, itemLimit int)
 	return ret
 }
 
-func (db *JSONDB) ReadStatusToday(_ context.Context, key string) (*model.Status, error) {
-	file, err := db.latestToday(key, time.Now(), db.latestStatusToday)
+func (db *JSONDB) ReadStatusToday(ctx context.Context, key string) (*model.Status, error) {
+	file, err := db.latestToday(ctx, key, time.Now(), db.latestStatusToday)
 	if err != nil {
 		return nil, err
 	}
-	return db.parseStatusFile(file)
+	return db.parseStatusFile(ctx, file)
 }
 
-func (db *JSONDB) FindByRequestID(_ context.Context, key string, requestID string) (*model.StatusFile, error) {
+func (db *JSONDB) FindByRequestID(ctx context.Context, key string, requestID string) (*model.StatusFile, error) {
 	if requestID == "" {
 		return nil, errRequestIDNotFound
 	}
 
-	matches, err := filepath.Glob(db.globPattern(key))
+	matches, err := db.files.Glob(ctx, db.globPattern(key))
 	if err != nil {
 		return nil, err
 	}
 
 	sort.Sort(sort.Reverse(sort.StringSlice(matches)))
 	for _, match := range matches {
-		status, err := ParseStatusFile(match)
+		status, err := db.readStatusFile(ctx, match)
 		if err != nil {
 			log.Printf("parsing failed %s : %s", match, err)
 			continue
@@ -215,12 +244,12 @@ func (db *JSONDB) RemoveAll(ctx context.Context, key string) error {
 	return db.RemoveOld(ctx, key, 0)
 }
 
-func (db *JSONDB) RemoveOld(_ context.Context, key string, retentionDays int) error {
+func (db *JSONDB) RemoveOld(ctx context.Context, key string, retentionDays int) error {
 	if retentionDays < 0 {
 		return nil
 	}
 
-	matches, err := filepath.Glob(db.globPattern(key))
+	matches, err := db.files.List(ctx, db.globPattern(key))
 	if err != nil {
 		return err
 	}
@@ -228,12 +257,8 @@ func (db *JSONDB) RemoveOld(_ context.Context, key string, retentionDays int) er
 	oldDate := time.Now().AddDate(0, 0, -retentionDays)
 	var lastErr error
 	for _, m := range matches {
-		info, err := os.Stat(m)
-		if err != nil {
-			continue
-		}
-		if info.ModTime().Before(oldDate) {
-			if err := os.Remove(m); err != nil {
+		if m.ModTime.Before(oldDate) {
+			if err := db.files.Remove(ctx, m.Path); err != nil {
 				lastErr = err
 			}
 		}
@@ -279,13 +304,13 @@ func (db *JSONDB) Compact(_ context.Context, targetFilePath string) error {
 	return nil
 }
 
-func (db *JSONDB) Rename(_ context.Context, oldKey, newKey string) error {
+func (db *JSONDB) Rename(ctx context.Context, oldKey, newKey string) error {
 	if !filepath.IsAbs(oldKey) || !filepath.IsAbs(newKey) {
 		return fmt.Errorf("invalid path: %s -> %s", oldKey, newKey)
 	}
 
 	oldDir := db.getDirectory(oldKey, getPrefix(oldKey))
-	if !db.exists(oldDir) {
+	if !db.exists(oldDir) && !db.files.Remote() {
 		return nil
 	}
 
@@ -296,7 +321,7 @@ func (db *JSONDB) Rename(_ context.Context, oldKey, newKey string) error {
 		}
 	}
 
-	matches, err := filepath.Glob(db.globPattern(oldKey))
+	matches, err := db.files.Glob(ctx, db.globPattern(oldKey))
 	if err != nil {
 		return err
 	}
@@ -306,7 +331,7 @@ func (db *JSONDB) Rename(_ context.Context, oldKey, newKey string) error {
 	for _, m := range matches {
 		base := filepath.Base(m)
 		f := strings.Replace(base, oldPrefix, newPrefix, 1)
-		if err := os.Rename(m, filepath.Join(newDir, f)); err != nil {
+		if err := db.files.Rename(ctx, m, filepath.Join(newDir, f)); err != nil {
 			log.Printf("failed to rename %s to %s: %s", m, f, err)
 		}
 	}
@@ -316,13 +341,87 @@ func (db *JSONDB) Rename(_ context.Context, oldKey, newKey string) error {
 	return nil
 }
 
-func (db *JSONDB) parseStatusFile(file string) (*model.Status, error) {
-	if db.fileCache != nil {
+// Query returns the runs matching the query. The start times of the runs are
+// read from the names of the files, so only the files of the runs started in
+// the range are parsed.
+func (db *JSONDB) Query(ctx context.Context, query persistence.HistoryQuery) (*persistence.HistoryQueryResult, error) {
+	var patterns []string
+	if query.Keys != nil {
+		for _, key := range query.Keys {
+			patterns = append(patterns, db.globPattern(key))
+		}
+	} else {
+		patterns = append(patterns, filepath.Join(db.baseDir, "*", "*"+extDat))
+	}
+
+	type run struct {
+		file      string
+		timestamp time.Time
+	}
+	var runs []run
+	for _, pattern := range patterns {
+		matches, err := db.files.Glob(ctx, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			timestamp, err := findTimestamp(match)
+			if err != nil {
+				continue
+			}
+			if !query.From.IsZero() && timestamp.Before(query.From) {
+				continue
+			}
+			if !query.To.IsZero() && !timestamp.Before(query.To) {
+				continue
+			}
+			runs = append(runs, run{file: match, timestamp: timestamp})
+		}
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].timestamp.After(runs[j].timestamp)
+	})
+
+	ret := &persistence.HistoryQueryResult{}
+	offset := query.Offset()
+	for _, r := range runs {
+		status, err := db.parseStatusFile(ctx, r.file)
+		if err != nil || !query.Match(status) {
+			continue
+		}
+		if ret.Total >= offset && (query.Limit < 1 || len(ret.Runs) < query.Limit) {
+			ret.Runs = append(ret.Runs, model.StatusFile{
+				File:   r.file,
+				Status: *status,
+			})
+		}
+		ret.Total++
+	}
+	return ret, nil
+}
+
+func (db *JSONDB) parseStatusFile(ctx context.Context, file string) (*model.Status, error) {
+	// The cache is only for the local files as it checks the modification
+	// time of the file.
+	if db.fileCache != nil && fileutil.FileExists(file) {
 		return db.fileCache.LoadLatest(file, func() (*model.Status, error) {
 			return ParseStatusFile(file)
 		})
 	}
-	return ParseStatusFile(file)
+	return db.readStatusFile(ctx, file)
+}
+
+// readStatusFile reads the status from the local file or from the remote
+// storage.
+func (db *JSONDB) readStatusFile(ctx context.Context, file string) (*model.Status, error) {
+	if !db.files.Remote() {
+		return ParseStatusFile(file)
+	}
+	data, err := db.files.ReadFile(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	return ReadStatus(bytes.NewReader(data))
 }
 
 func (db *JSONDB) getDirectory(key string, prefix string) string {
@@ -348,11 +447,11 @@ func (db *JSONDB) generateFilePath(key string, timestamp timeInUTC, requestID st
 	return fmt.Sprintf("%s.%s.%s.dat", prefix, timestampString, requestID), nil
 }
 
-func (db *JSONDB) latestToday(key string, day time.Time, latestStatusToday bool) (string, error) {
+func (db *JSONDB) latestToday(ctx context.Context, key string, day time.Time, latestStatusToday bool) (string, error) {
 	prefix := db.createPrefix(key)
 	pattern := fmt.Sprintf("%s.*.*.dat", prefix)
 
-	matches, err := filepath.Glob(pattern)
+	matches, err := db.files.Glob(ctx, pattern)
 	if err != nil || len(matches) == 0 {
 		return "", persistence.ErrNoStatusDataToday
 	}
@@ -377,8 +476,8 @@ func (db *JSONDB) latestToday(key string, day time.Time, latestStatusToday bool)
 	return ret[0], nil
 }
 
-func (s *JSONDB) getLatestMatches(pattern string, itemLimit int) []string {
-	matches, err := filepath.Glob(pattern)
+func (s *JSONDB) getLatestMatches(ctx context.Context, pattern string, itemLimit int) []string {
+	matches, err := s.files.Glob(ctx, pattern)
 	if err != nil || len(matches) == 0 {
 		return nil
 	}
@@ -408,21 +507,25 @@ func ParseStatusFile(filePath string) (*model.Status, error) {
 	}
 	defer f.Close()
 
-	var (
-		offset int64
-		result *model.Status
-	)
+	return ReadStatus(f)
+}
+
+// ReadStatus reads the latest status from the content of a status file. It
+// returns io.EOF if there is no status.
+func ReadStatus(r io.Reader) (*model.Status, error) {
+	reader := bufio.NewReader(r)
+
+	var result *model.Status
 	for {
-		line, err := readLineFrom(f, offset)
-		if err == io.EOF {
+		line, err := readLine(reader)
+		if err == io.EOF && len(line) == 0 {
 			if result == nil {
 				return nil, err
 			}
 			return result, nil
-		} else if err != nil {
+		} else if err != nil && err != io.EOF {
 			return nil, err
 		}
-		offset += int64(len(line)) + 1 // +1 for newline
 		if len(line) > 0 {
 			status, err := model.StatusFromJSON(string(line))
 			if err == nil {
@@ -469,11 +572,7 @@ func findTimestamp(file string) (time.Time, error) {
 	return t, nil
 }
 
-func readLineFrom(f *os.File, offset int64) ([]byte, error) {
-	if _, err := f.Seek(offset, io.SeekStart); err != nil {
-		return nil, err
-	}
-	reader := bufio.NewReader(f)
+func readLine(reader *bufio.Reader) ([]byte, error) {
 	var ret []byte
 	for {
 		line, isPrefix, err := reader.ReadLine()
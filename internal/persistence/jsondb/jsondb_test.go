@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
 	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/filecache"
 	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/persistence/objstore"
+	"github.com/dagu-org/dagu/internal/persistence/objstore/s3test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -354,3 +358,166 @@ func TestJSONDB_FileManagement(t *testing.T) {
 		assert.Less(t, info.Size(), sizeBeforeCompact)
 	})
 }
+
+func TestJSONDB_Query(t *testing.T) {
+	th := testSetup(t)
+
+	now := time.Now()
+	write := func(t *testing.T, dag dagTestHelper, requestID string, startedAt time.Time, st scheduler.Status, params string) {
+		t.Helper()
+
+		require.NoError(t, th.DB.Open(th.Context, dag.Location, startedAt, requestID))
+		status := model.NewStatusFactory(dag.DAG).Create(requestID, st, testPID, startedAt)
+		status.Params = params
+		require.NoError(t, th.DB.Write(th.Context, status))
+		require.NoError(t, th.DB.Close(th.Context))
+	}
+	dagA, dagB := th.DAG("test_query_a"), th.DAG("test_query_b")
+	write(t, dagA, "request-id-a1", now.Add(-3*time.Hour), scheduler.StatusSuccess, "env=prod")
+	write(t, dagA, "request-id-a2", now.Add(-2*time.Hour), scheduler.StatusError, "env=dev")
+	write(t, dagB, "request-id-b1", now.Add(-time.Hour), scheduler.StatusError, "env=prod")
+	write(t, dagB, "request-id-b2", now.Add(-48*time.Hour), scheduler.StatusError, "")
+
+	requestIDs := func(result *persistence.HistoryQueryResult) []string {
+		var ret []string
+		for _, run := range result.Runs {
+			ret = append(ret, run.Status.RequestID)
+		}
+		return ret
+	}
+
+	t.Run("All", func(t *testing.T) {
+		result, err := th.DB.Query(th.Context, persistence.HistoryQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Total)
+		assert.Equal(t, []string{"request-id-b1", "request-id-a2", "request-id-a1", "request-id-b2"}, requestIDs(result))
+	})
+
+	t.Run("StatusAndTimeRange", func(t *testing.T) {
+		result, err := th.DB.Query(th.Context, persistence.HistoryQuery{
+			From:     now.Add(-24 * time.Hour),
+			To:       now.Add(-90 * time.Minute),
+			Statuses: []scheduler.Status{scheduler.StatusError},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"request-id-a2"}, requestIDs(result))
+	})
+
+	t.Run("NameAndParams", func(t *testing.T) {
+		result, err := th.DB.Query(th.Context, persistence.HistoryQuery{Name: "test_query_a", Params: "prod"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"request-id-a1"}, requestIDs(result))
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		result, err := th.DB.Query(th.Context, persistence.HistoryQuery{Keys: []string{dagB.Location}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"request-id-b1", "request-id-b2"}, requestIDs(result))
+
+		result, err = th.DB.Query(th.Context, persistence.HistoryQuery{Keys: []string{}})
+		require.NoError(t, err)
+		assert.Zero(t, result.Total)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		result, err := th.DB.Query(th.Context, persistence.HistoryQuery{Page: 2, Limit: 3})
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Total)
+		assert.Equal(t, []string{"request-id-b2"}, requestIDs(result))
+	})
+}
+
+func TestJSONDB_RemoteStorage(t *testing.T) {
+	th := testSetup(t)
+	server := s3test.NewServer(t)
+	db := New(th.tmpDir,
+		WithFileCache(filecache.New[*model.Status](100, time.Hour)),
+		WithFiles(objstore.NewFiles(objstore.NewS3(server.Config()))),
+	)
+
+	writeRun := func(t *testing.T, dag dagTestHelper, requestID string, startedAt time.Time) {
+		t.Helper()
+
+		require.NoError(t, db.Open(th.Context, dag.Location, startedAt, requestID))
+		status := model.NewStatusFactory(dag.DAG).Create(requestID, scheduler.StatusSuccess, testPID, startedAt)
+		require.NoError(t, db.Write(th.Context, status))
+		require.NoError(t, db.Close(th.Context))
+	}
+
+	t.Run("ReadUploadedStatus", func(t *testing.T) {
+		dag := th.DAG("test_remote_read")
+		writeRun(t, dag, "request-id-remote", time.Now())
+
+		// The status file is moved to the remote storage.
+		statusFile, err := db.FindByRequestID(th.Context, dag.Location, "request-id-remote")
+		require.NoError(t, err)
+		assert.NoFileExists(t, statusFile.File)
+		assert.Len(t, server.Keys(), 1)
+
+		recent := db.ReadStatusRecent(th.Context, dag.Location, 10)
+		require.Len(t, recent, 1)
+		assert.Equal(t, "request-id-remote", recent[0].Status.RequestID)
+
+		status, err := db.ReadStatusToday(th.Context, dag.Location)
+		require.NoError(t, err)
+		assert.Equal(t, "request-id-remote", status.RequestID)
+
+		result, err := db.Query(th.Context, persistence.HistoryQuery{Name: "test_remote_read"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Total)
+	})
+
+	t.Run("UpdateUploadedStatus", func(t *testing.T) {
+		dag := th.DAG("test_remote_update")
+		writeRun(t, dag, "request-id-update", time.Now())
+
+		status := model.NewStatusFactory(dag.DAG).Create("request-id-update", scheduler.StatusError, testPID, time.Now())
+		require.NoError(t, db.Update(th.Context, dag.Location, "request-id-update", status))
+
+		statusFile, err := db.FindByRequestID(th.Context, dag.Location, "request-id-update")
+		require.NoError(t, err)
+		assert.Equal(t, scheduler.StatusError, statusFile.Status.Status)
+		assert.NoFileExists(t, statusFile.File)
+
+		// The status is appended to the uploaded status file.
+		data, err := db.files.ReadFile(th.Context, statusFile.File)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Len(t, lines, 2)
+		for i, expected := range []scheduler.Status{scheduler.StatusSuccess, scheduler.StatusError} {
+			status, err := model.StatusFromJSON(lines[i])
+			require.NoError(t, err)
+			assert.Equal(t, "request-id-update", status.RequestID)
+			assert.Equal(t, expected, status.Status)
+		}
+	})
+
+	t.Run("RenameAndRemove", func(t *testing.T) {
+		oldDAG, newDAG := th.DAG("test_remote_old"), th.DAG("test_remote_new")
+		writeRun(t, oldDAG, "request-id-old", time.Now().AddDate(0, 0, -10))
+		writeRun(t, oldDAG, "request-id-new", time.Now())
+
+		require.NoError(t, db.Rename(th.Context, oldDAG.Location, newDAG.Location))
+
+		// The old run was uploaded 10 days ago. It is changed before the
+		// runs are listed as the listings of the remote storage are reused.
+		prefix := strings.TrimPrefix(filepath.ToSlash(db.createPrefix(newDAG.Location)), "/")
+		var keys []string
+		for _, key := range server.Keys() {
+			if strings.HasPrefix(key, prefix) {
+				keys = append(keys, key)
+			}
+		}
+		require.Len(t, keys, 2)
+		server.SetLastModified(keys[0], time.Now().AddDate(0, 0, -10))
+
+		assert.Empty(t, db.ReadStatusRecent(th.Context, oldDAG.Location, 10))
+		recent := db.ReadStatusRecent(th.Context, newDAG.Location, 10)
+		require.Len(t, recent, 2)
+		require.NoError(t, db.RemoveOld(th.Context, newDAG.Location, 7))
+
+		recent = db.ReadStatusRecent(th.Context, newDAG.Location, 10)
+		require.Len(t, recent, 1)
+		assert.Equal(t, "request-id-new", recent[0].Status.RequestID)
+	})
+}
@@ -37,6 +37,10 @@ func FromNode(node scheduler.NodeData) *Node {
 		RetryCount: node.State.RetryCount,
 		DoneCount:  node.State.DoneCount,
 		Error:      errText(node.State.Error),
+
+		Approver:        node.State.Approver,
+		ApprovalComment: node.State.ApprovalComment,
+		Repetitions:     fromRepetitions(node.State.Repetitions),
 	}
 }
 
@@ -51,6 +55,46 @@ type Node struct {
 	DoneCount  int                  `json:"DoneCount,omitempty"`
 	Error      string               `json:"Error,omitempty"`
 	StatusText string               `json:"StatusText"`
+	// Approver is the user who approved or rejected the step.
+	Approver string `json:"Approver,omitempty"`
+	// ApprovalComment is the comment given with the approval or rejection.
+	ApprovalComment string `json:"ApprovalComment,omitempty"`
+	// Repetitions contains the latest executions of a step with a repeat
+	// policy.
+	Repetitions []Repetition `json:"Repetitions,omitempty"`
+}
+
+// Repetition is an execution of a step with a repeat policy.
+type Repetition struct {
+	StartedAt  string `json:"StartedAt"`
+	FinishedAt string `json:"FinishedAt"`
+	ExitCode   int    `json:"ExitCode"`
+}
+
+func fromRepetitions(repetitions []scheduler.Repetition) []Repetition {
+	var ret []Repetition
+	for _, r := range repetitions {
+		ret = append(ret, Repetition{
+			StartedAt:  stringutil.FormatTime(r.StartedAt),
+			FinishedAt: stringutil.FormatTime(r.FinishedAt),
+			ExitCode:   r.ExitCode,
+		})
+	}
+	return ret
+}
+
+func toRepetitions(repetitions []Repetition) []scheduler.Repetition {
+	var ret []scheduler.Repetition
+	for _, r := range repetitions {
+		startedAt, _ := stringutil.ParseTime(r.StartedAt)
+		finishedAt, _ := stringutil.ParseTime(r.FinishedAt)
+		ret = append(ret, scheduler.Repetition{
+			StartedAt:  startedAt,
+			FinishedAt: finishedAt,
+			ExitCode:   r.ExitCode,
+		})
+	}
+	return ret
 }
 
 func (n *Node) ToNode() *scheduler.Node {
@@ -66,6 +110,10 @@ func (n *Node) ToNode() *scheduler.Node {
 		RetryCount: n.RetryCount,
 		DoneCount:  n.DoneCount,
 		Error:      errFromText(n.Error),
+
+		Approver:        n.Approver,
+		ApprovalComment: n.ApprovalComment,
+		Repetitions:     toRepetitions(n.Repetitions),
 	})
 }
 
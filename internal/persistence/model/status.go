@@ -83,6 +83,38 @@ func WithOnCancelNode(node *scheduler.Node) StatusOption {
 	}
 }
 
+// WithUpstream sets the upstream run that triggered the run.
+func WithUpstream(upstream *RunRef) StatusOption {
+	return func(s *Status) {
+		s.Upstream = upstream
+	}
+}
+
+// WithLogicalDate sets the scheduled time the run is for.
+func WithLogicalDate(t time.Time) StatusOption {
+	return func(s *Status) {
+		if !t.IsZero() {
+			s.LogicalDate = stringutil.FormatTime(t)
+		}
+	}
+}
+
+// WithDownstreams sets the runs triggered by the run.
+func WithDownstreams(downstreams []RunRef) StatusOption {
+	return func(s *Status) {
+		s.Downstreams = downstreams
+	}
+}
+
+// WithAgentProcess sets the host and the start time of the process of the
+// agent of the run.
+func WithAgentProcess(hostname, procStartTime string) StatusOption {
+	return func(s *Status) {
+		s.Hostname = hostname
+		s.ProcStartTime = procStartTime
+	}
+}
+
 func WithLogFilePath(logFilePath string) StatusOption {
 	return func(s *Status) {
 		s.Log = logFilePath
@@ -144,10 +176,39 @@ type Status struct {
 	Log        string           `json:"Log"`
 	Params     string           `json:"Params,omitempty"`
 	ParamsList []string         `json:"ParamsList,omitempty"`
+	// Upstream is the run of another DAG that triggered this run.
+	Upstream *RunRef `json:"Upstream,omitempty"`
+	// Downstreams are the runs of other DAGs triggered by this run.
+	Downstreams []RunRef `json:"Downstreams,omitempty"`
+	// LogicalDate is the scheduled time the run is for. It is set for the
+	// caught-up and backfilled runs.
+	LogicalDate string `json:"LogicalDate,omitempty"`
+	// Hostname is the host the agent of the run runs on.
+	Hostname string `json:"Hostname,omitempty"`
+	// ProcStartTime identifies when the process of the agent started, so
+	// that it is not confused with another process reusing its PID. It is
+	// empty if it is unknown.
+	ProcStartTime string `json:"ProcStartTime,omitempty"`
+}
+
+// RunRef refers to a run of a DAG.
+type RunRef struct {
+	Name      string `json:"Name"`
+	RequestID string `json:"RequestId"`
+}
+
+// HasDownstream returns true if the run has triggered the run of the DAG.
+func (st *Status) HasDownstream(name string) bool {
+	for _, ref := range st.Downstreams {
+		if ref.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 func (st *Status) CorrectRunningStatus() {
-	if st.Status == scheduler.StatusRunning {
+	if st.Status.IsActive() {
 		st.Status = scheduler.StatusError
 		st.StatusText = st.Status.String()
 	}
@@ -0,0 +1,328 @@
+package objstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/fileutil"
+)
+
+// listingTTL is how long a listing of the remote store is reused. The files
+// changed through Files invalidate the listings including them, so the TTL
+// only delays the files uploaded or removed by the other hosts.
+const listingTTL = 30 * time.Second
+
+// Files gives access to the files written locally that are moved to the
+// remote store once they are complete. The local files are only a buffer
+// for the files being written; the files are read from the remote store
+// when they are not found locally. A file is stored under the key of its
+// absolute path, so the hosts sharing the store must use the same paths.
+//
+// A nil Files, or Files without a store, keeps the files local only.
+type Files struct {
+	store Store
+
+	mu       sync.Mutex
+	listings map[string]*listing
+}
+
+// listing is a listing of the objects under a prefix of the keys.
+type listing struct {
+	objects  []Object
+	keys     map[string]bool
+	listedAt time.Time
+	// local is the keys of the files found locally under the prefix when it
+	// was listed. A file not found locally any more was uploaded or removed
+	// after the listing, e.g. by the agent of a run in another process.
+	local map[string]bool
+}
+
+// NewFiles creates a new Files storing the files in the store.
+func NewFiles(store Store) *Files {
+	return &Files{store: store, listings: make(map[string]*listing)}
+}
+
+// FileInfo describes a file stored either locally or remotely.
+type FileInfo struct {
+	Path    string
+	ModTime time.Time
+	// Remote is true if the file is only in the remote store.
+	Remote bool
+}
+
+// Remote returns true if the files are moved to a remote store.
+func (f *Files) Remote() bool {
+	return f != nil && f.store != nil
+}
+
+// Upload moves the local file to the remote store. It does nothing if the
+// files are kept local.
+func (f *Files) Upload(ctx context.Context, file string) error {
+	if !f.Remote() {
+		return nil
+	}
+	defer f.invalidate(file)
+
+	r, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	info, err := r.Stat()
+	if err != nil {
+		_ = r.Close()
+		return err
+	}
+	err = f.store.Put(ctx, key(file), r, info.Size())
+	_ = r.Close()
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", file, err)
+	}
+	return os.Remove(file)
+}
+
+// Download copies the file from the remote store to the local file, so that
+// it can be appended to and uploaded again. It does nothing if the file is
+// local, or is not in the remote store.
+func (f *Files) Download(ctx context.Context, file string) error {
+	if !f.Remote() {
+		return nil
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		return err
+	}
+
+	r, err := f.store.Get(ctx, key(file))
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", file, err)
+	}
+	return fileutil.WriteFileAtomic(file, data)
+}
+
+// ReadFile reads the file from the local file or from the remote store.
+func (f *Files) ReadFile(ctx context.Context, file string) ([]byte, error) {
+	data, err := os.ReadFile(file)
+	if err == nil || !os.IsNotExist(err) || !f.Remote() {
+		return data, err
+	}
+
+	r, getErr := f.store.Get(ctx, key(file))
+	if errors.Is(getErr, ErrNotFound) {
+		return nil, err
+	}
+	if getErr != nil {
+		return nil, getErr
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// List returns the files matching the pattern of filepath.Match, ordered
+// by the paths. A file stored both locally and remotely is returned once.
+func (f *Files) List(ctx context.Context, pattern string) ([]FileInfo, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []FileInfo
+	local := make(map[string]bool, len(matches))
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil {
+			continue
+		}
+		local[match] = true
+		ret = append(ret, FileInfo{Path: match, ModTime: info.ModTime()})
+	}
+
+	if f.Remote() {
+		// List the objects under the part of the pattern without the meta
+		// characters.
+		absPattern, err := filepath.Abs(pattern)
+		if err != nil {
+			return nil, err
+		}
+		prefix := absPattern
+		if i := strings.IndexAny(prefix, `*?[\`); i >= 0 {
+			prefix = prefix[:i]
+		}
+		objects, err := f.listObjects(ctx, key(prefix), absPattern, local)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objects {
+			file := filepath.FromSlash("/" + obj.Key)
+			if ok, _ := filepath.Match(absPattern, file); !ok || local[file] {
+				continue
+			}
+			ret = append(ret, FileInfo{Path: file, ModTime: obj.LastModified, Remote: true})
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Path < ret[j].Path
+	})
+	return ret, nil
+}
+
+// listObjects lists the objects under the prefix, reusing the listing of the
+// prefix unless it is expired or one of the files matching the pattern that
+// were local when it was listed is neither local nor listed any more.
+func (f *Files) listObjects(ctx context.Context, prefix, pattern string, local map[string]bool) ([]Object, error) {
+	localKeys := make(map[string]bool, len(local))
+	for file := range local {
+		localKeys[key(file)] = true
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if l := f.listings[prefix]; l != nil && time.Since(l.listedAt) < listingTTL {
+		fresh := true
+		for k := range l.local {
+			if ok, _ := filepath.Match(pattern, filepath.FromSlash("/"+k)); ok && !localKeys[k] && !l.keys[k] {
+				fresh = false
+				break
+			}
+		}
+		if fresh {
+			for k := range localKeys {
+				l.local[k] = true
+			}
+			return l.objects, nil
+		}
+	}
+
+	objects, err := f.store.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool, len(objects))
+	for _, obj := range objects {
+		keys[obj.Key] = true
+	}
+	f.listings[prefix] = &listing{objects: objects, keys: keys, listedAt: time.Now(), local: localKeys}
+	return objects, nil
+}
+
+// invalidate drops the listings including the file.
+func (f *Files) invalidate(file string) {
+	k := key(file)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for prefix := range f.listings {
+		if strings.HasPrefix(k, prefix) {
+			delete(f.listings, prefix)
+		}
+	}
+}
+
+// Glob returns the paths of the files matching the pattern of
+// filepath.Match, ordered by the paths.
+func (f *Files) Glob(ctx context.Context, pattern string) ([]string, error) {
+	files, err := f.List(ctx, pattern)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]string, 0, len(files))
+	for _, file := range files {
+		ret = append(ret, file.Path)
+	}
+	return ret, nil
+}
+
+// ModTime returns the modification time of the file, which is the time it
+// was uploaded if the file is only in the remote store.
+func (f *Files) ModTime(ctx context.Context, file string) (time.Time, error) {
+	info, err := os.Stat(file)
+	if err == nil {
+		return info.ModTime(), nil
+	}
+	if !os.IsNotExist(err) || !f.Remote() {
+		return time.Time{}, err
+	}
+
+	obj, statErr := f.store.Stat(ctx, key(file))
+	if errors.Is(statErr, ErrNotFound) {
+		return time.Time{}, err
+	}
+	if statErr != nil {
+		return time.Time{}, statErr
+	}
+	return obj.LastModified, nil
+}
+
+// Remove removes the file both locally and from the remote store.
+func (f *Files) Remove(ctx context.Context, file string) error {
+	if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if !f.Remote() {
+		return nil
+	}
+	defer f.invalidate(file)
+	return f.store.Delete(ctx, key(file))
+}
+
+// Rename moves the file to the new path both locally and in the remote
+// store.
+func (f *Files) Rename(ctx context.Context, oldFile, newFile string) error {
+	if _, err := os.Stat(oldFile); err == nil {
+		if err := os.MkdirAll(filepath.Dir(newFile), 0755); err != nil {
+			return err
+		}
+		if err := os.Rename(oldFile, newFile); err != nil {
+			return err
+		}
+	}
+	if !f.Remote() {
+		return nil
+	}
+	defer f.invalidate(oldFile)
+	defer f.invalidate(newFile)
+
+	oldKey := key(oldFile)
+	obj, err := f.store.Stat(ctx, oldKey)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	r, err := f.store.Get(ctx, oldKey)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if err := f.store.Put(ctx, key(newFile), r, obj.Size); err != nil {
+		return err
+	}
+	return f.store.Delete(ctx, oldKey)
+}
+
+// key returns the key of the object of the file, the slash-separated
+// absolute path without the leading slash.
+func key(file string) string {
+	if abs, err := filepath.Abs(file); err == nil {
+		file = abs
+	}
+	return strings.TrimPrefix(filepath.ToSlash(file), "/")
+}
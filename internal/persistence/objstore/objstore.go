@@ -0,0 +1,37 @@
+// Package objstore stores the history and the log files of the runs in a
+// remote object storage, such as an S3 bucket, so that they outlive the
+// host the runs ran on.
+package objstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotFound is returned when the object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Store is a flat storage of objects addressed by slash-separated keys.
+type Store interface {
+	// Put stores the object read from r, replacing the existing one.
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Get returns the content of the object. The caller must close it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Stat returns the metadata of the object.
+	Stat(ctx context.Context, key string) (*Object, error)
+	// List returns the objects whose keys start with the prefix, ordered by
+	// the keys.
+	List(ctx context.Context, prefix string) ([]Object, error)
+	// Delete deletes the object. It does not fail if the object does not
+	// exist.
+	Delete(ctx context.Context, key string) error
+}
+
+// Object is the metadata of an object.
+type Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
@@ -0,0 +1,212 @@
+package objstore_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/persistence/objstore"
+	"github.com/dagu-org/dagu/internal/persistence/objstore/s3test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3(t *testing.T) {
+	ctx := context.Background()
+	server := s3test.NewServer(t)
+	cfg := server.Config()
+	cfg.Prefix = "prod"
+	store := objstore.NewS3(cfg)
+
+	put := func(t *testing.T, key, data string) {
+		t.Helper()
+		require.NoError(t, store.Put(ctx, key, strings.NewReader(data), int64(len(data))))
+	}
+
+	t.Run("PutAndGet", func(t *testing.T) {
+		put(t, "logs/dag/step 1.20250101.00:00:00.000.log", "hello")
+		assert.Contains(t, server.Keys(), "prod/logs/dag/step 1.20250101.00:00:00.000.log")
+
+		r, err := store.Get(ctx, "logs/dag/step 1.20250101.00:00:00.000.log")
+		require.NoError(t, err)
+		defer r.Close()
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+
+		obj, err := store.Stat(ctx, "logs/dag/step 1.20250101.00:00:00.000.log")
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), obj.Size)
+		assert.WithinDuration(t, time.Now(), obj.LastModified, time.Minute)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := store.Get(ctx, "not-found")
+		assert.ErrorIs(t, err, objstore.ErrNotFound)
+		_, err = store.Stat(ctx, "not-found")
+		assert.ErrorIs(t, err, objstore.ErrNotFound)
+		assert.NoError(t, store.Delete(ctx, "not-found"))
+	})
+
+	t.Run("List", func(t *testing.T) {
+		server.MaxKeys = 2
+		defer func() { server.MaxKeys = 1000 }()
+		for _, key := range []string{"list/a", "list/b", "list/c", "other/d"} {
+			put(t, key, key)
+		}
+
+		objects, err := store.List(ctx, "list/")
+		require.NoError(t, err)
+		var keys []string
+		for _, obj := range objects {
+			keys = append(keys, obj.Key)
+		}
+		assert.Equal(t, []string{"list/a", "list/b", "list/c"}, keys)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		put(t, "delete/a", "a")
+		require.NoError(t, store.Delete(ctx, "delete/a"))
+		_, err := store.Stat(ctx, "delete/a")
+		assert.ErrorIs(t, err, objstore.ErrNotFound)
+	})
+
+	t.Run("InvalidCredentials", func(t *testing.T) {
+		cfg := server.Config()
+		cfg.AccessKeyID = "invalid"
+		err := objstore.NewS3(cfg).Put(ctx, "a", bytes.NewReader(nil), 0)
+		assert.ErrorContains(t, err, "AccessDenied")
+	})
+}
+
+func TestFiles(t *testing.T) {
+	ctx := context.Background()
+	server := s3test.NewServer(t)
+	files := objstore.NewFiles(objstore.NewS3(server.Config()))
+	dir := t.TempDir()
+
+	write := func(t *testing.T, name, data string) string {
+		t.Helper()
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+		require.NoError(t, os.WriteFile(file, []byte(data), 0600))
+		return file
+	}
+
+	t.Run("Upload", func(t *testing.T) {
+		file := write(t, "upload/a.log", "uploaded")
+		require.NoError(t, files.Upload(ctx, file))
+
+		// The local file is removed and read from the store.
+		assert.NoFileExists(t, file)
+		data, err := files.ReadFile(ctx, file)
+		require.NoError(t, err)
+		assert.Equal(t, "uploaded", string(data))
+
+		modTime, err := files.ModTime(ctx, file)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), modTime, time.Minute)
+
+		_, err = files.ReadFile(ctx, filepath.Join(dir, "upload", "not-found.log"))
+		assert.True(t, os.IsNotExist(err))
+
+		// The file is downloaded to be appended to.
+		require.NoError(t, files.Download(ctx, file))
+		data, err = os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, "uploaded", string(data))
+
+		notFound := filepath.Join(dir, "upload", "not-found.log")
+		require.NoError(t, files.Download(ctx, notFound))
+		assert.NoFileExists(t, notFound)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		require.NoError(t, files.Upload(ctx, write(t, "list/a.dat", "a")))
+		write(t, "list/b.dat", "b")
+		require.NoError(t, files.Upload(ctx, write(t, "list/c.dat", "c")))
+		write(t, "list/c.dat", "c")
+		write(t, "list/d.log", "d")
+
+		matches, err := files.Glob(ctx, filepath.Join(dir, "list", "*.dat"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			filepath.Join(dir, "list", "a.dat"),
+			filepath.Join(dir, "list", "b.dat"),
+			filepath.Join(dir, "list", "c.dat"),
+		}, matches)
+
+		list, err := files.List(ctx, filepath.Join(dir, "*", "a.dat"))
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		assert.True(t, list[0].Remote)
+	})
+
+	t.Run("RemoveAndRename", func(t *testing.T) {
+		removed := write(t, "remove/a.dat", "a")
+		require.NoError(t, files.Upload(ctx, removed))
+		require.NoError(t, files.Remove(ctx, removed))
+		_, err := files.ReadFile(ctx, removed)
+		assert.True(t, os.IsNotExist(err))
+
+		oldFile := write(t, "rename/old.dat", "renamed")
+		require.NoError(t, files.Upload(ctx, oldFile))
+		newFile := filepath.Join(dir, "rename", "new.dat")
+		require.NoError(t, files.Rename(ctx, oldFile, newFile))
+
+		data, err := files.ReadFile(ctx, newFile)
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", string(data))
+		_, err = files.ReadFile(ctx, oldFile)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("ListingCache", func(t *testing.T) {
+		pattern := filepath.Join(dir, "cache", "*.dat")
+		glob := func(t *testing.T, lists int, expected ...string) {
+			t.Helper()
+			matches, err := files.Glob(ctx, pattern)
+			require.NoError(t, err)
+			var paths []string
+			for _, name := range expected {
+				paths = append(paths, filepath.Join(dir, "cache", name))
+			}
+			assert.Equal(t, paths, matches)
+			assert.Equal(t, lists, server.Lists())
+		}
+
+		require.NoError(t, files.Upload(ctx, write(t, "cache/a.dat", "a")))
+		local := write(t, "cache/b.dat", "b")
+		lists := server.Lists()
+		glob(t, lists+1, "a.dat", "b.dat")
+		glob(t, lists+1, "a.dat", "b.dat")
+
+		// The files changed through Files invalidate the listing.
+		require.NoError(t, files.Upload(ctx, write(t, "cache/c.dat", "c")))
+		glob(t, lists+2, "a.dat", "b.dat", "c.dat")
+		require.NoError(t, files.Remove(ctx, filepath.Join(dir, "cache", "c.dat")))
+		glob(t, lists+3, "a.dat", "b.dat")
+
+		// The local file uploaded by another process is listed again.
+		other := objstore.NewFiles(objstore.NewS3(server.Config()))
+		require.NoError(t, other.Upload(ctx, local))
+		glob(t, lists+4, "a.dat", "b.dat")
+		glob(t, lists+4, "a.dat", "b.dat")
+	})
+
+	t.Run("LocalOnly", func(t *testing.T) {
+		var local *objstore.Files
+		file := write(t, "local/a.log", "local")
+		require.NoError(t, local.Upload(ctx, file))
+		assert.FileExists(t, file)
+
+		matches, err := local.Glob(ctx, filepath.Join(dir, "local", "*.log"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{file}, matches)
+	})
+}
@@ -0,0 +1,210 @@
+// Package s3test provides an in-memory S3-compatible server for tests,
+// standing in for a storage like MinIO.
+package s3test
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/persistence/objstore"
+)
+
+const (
+	// Bucket is the bucket of the server.
+	Bucket = "dagu"
+	// AccessKeyID and SecretAccessKey are the credentials the server
+	// accepts.
+	AccessKeyID     = "test-access-key"
+	SecretAccessKey = "test-secret-key"
+)
+
+// Server is an S3-compatible server storing the objects in memory. It
+// supports the path-style requests of the operations used by the S3 store
+// on a single bucket.
+type Server struct {
+	URL string
+
+	// MaxKeys is the maximum number of the objects listed in a response.
+	MaxKeys int
+
+	mu      sync.Mutex
+	objects map[string]object
+	lists   int
+}
+
+type object struct {
+	data         []byte
+	lastModified time.Time
+}
+
+// NewServer starts a new server, which is closed when the test finishes.
+func NewServer(t *testing.T) *Server {
+	t.Helper()
+
+	s := &Server{
+		MaxKeys: 1000,
+		objects: make(map[string]object),
+	}
+	ts := httptest.NewServer(s)
+	t.Cleanup(ts.Close)
+	s.URL = ts.URL
+	return s
+}
+
+// Config returns the configuration of the S3 store using the server.
+func (s *Server) Config() objstore.S3Config {
+	return objstore.S3Config{
+		Endpoint:        s.URL,
+		Bucket:          Bucket,
+		AccessKeyID:     AccessKeyID,
+		SecretAccessKey: SecretAccessKey,
+		UsePathStyle:    true,
+	}
+}
+
+// Keys returns the keys of the objects.
+func (s *Server) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Lists returns the number of the list requests served, counting each page.
+func (s *Server) Lists() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lists
+}
+
+// SetLastModified changes the last modified time of the object.
+func (s *Server) SetLastModified(key string, t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if obj, ok := s.objects[key]; ok {
+		obj.lastModified = t
+		s.objects[key] = obj
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential="+AccessKeyID+"/") {
+		writeError(w, http.StatusForbidden, "AccessDenied", "The request is not signed.")
+		return
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != Bucket {
+		writeError(w, http.StatusNotFound, "NoSuchBucket", "The bucket does not exist.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		s.list(w, r)
+
+	case r.Method == http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+			return
+		}
+		s.objects[key] = object{data: data, lastModified: time.Now().UTC()}
+		w.WriteHeader(http.StatusOK)
+
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		obj, ok := s.objects[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey", "The key does not exist.")
+			return
+		}
+		w.Header().Set("Last-Modified", obj.lastModified.Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.data)))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(obj.data)
+		}
+
+	case r.Method == http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The method is not allowed.")
+	}
+}
+
+type listBucketResult struct {
+	XMLName               xml.Name  `xml:"ListBucketResult"`
+	Contents              []content `xml:"Contents"`
+	IsTruncated           bool      `xml:"IsTruncated"`
+	NextContinuationToken string    `xml:"NextContinuationToken,omitempty"`
+}
+
+type content struct {
+	Key          string `xml:"Key"`
+	Size         int    `xml:"Size"`
+	LastModified string `xml:"LastModified"`
+}
+
+// list lists the objects as ListObjectsV2. The continuation token is the
+// key of the last object listed.
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+	after := r.URL.Query().Get("continuation-token")
+	s.lists++
+
+	var keys []string
+	for key := range s.objects {
+		if strings.HasPrefix(key, prefix) && key > after {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var result listBucketResult
+	if len(keys) > s.MaxKeys {
+		keys = keys[:s.MaxKeys]
+		result.IsTruncated = true
+		result.NextContinuationToken = keys[len(keys)-1]
+	}
+	for _, key := range keys {
+		obj := s.objects[key]
+		result.Contents = append(result.Contents, content{
+			Key:          key,
+			Size:         len(obj.data),
+			LastModified: obj.lastModified.Format("2006-01-02T15:04:05.000Z"),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+		Message string   `xml:"Message"`
+	}{Code: code, Message: message})
+}
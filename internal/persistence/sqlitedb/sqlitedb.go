@@ -0,0 +1,546 @@
+package sqlitedb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+
+	// Register the pure Go SQLite driver, which keeps the binary free of cgo.
+	_ "modernc.org/sqlite"
+)
+
+var (
+	errRequestIDNotFound = errors.New("request ID not found")
+	errKeyEmpty          = errors.New("dagFile is empty")
+	errInvalidFile       = errors.New("invalid status file")
+	errNotOpen           = errors.New("status is not open")
+)
+
+// busyTimeout is the time a connection waits for the lock of the database
+// held by another process, e.g. the agent of another run writing its status.
+const busyTimeout = 5 * time.Second
+
+// fileSeparator separates the path of the database from the ID of the run
+// in the file of a status. See ParseStatusFile.
+const fileSeparator = "#"
+
+const schema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	dag        TEXT    NOT NULL,
+	name       TEXT    NOT NULL,
+	request_id TEXT    NOT NULL,
+	status     INTEGER NOT NULL,
+	started_at INTEGER NOT NULL,
+	updated_at INTEGER NOT NULL,
+	data       TEXT    NOT NULL
+);
+CREATE INDEX IF NOT EXISTS runs_dag_started_at ON runs (dag, started_at);
+CREATE INDEX IF NOT EXISTS runs_request_id ON runs (request_id);
+CREATE INDEX IF NOT EXISTS runs_status ON runs (status);
+CREATE INDEX IF NOT EXISTS runs_started_at ON runs (started_at);
+`
+
+// DB is the database file of the history shared by the stores. The file is
+// opened and its schema created on the first use, so that an error is
+// reported by the operation of the store needing the database.
+type DB struct {
+	path string
+	once sync.Once
+	db   *sql.DB
+	err  error
+
+	// ro is the read-only connection of the readers of the status files.
+	roOnce sync.Once
+	ro     *sql.DB
+	roErr  error
+}
+
+// NewDB returns the database of the history in the file.
+func NewDB(path string) *DB {
+	return &DB{path: path}
+}
+
+func (d *DB) conn() (*sql.DB, error) {
+	d.once.Do(func() {
+		d.db, d.err = open(d.path)
+	})
+	return d.db, d.err
+}
+
+// readOnlyConn returns the read-only connection of the database. It never
+// creates the database nor its schema.
+func (d *DB) readOnlyConn() (*sql.DB, error) {
+	d.roOnce.Do(func() {
+		dsn := fmt.Sprintf("file:%s?mode=ro&_pragma=busy_timeout(%d)", d.path, busyTimeout.Milliseconds())
+		d.ro, d.roErr = sql.Open("sqlite", dsn)
+	})
+	return d.ro, d.roErr
+}
+
+// Close closes the database if it has been opened.
+func (d *DB) Close() error {
+	var err error
+	if d.ro != nil {
+		err = d.ro.Close()
+	}
+	if d.db == nil {
+		return err
+	}
+	return errors.Join(err, d.db.Close())
+}
+
+func open(path string) (*sql.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory of the history database: %w", err)
+	}
+	// WAL lets the readers, e.g. the web server, read the history while the
+	// agents of the runs write their statuses.
+	dsn := fmt.Sprintf("file:%s?_pragma=busy_timeout(%d)&_pragma=journal_mode(WAL)",
+		path, busyTimeout.Milliseconds())
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database %s: %w", path, err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create schema of history database %s: %w", path, err)
+	}
+	return db, nil
+}
+
+var _ persistence.HistoryStore = (*SQLiteDB)(nil)
+
+// SQLiteDB manages the statuses of the DAG runs in a SQLite database. Each
+// run is a row indexed by the DAG, the request ID, the status and the start
+// time, so the history is queried without scanning the runs.
+type SQLiteDB struct {
+	db                *DB
+	latestStatusToday bool
+	writer            *writer
+}
+
+// writer is the run opened for writing its status. The row of the run is
+// inserted by the first write.
+type writer struct {
+	key       string
+	startedAt time.Time
+	id        int64
+}
+
+type Option func(*Options)
+
+type Options struct {
+	LatestStatusToday bool
+}
+
+func WithLatestStatusToday(latestStatusToday bool) Option {
+	return func(o *Options) {
+		o.LatestStatusToday = latestStatusToday
+	}
+}
+
+// New creates a new SQLiteDB instance storing the history in the database.
+func New(db *DB, opts ...Option) *SQLiteDB {
+	options := &Options{
+		LatestStatusToday: true,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return &SQLiteDB{
+		db:                db,
+		latestStatusToday: options.LatestStatusToday,
+	}
+}
+
+func (s *SQLiteDB) Open(ctx context.Context, key string, timestamp time.Time, requestID string) error {
+	if key == "" {
+		return errKeyEmpty
+	}
+	if _, err := s.db.conn(); err != nil {
+		return err
+	}
+
+	logger.Infof(ctx, "Initializing status of run %s in %s", requestID, s.db.path)
+
+	s.writer = &writer{key: key, startedAt: timestamp}
+	return nil
+}
+
+func (s *SQLiteDB) Write(ctx context.Context, status model.Status) error {
+	if s.writer == nil {
+		return errNotOpen
+	}
+	conn, err := s.db.conn()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	now := toMillis(time.Now())
+	if s.writer.id != 0 {
+		_, err := conn.ExecContext(ctx,
+			`UPDATE runs SET name = ?, request_id = ?, status = ?, updated_at = ?, data = ? WHERE id = ?`,
+			status.Name, status.RequestID, int(status.Status), now, string(data), s.writer.id)
+		return err
+	}
+
+	ret, err := conn.ExecContext(ctx,
+		`INSERT INTO runs (dag, name, request_id, status, started_at, updated_at, data) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		s.writer.key, status.Name, status.RequestID, int(status.Status), toMillis(s.writer.startedAt), now, string(data))
+	if err != nil {
+		return err
+	}
+	s.writer.id, err = ret.LastInsertId()
+	return err
+}
+
+func (s *SQLiteDB) Close(_ context.Context) error {
+	s.writer = nil
+	return nil
+}
+
+func (s *SQLiteDB) Update(ctx context.Context, key, requestID string, status model.Status) error {
+	statusFile, err := s.FindByRequestID(ctx, key, requestID)
+	if err != nil {
+		return err
+	}
+	id, err := s.parseFile(statusFile.File)
+	if err != nil {
+		return err
+	}
+	conn, err := s.db.conn()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	_, err = conn.ExecContext(ctx,
+		`UPDATE runs SET name = ?, status = ?, updated_at = ?, data = ? WHERE id = ?`,
+		status.Name, int(status.Status), toMillis(time.Now()), string(data), id)
+	return err
+}
+
+func (s *SQLiteDB) ReadStatusRecent(ctx context.Context, key string, itemLimit int) []model.StatusFile {
+	conn, err := s.db.conn()
+	if err != nil {
+		logger.Error(ctx, "Failed to open history database", "err", err)
+		return nil
+	}
+	rows, err := conn.QueryContext(ctx,
+		`SELECT id, data FROM runs WHERE dag = ? ORDER BY started_at DESC, id DESC LIMIT ?`,
+		key, itemLimit)
+	if err != nil {
+		logger.Error(ctx, "Failed to read history", "dag", key, "err", err)
+		return nil
+	}
+	defer rows.Close()
+
+	var ret []model.StatusFile
+	for rows.Next() {
+		statusFile, err := s.scanStatusFile(rows)
+		if err != nil {
+			continue
+		}
+		ret = append(ret, *statusFile)
+	}
+	return ret
+}
+
+func (s *SQLiteDB) ReadStatusToday(ctx context.Context, key string) (*model.Status, error) {
+	conn, err := s.db.conn()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		startedAt int64
+		data      string
+	)
+	err = conn.QueryRowContext(ctx,
+		`SELECT started_at, data FROM runs WHERE dag = ? ORDER BY started_at DESC, id DESC LIMIT 1`,
+		key).Scan(&startedAt, &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, persistence.ErrNoStatusDataToday
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if s.latestStatusToday {
+		startOfDay := time.Now().Truncate(24 * time.Hour)
+		if fromMillis(startedAt).Before(startOfDay) {
+			return nil, persistence.ErrNoStatusDataToday
+		}
+	}
+	return model.StatusFromJSON(data)
+}
+
+func (s *SQLiteDB) FindByRequestID(ctx context.Context, key string, requestID string) (*model.StatusFile, error) {
+	if requestID == "" {
+		return nil, errRequestIDNotFound
+	}
+	conn, err := s.db.conn()
+	if err != nil {
+		return nil, err
+	}
+
+	// A retry records a new run with the request ID of the original run, so
+	// the latest run is the current status of the request.
+	row := conn.QueryRowContext(ctx,
+		`SELECT id, data FROM runs WHERE dag = ? AND request_id = ? ORDER BY started_at DESC, id DESC LIMIT 1`,
+		key, requestID)
+	statusFile, err := s.scanStatusFile(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w : %s", persistence.ErrRequestIDNotFound, requestID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return statusFile, nil
+}
+
+func (s *SQLiteDB) RemoveAll(ctx context.Context, key string) error {
+	conn, err := s.db.conn()
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, `DELETE FROM runs WHERE dag = ?`, key)
+	return err
+}
+
+func (s *SQLiteDB) RemoveOld(ctx context.Context, key string, retentionDays int) error {
+	if retentionDays < 0 {
+		return nil
+	}
+	conn, err := s.db.conn()
+	if err != nil {
+		return err
+	}
+
+	oldDate := time.Now().AddDate(0, 0, -retentionDays)
+	_, err = conn.ExecContext(ctx,
+		`DELETE FROM runs WHERE dag = ? AND updated_at < ?`, key, toMillis(oldDate))
+	return err
+}
+
+func (s *SQLiteDB) Rename(ctx context.Context, oldKey, newKey string) error {
+	if !filepath.IsAbs(oldKey) || !filepath.IsAbs(newKey) {
+		return fmt.Errorf("invalid path: %s -> %s", oldKey, newKey)
+	}
+	conn, err := s.db.conn()
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, `UPDATE runs SET dag = ? WHERE dag = ?`, newKey, oldKey)
+	return err
+}
+
+func (s *SQLiteDB) Query(ctx context.Context, query persistence.HistoryQuery) (*persistence.HistoryQueryResult, error) {
+	conn, err := s.db.conn()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		conds []string
+		args  []any
+	)
+	if !query.From.IsZero() {
+		conds = append(conds, "started_at >= ?")
+		args = append(args, toMillis(query.From))
+	}
+	if !query.To.IsZero() {
+		conds = append(conds, "started_at < ?")
+		args = append(args, toMillis(query.To))
+	}
+	if len(query.Statuses) > 0 {
+		conds = append(conds, "status IN ("+placeholders(len(query.Statuses))+")")
+		for _, status := range query.Statuses {
+			args = append(args, int(status))
+		}
+	}
+	if query.Name != "" {
+		conds = append(conds, "name = ?")
+		args = append(args, query.Name)
+	}
+	if query.Keys != nil {
+		if len(query.Keys) == 0 {
+			return &persistence.HistoryQueryResult{}, nil
+		}
+		conds = append(conds, "dag IN ("+placeholders(len(query.Keys))+")")
+		for _, key := range query.Keys {
+			args = append(args, key)
+		}
+	}
+	if query.Params != "" {
+		conds = append(conds, "instr(json_extract(data, '$.Params'), ?) > 0")
+		args = append(args, query.Params)
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = " WHERE " + strings.Join(conds, " AND ")
+	}
+
+	ret := &persistence.HistoryQueryResult{}
+	if err := conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM runs"+where, args...).Scan(&ret.Total); err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit < 1 {
+		limit = -1 // No limit
+	}
+	rows, err := conn.QueryContext(ctx,
+		"SELECT id, data FROM runs"+where+" ORDER BY started_at DESC, id DESC LIMIT ? OFFSET ?",
+		append(args, limit, query.Offset())...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		statusFile, err := s.scanStatusFile(rows)
+		if err != nil {
+			return nil, err
+		}
+		ret.Runs = append(ret.Runs, *statusFile)
+	}
+	return ret, rows.Err()
+}
+
+// Import records the status of a run read from another store, e.g. when the
+// history is migrated from the JSON files. It returns false without
+// recording the run if the run of the DAG with the request ID and the start
+// time already exists, so that the import can be repeated.
+func (s *SQLiteDB) Import(ctx context.Context, key string, startedAt, updatedAt time.Time, status model.Status) (bool, error) {
+	if key == "" {
+		return false, errKeyEmpty
+	}
+	conn, err := s.db.conn()
+	if err != nil {
+		return false, err
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	if err := conn.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM runs WHERE dag = ? AND request_id = ? AND started_at = ?)`,
+		key, status.RequestID, toMillis(startedAt)).Scan(&exists); err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+
+	_, err = conn.ExecContext(ctx,
+		`INSERT INTO runs (dag, name, request_id, status, started_at, updated_at, data) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		key, status.Name, status.RequestID, int(status.Status), toMillis(startedAt), toMillis(updatedAt), string(data))
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// scanStatusFile reads the ID and the status of a run from the row.
+func (s *SQLiteDB) scanStatusFile(row interface{ Scan(...any) error }) (*model.StatusFile, error) {
+	var (
+		id   int64
+		data string
+	)
+	if err := row.Scan(&id, &data); err != nil {
+		return nil, err
+	}
+	status, err := model.StatusFromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return &model.StatusFile{
+		File:   s.db.path + fileSeparator + strconv.FormatInt(id, 10),
+		Status: *status,
+	}, nil
+}
+
+// parseFile returns the ID of the run in the file of the status.
+func (s *SQLiteDB) parseFile(file string) (int64, error) {
+	path, id, ok := splitFile(file)
+	if !ok || path != s.db.path {
+		return 0, fmt.Errorf("%w: %s", errInvalidFile, file)
+	}
+	return id, nil
+}
+
+// IsStatusFile returns true if the file of a status refers to a run in a
+// SQLite database rather than to a JSON file.
+func IsStatusFile(file string) bool {
+	_, _, ok := splitFile(file)
+	return ok
+}
+
+// ReadStatusFile reads the status of the run the file refers to. The file
+// of a status stored in SQLite is the path of the database followed by "#"
+// and the ID of the run; the files of the other databases are rejected.
+// The database is read through the read-only connection.
+func (d *DB) ReadStatusFile(ctx context.Context, file string) (*model.Status, error) {
+	path, id, ok := splitFile(file)
+	if !ok || path != d.path {
+		return nil, fmt.Errorf("%w: %s", errInvalidFile, file)
+	}
+
+	conn, err := d.readOnlyConn()
+	if err != nil {
+		return nil, err
+	}
+	var data string
+	if err := conn.QueryRowContext(ctx, `SELECT data FROM runs WHERE id = ?`, id).Scan(&data); err != nil {
+		return nil, err
+	}
+	return model.StatusFromJSON(data)
+}
+
+func splitFile(file string) (string, int64, bool) {
+	i := strings.LastIndex(file, fileSeparator)
+	if i <= 0 {
+		return "", 0, false
+	}
+	id, err := strconv.ParseInt(file[i+1:], 10, 64)
+	if err != nil || id <= 0 {
+		return "", 0, false
+	}
+	return file[:i], id, true
+}
+
+// placeholders returns the placeholders of n values of a SQL statement.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func toMillis(t time.Time) int64 {
+	return t.UnixMilli()
+}
+
+func fromMillis(ms int64) time.Time {
+	return time.UnixMilli(ms)
+}
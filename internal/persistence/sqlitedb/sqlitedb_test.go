@@ -0,0 +1,348 @@
+package sqlitedb
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPID = 12345
+
+type testHelper struct {
+	Context context.Context
+	DB      *SQLiteDB
+	tmpDir  string
+}
+
+func testSetup(t *testing.T) testHelper {
+	tmpDir := t.TempDir()
+	db := NewDB(filepath.Join(tmpDir, "history.db"))
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	return testHelper{
+		Context: context.Background(),
+		DB:      New(db),
+		tmpDir:  tmpDir,
+	}
+}
+
+func (th testHelper) DAG(name string) *digraph.DAG {
+	return &digraph.DAG{
+		Name:     name,
+		Location: filepath.Join(th.tmpDir, name+".yaml"),
+	}
+}
+
+// writeRun records a run of the DAG started at the time.
+func (th testHelper) writeRun(t *testing.T, dag *digraph.DAG, requestID string, startedAt time.Time, status scheduler.Status) {
+	t.Helper()
+
+	require.NoError(t, th.DB.Open(th.Context, dag.Location, startedAt, requestID))
+	st := model.NewStatusFactory(dag).Create(requestID, status, testPID, startedAt)
+	require.NoError(t, th.DB.Write(th.Context, st))
+	require.NoError(t, th.DB.Close(th.Context))
+}
+
+func TestSQLiteDB_Basic(t *testing.T) {
+	th := testSetup(t)
+
+	t.Run("WriteTwice", func(t *testing.T) {
+		dag := th.DAG("test_write")
+		requestID := "request-id-test-write"
+
+		require.NoError(t, th.DB.Open(th.Context, dag.Location, time.Now(), requestID))
+		status := model.NewStatusFactory(dag).Create(
+			requestID, scheduler.StatusRunning, testPID, time.Now(),
+		)
+		require.NoError(t, th.DB.Write(th.Context, status))
+
+		status.Status = scheduler.StatusSuccess
+		require.NoError(t, th.DB.Write(th.Context, status))
+		require.NoError(t, th.DB.Close(th.Context))
+
+		// The second write updates the run.
+		statuses := th.DB.ReadStatusRecent(th.Context, dag.Location, 10)
+		require.Len(t, statuses, 1)
+		assert.Equal(t, scheduler.StatusSuccess, statuses[0].Status.Status)
+	})
+
+	t.Run("WriteNotOpen", func(t *testing.T) {
+		dag := th.DAG("test_not_open")
+		status := model.NewStatusFactory(dag).Create(
+			"request-id", scheduler.StatusRunning, testPID, time.Now(),
+		)
+		assert.ErrorIs(t, th.DB.Write(th.Context, status), errNotOpen)
+	})
+
+	t.Run("UpdateStatus", func(t *testing.T) {
+		dag := th.DAG("test_update")
+		requestID := "request-id-test-update"
+		th.writeRun(t, dag, requestID, time.Now(), scheduler.StatusRunning)
+
+		status := model.NewStatusFactory(dag).Create(
+			requestID, scheduler.StatusSuccess, testPID, time.Now(),
+		)
+		require.NoError(t, th.DB.Update(th.Context, dag.Location, requestID, status))
+
+		statusFile, err := th.DB.FindByRequestID(th.Context, dag.Location, requestID)
+		require.NoError(t, err)
+		assert.Equal(t, scheduler.StatusSuccess, statusFile.Status.Status)
+	})
+
+	t.Run("FindRetriedRun", func(t *testing.T) {
+		dag := th.DAG("test_retry")
+		requestID := "request-id-test-retry"
+		now := time.Now()
+		th.writeRun(t, dag, requestID, now.Add(-time.Hour), scheduler.StatusError)
+		th.writeRun(t, dag, requestID, now, scheduler.StatusSuccess)
+
+		// The latest run of the request is found.
+		statusFile, err := th.DB.FindByRequestID(th.Context, dag.Location, requestID)
+		require.NoError(t, err)
+		assert.Equal(t, scheduler.StatusSuccess, statusFile.Status.Status)
+	})
+}
+
+func TestSQLiteDB_ReadStatus(t *testing.T) {
+	th := testSetup(t)
+
+	t.Run("ReadStatusRecent", func(t *testing.T) {
+		dag := th.DAG("test_read_recent")
+		for i := 0; i < 5; i++ {
+			th.writeRun(t, dag, fmt.Sprintf("request-id-%d", i),
+				time.Now().Add(time.Duration(-i)*time.Hour), scheduler.StatusSuccess)
+		}
+		// The runs of the other DAGs are not read.
+		th.writeRun(t, th.DAG("test_other"), "request-id-other", time.Now(), scheduler.StatusSuccess)
+
+		statuses := th.DB.ReadStatusRecent(th.Context, dag.Location, 3)
+		require.Len(t, statuses, 3)
+		assert.Equal(t, "request-id-0", statuses[0].Status.RequestID)
+		assert.Equal(t, "request-id-2", statuses[2].Status.RequestID)
+
+		statuses = th.DB.ReadStatusRecent(th.Context, dag.Location, 10)
+		assert.Len(t, statuses, 5)
+	})
+
+	t.Run("ReadStatusToday", func(t *testing.T) {
+		dag := th.DAG("test_read_today")
+		th.writeRun(t, dag, "request-id-today", time.Now(), scheduler.StatusSuccess)
+
+		status, err := th.DB.ReadStatusToday(th.Context, dag.Location)
+		require.NoError(t, err)
+		assert.Equal(t, "request-id-today", status.RequestID)
+	})
+
+	t.Run("NoStatusToday", func(t *testing.T) {
+		dag := th.DAG("test_no_status_today")
+		th.writeRun(t, dag, "request-id-yesterday", time.Now().AddDate(0, 0, -1), scheduler.StatusSuccess)
+
+		_, err := th.DB.ReadStatusToday(th.Context, dag.Location)
+		assert.ErrorIs(t, err, persistence.ErrNoStatusDataToday)
+	})
+
+	t.Run("NoStatusData", func(t *testing.T) {
+		dag := th.DAG("test_no_status_data")
+		_, err := th.DB.ReadStatusToday(th.Context, dag.Location)
+		assert.ErrorIs(t, err, persistence.ErrNoStatusDataToday)
+	})
+
+	t.Run("ReadStatusFile", func(t *testing.T) {
+		dag := th.DAG("test_parse_file")
+		th.writeRun(t, dag, "request-id-file", time.Now(), scheduler.StatusSuccess)
+
+		statuses := th.DB.ReadStatusRecent(th.Context, dag.Location, 1)
+		require.Len(t, statuses, 1)
+		require.True(t, IsStatusFile(statuses[0].File))
+
+		status, err := th.DB.db.ReadStatusFile(th.Context, statuses[0].File)
+		require.NoError(t, err)
+		assert.Equal(t, "request-id-file", status.RequestID)
+
+		assert.False(t, IsStatusFile(filepath.Join(th.tmpDir, "test.20250101.00:00:00.000Z.abcdefgh.dat")))
+
+		// The files of the other databases are rejected and not created.
+		other := filepath.Join(th.tmpDir, "other.db")
+		_, err = th.DB.db.ReadStatusFile(th.Context, other+"#1")
+		assert.ErrorIs(t, err, errInvalidFile)
+		assert.NoFileExists(t, other)
+	})
+	t.Run("ReadStatusFileReadOnly", func(t *testing.T) {
+		// The database is neither created nor changed by the readers.
+		path := filepath.Join(t.TempDir(), "history.db")
+		db := NewDB(path)
+		t.Cleanup(func() {
+			_ = db.Close()
+		})
+		_, err := db.ReadStatusFile(th.Context, path+"#1")
+		assert.Error(t, err)
+		assert.NoFileExists(t, path)
+	})
+}
+
+func TestSQLiteDB_Remove(t *testing.T) {
+	th := testSetup(t)
+
+	t.Run("RemoveOld", func(t *testing.T) {
+		dag := th.DAG("test_remove_old")
+		th.writeRun(t, dag, "request-id-old", time.Now().AddDate(0, 0, -10), scheduler.StatusSuccess)
+
+		// The run was updated just now.
+		require.NoError(t, th.DB.RemoveOld(th.Context, dag.Location, 7))
+		assert.Len(t, th.DB.ReadStatusRecent(th.Context, dag.Location, 10), 1)
+
+		_, err := th.DB.Import(th.Context, dag.Location, time.Now().AddDate(0, 0, -9), time.Now().AddDate(0, 0, -9),
+			model.NewStatusFactory(dag).Create("request-id-older", scheduler.StatusSuccess, testPID, time.Now()))
+		require.NoError(t, err)
+		require.NoError(t, th.DB.RemoveOld(th.Context, dag.Location, 7))
+
+		statuses := th.DB.ReadStatusRecent(th.Context, dag.Location, 10)
+		require.Len(t, statuses, 1)
+		assert.Equal(t, "request-id-old", statuses[0].Status.RequestID)
+	})
+
+	t.Run("RemoveAll", func(t *testing.T) {
+		dag := th.DAG("test_remove_all")
+		for i := 0; i < 3; i++ {
+			th.writeRun(t, dag, fmt.Sprintf("request-id-%d", i), time.Now(), scheduler.StatusSuccess)
+		}
+
+		require.NoError(t, th.DB.RemoveAll(th.Context, dag.Location))
+		assert.Empty(t, th.DB.ReadStatusRecent(th.Context, dag.Location, 10))
+	})
+}
+
+func TestSQLiteDB_Rename(t *testing.T) {
+	th := testSetup(t)
+
+	oldDAG, newDAG := th.DAG("test_rename_old"), th.DAG("test_rename_new")
+	th.writeRun(t, oldDAG, "request-id-rename", time.Now(), scheduler.StatusSuccess)
+
+	require.NoError(t, th.DB.Rename(th.Context, oldDAG.Location, newDAG.Location))
+	assert.Empty(t, th.DB.ReadStatusRecent(th.Context, oldDAG.Location, 10))
+	_, err := th.DB.FindByRequestID(th.Context, newDAG.Location, "request-id-rename")
+	require.NoError(t, err)
+
+	err = th.DB.Rename(th.Context, "relative/path", newDAG.Location)
+	assert.Error(t, err)
+}
+
+func TestSQLiteDB_Import(t *testing.T) {
+	th := testSetup(t)
+
+	dag := th.DAG("test_import")
+	startedAt := time.Now().Add(-time.Hour)
+	status := model.NewStatusFactory(dag).Create("request-id-import", scheduler.StatusSuccess, testPID, startedAt)
+
+	ok, err := th.DB.Import(th.Context, dag.Location, startedAt, startedAt, status)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// The run already imported is skipped.
+	ok, err = th.DB.Import(th.Context, dag.Location, startedAt, startedAt, status)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Len(t, th.DB.ReadStatusRecent(th.Context, dag.Location, 10), 1)
+}
+
+func TestSQLiteDB_ErrorHandling(t *testing.T) {
+	th := testSetup(t)
+
+	t.Run("FindByRequestIDNotFound", func(t *testing.T) {
+		dag := th.DAG("test_not_found")
+		_, err := th.DB.FindByRequestID(th.Context, dag.Location, "nonexistent-id")
+		assert.ErrorIs(t, err, persistence.ErrRequestIDNotFound)
+	})
+
+	t.Run("UpdateWithEmptyRequestID", func(t *testing.T) {
+		dag := th.DAG("test_update_empty_id")
+		status := model.NewStatusFactory(dag).Create("", scheduler.StatusSuccess, testPID, time.Now())
+		err := th.DB.Update(th.Context, dag.Location, "", status)
+		assert.ErrorIs(t, err, errRequestIDNotFound)
+	})
+
+	t.Run("EmptyDAGFile", func(t *testing.T) {
+		err := th.DB.Open(th.Context, "", time.Now(), "request-id")
+		assert.ErrorIs(t, err, errKeyEmpty)
+	})
+}
+
+func TestSQLiteDB_Query(t *testing.T) {
+	th := testSetup(t)
+
+	now := time.Now()
+	write := func(t *testing.T, dag *digraph.DAG, requestID string, startedAt time.Time, st scheduler.Status, params string) {
+		t.Helper()
+
+		require.NoError(t, th.DB.Open(th.Context, dag.Location, startedAt, requestID))
+		status := model.NewStatusFactory(dag).Create(requestID, st, testPID, startedAt)
+		status.Params = params
+		require.NoError(t, th.DB.Write(th.Context, status))
+		require.NoError(t, th.DB.Close(th.Context))
+	}
+	dagA, dagB := th.DAG("test_query_a"), th.DAG("test_query_b")
+	write(t, dagA, "request-id-a1", now.Add(-3*time.Hour), scheduler.StatusSuccess, "env=prod")
+	write(t, dagA, "request-id-a2", now.Add(-2*time.Hour), scheduler.StatusError, "env=dev")
+	write(t, dagB, "request-id-b1", now.Add(-time.Hour), scheduler.StatusError, "env=prod")
+	write(t, dagB, "request-id-b2", now.Add(-48*time.Hour), scheduler.StatusError, "")
+
+	requestIDs := func(result *persistence.HistoryQueryResult) []string {
+		var ret []string
+		for _, run := range result.Runs {
+			ret = append(ret, run.Status.RequestID)
+		}
+		return ret
+	}
+
+	t.Run("All", func(t *testing.T) {
+		result, err := th.DB.Query(th.Context, persistence.HistoryQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Total)
+		assert.Equal(t, []string{"request-id-b1", "request-id-a2", "request-id-a1", "request-id-b2"}, requestIDs(result))
+	})
+
+	t.Run("StatusAndTimeRange", func(t *testing.T) {
+		result, err := th.DB.Query(th.Context, persistence.HistoryQuery{
+			From:     now.Add(-24 * time.Hour),
+			To:       now.Add(-90 * time.Minute),
+			Statuses: []scheduler.Status{scheduler.StatusError},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"request-id-a2"}, requestIDs(result))
+	})
+
+	t.Run("NameAndParams", func(t *testing.T) {
+		result, err := th.DB.Query(th.Context, persistence.HistoryQuery{Name: "test_query_a", Params: "prod"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"request-id-a1"}, requestIDs(result))
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		result, err := th.DB.Query(th.Context, persistence.HistoryQuery{Keys: []string{dagB.Location}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"request-id-b1", "request-id-b2"}, requestIDs(result))
+
+		result, err = th.DB.Query(th.Context, persistence.HistoryQuery{Keys: []string{}})
+		require.NoError(t, err)
+		assert.Zero(t, result.Total)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		result, err := th.DB.Query(th.Context, persistence.HistoryQuery{Page: 2, Limit: 3})
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Total)
+		assert.Equal(t, []string{"request-id-b2"}, requestIDs(result))
+	})
+}
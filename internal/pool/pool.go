@@ -0,0 +1,114 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/dagu-org/dagu/internal/fileutil"
+)
+
+var (
+	ErrPoolNotFound = errors.New("pool not found")
+)
+
+const defaultPollInterval = time.Second
+
+// Pools coordinates named resource pools between all the agent processes
+// that share the same directory.
+//
+// Each slot of a pool is a lock file under <dir>/<pool name>. A slot is
+// held as long as an exclusive flock on its file is held. The kernel
+// releases the lock when the process exits, so slots held by an agent
+// that crashed become available again without any cleanup.
+type Pools struct {
+	dir          string
+	sizes        map[string]int
+	pollInterval time.Duration
+}
+
+// Option is a functional option for Pools.
+type Option func(*Pools)
+
+// WithPollInterval sets the interval to check for a free slot while
+// waiting for a pool.
+func WithPollInterval(interval time.Duration) Option {
+	return func(p *Pools) {
+		p.pollInterval = interval
+	}
+}
+
+// New creates a new Pools with the given slot counts by pool name.
+// Pool names are case-insensitive.
+func New(dir string, sizes map[string]int, opts ...Option) *Pools {
+	p := &Pools{
+		dir:          dir,
+		sizes:        make(map[string]int, len(sizes)),
+		pollInterval: defaultPollInterval,
+	}
+	for name, size := range sizes {
+		p.sizes[strings.ToLower(name)] = size
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Acquire blocks until a slot of the named pool is available or the
+// context is done. The returned function releases the slot.
+func (p *Pools) Acquire(ctx context.Context, name string) (func(), error) {
+	size, ok := p.sizes[strings.ToLower(name)]
+	if !ok || size < 1 {
+		return nil, fmt.Errorf("%w: %s", ErrPoolNotFound, name)
+	}
+
+	dir := filepath.Join(p.dir, fileutil.SafeName(name))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create pool directory %s: %w", dir, err)
+	}
+
+	for {
+		for i := 0; i < size; i++ {
+			file, err := tryLock(filepath.Join(dir, fmt.Sprintf("slot_%d.lock", i)))
+			if err != nil {
+				return nil, err
+			}
+			if file != nil {
+				return func() {
+					_ = unix.Flock(int(file.Fd()), unix.LOCK_UN)
+					_ = file.Close()
+				}, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
+
+// tryLock takes an exclusive lock on the file without blocking. It returns
+// nil if the lock is held by someone else.
+func tryLock(name string) (*os.File, error) {
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open slot file %s: %w", name, err)
+	}
+	if err := unix.Flock(int(file.Fd()), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+		_ = file.Close()
+		if errors.Is(err, unix.EWOULDBLOCK) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to lock slot file %s: %w", name, err)
+	}
+	return file, nil
+}
@@ -0,0 +1,87 @@
+package pool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPools(t *testing.T) {
+	t.Run("AcquireAndRelease", func(t *testing.T) {
+		pools := New(t.TempDir(), map[string]int{"Warehouse": 2}, WithPollInterval(10*time.Millisecond))
+		ctx := context.Background()
+
+		release1, err := pools.Acquire(ctx, "warehouse")
+		require.NoError(t, err)
+		release2, err := pools.Acquire(ctx, "WAREHOUSE")
+		require.NoError(t, err)
+
+		// The pool is full, so the third acquisition waits.
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err = pools.Acquire(timeoutCtx, "warehouse")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		release1()
+		release3, err := pools.Acquire(ctx, "warehouse")
+		require.NoError(t, err)
+
+		release2()
+		release3()
+	})
+	t.Run("WaitForRelease", func(t *testing.T) {
+		pools := New(t.TempDir(), map[string]int{"db": 1}, WithPollInterval(10*time.Millisecond))
+		ctx := context.Background()
+
+		release, err := pools.Acquire(ctx, "db")
+		require.NoError(t, err)
+
+		acquired := make(chan struct{})
+		go func() {
+			release, err := pools.Acquire(ctx, "db")
+			if err == nil {
+				release()
+			}
+			close(acquired)
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("slot acquired while the pool is full")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		release()
+
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatal("slot not acquired after release")
+		}
+	})
+	t.Run("SharedDirectory", func(t *testing.T) {
+		// Two instances share the slots when they use the same directory
+		// as the agents of different DAG runs do.
+		dir := t.TempDir()
+		pools1 := New(dir, map[string]int{"db": 1})
+		pools2 := New(dir, map[string]int{"db": 1}, WithPollInterval(10*time.Millisecond))
+		ctx := context.Background()
+
+		release, err := pools1.Acquire(ctx, "db")
+		require.NoError(t, err)
+		defer release()
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err = pools2.Acquire(timeoutCtx, "db")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("PoolNotFound", func(t *testing.T) {
+		pools := New(t.TempDir(), map[string]int{"db": 1})
+
+		_, err := pools.Acquire(context.Background(), "unknown")
+		require.ErrorIs(t, err, ErrPoolNotFound)
+	})
+}
@@ -0,0 +1,52 @@
+package procutil
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// bootID returns the ID of the current boot of the host, or "" if it is
+// unknown.
+var bootID = sync.OnceValue(func() string {
+	data, err := os.ReadFile("/proc/sys/kernel/random/boot_id")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+})
+
+// Hostname returns the name of the host, or "" if it is unknown.
+func Hostname() string {
+	hostname, _ := os.Hostname()
+	return hostname
+}
+
+// StartTime returns an identifier of when the process started, unique on
+// the host across reboots, so that the process is not confused with
+// another one reusing its PID. It returns "" if the process does not exist
+// or the start time is unknown, e.g. on the systems without /proc.
+func StartTime(pid int) string {
+	boot := bootID()
+	if boot == "" || pid <= 0 {
+		return ""
+	}
+	data, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return ""
+	}
+	// The name of the command is in parentheses and may contain spaces, so
+	// the fields are counted from the last parenthesis. The start time is
+	// the 22nd field, in clock ticks after the boot.
+	stat := string(data)
+	i := strings.LastIndexByte(stat, ')')
+	if i < 0 {
+		return ""
+	}
+	fields := strings.Fields(stat[i+1:])
+	if len(fields) < 20 {
+		return ""
+	}
+	return boot + ":" + fields[19]
+}
@@ -0,0 +1,35 @@
+package procutil
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartTime(t *testing.T) {
+	if bootID() == "" {
+		t.Skip("the start time of the processes is not available")
+	}
+
+	t.Run("Self", func(t *testing.T) {
+		start := StartTime(os.Getpid())
+		require.NotEmpty(t, start)
+		require.Equal(t, start, StartTime(os.Getpid()))
+	})
+	t.Run("OtherProcess", func(t *testing.T) {
+		cmd := exec.Command("sleep", "1")
+		require.NoError(t, cmd.Start())
+
+		require.NotEmpty(t, StartTime(cmd.Process.Pid))
+
+		require.NoError(t, cmd.Process.Kill())
+		_ = cmd.Wait()
+		require.Empty(t, StartTime(cmd.Process.Pid))
+	})
+	t.Run("NotExists", func(t *testing.T) {
+		require.Empty(t, StartTime(-1))
+		require.Empty(t, StartTime(1<<30))
+	})
+}
@@ -0,0 +1,272 @@
+// Package queue implements the persistent queue of the starts of the DAGs
+// waiting for the running runs to finish.
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dagu-org/dagu/internal/fileutil"
+)
+
+var (
+	ErrItemNotFound   = errors.New("queued item not found")
+	ErrInvalidDAGName = errors.New("invalid DAG name of the queue")
+)
+
+// Item is a start of a DAG in the queue.
+type Item struct {
+	// ID is the unique ID of the item.
+	ID string `json:"id"`
+	// DAG is the name of the DAG file without the extension.
+	DAG string `json:"dag"`
+	// Trigger is what started the DAG: schedule or manual.
+	Trigger Trigger `json:"trigger"`
+	// Params is the parameters of the run.
+	Params string `json:"params,omitempty"`
+	// Steps is the steps to run. All the steps run if it is empty.
+	Steps []string `json:"steps,omitempty"`
+	// Downstream runs the downstream steps of the steps too.
+	Downstream bool `json:"downstream,omitempty"`
+	// LogicalDate is the scheduled time of the run started by the schedule.
+	LogicalDate time.Time `json:"logicalDate,omitempty"`
+	// EnqueuedAt is the time the item was queued.
+	EnqueuedAt time.Time `json:"enqueuedAt"`
+	// DequeuedAt is the time the item was taken out of the queue. It is
+	// set only for the items in flight.
+	DequeuedAt time.Time `json:"dequeuedAt,omitempty"`
+}
+
+// Trigger is what queued the start of the DAG.
+type Trigger string
+
+const (
+	TriggerSchedule Trigger = "schedule"
+	TriggerManual   Trigger = "manual"
+)
+
+// Store is a FIFO queue per DAG kept on disk, shared by the scheduler that
+// drains it and the web server that queues and cancels the items.
+//
+// Each item is a file <dir>/<DAG>/<enqueued at in nanoseconds>_<ID>.json, so
+// that the files sorted by name are in the order they were queued. An item
+// is taken out of the queue by renaming its file to the in-flight file with
+// the extension .inflight, or cancelled by removing it, which succeeds for
+// only one of the processes. The in-flight file is removed by Done once the
+// run has started, or renamed back by Requeue if the process that took the
+// item stopped before it started the run, so that no item is lost.
+type Store struct {
+	dir string
+}
+
+// New creates a new Store in the given directory.
+func New(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Enqueue adds the item to the end of the queue of its DAG and returns it
+// with the ID and the time it was queued.
+func (s *Store) Enqueue(_ context.Context, item Item) (Item, error) {
+	if item.DAG == "" {
+		return Item{}, errors.New("the DAG of the queued item is required")
+	}
+	dir, err := s.dagDir(item.DAG)
+	if err != nil {
+		return Item{}, err
+	}
+	item.ID = uuid.NewString()
+	item.EnqueuedAt = time.Now()
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return Item{}, fmt.Errorf("failed to marshal queued item: %w", err)
+	}
+	name := fmt.Sprintf("%020d_%s%s", item.EnqueuedAt.UnixNano(), item.ID, queuedExt)
+	if err := fileutil.WriteFileAtomic(filepath.Join(dir, name), data); err != nil {
+		return Item{}, fmt.Errorf("failed to write queued item: %w", err)
+	}
+	return item, nil
+}
+
+// List returns the items in the queue of the DAG in the order they run.
+func (s *Store) List(_ context.Context, dag string) ([]Item, error) {
+	paths, err := s.paths(dag, queuedExt)
+	if err != nil {
+		return nil, err
+	}
+	return readItems(paths)
+}
+
+// InFlight returns the items of the DAG taken out of the queue and not done.
+func (s *Store) InFlight(_ context.Context, dag string) ([]Item, error) {
+	paths, err := s.paths(dag, inFlightExt)
+	if err != nil {
+		return nil, err
+	}
+	return readItems(paths)
+}
+
+// Dequeue takes the first item out of the queue of the DAG and keeps it in
+// flight until Done or Requeue is called. It returns nil if the queue is
+// empty.
+func (s *Store) Dequeue(_ context.Context, dag string) (*Item, error) {
+	paths, err := s.paths(dag, queuedExt)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		inFlight := strings.TrimSuffix(path, queuedExt) + inFlightExt
+		if err := os.Rename(path, inFlight); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// Cancelled or taken by another process.
+				continue
+			}
+			return nil, fmt.Errorf("failed to dequeue item: %w", err)
+		}
+		item, err := readItem(inFlight)
+		if err != nil {
+			return nil, err
+		}
+		item.DequeuedAt = time.Now()
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal queued item: %w", err)
+		}
+		if err := fileutil.WriteFileAtomic(inFlight, data); err != nil {
+			return nil, fmt.Errorf("failed to write queued item: %w", err)
+		}
+		return &item, nil
+	}
+	return nil, nil
+}
+
+// Done removes the item in flight once its run has started.
+func (s *Store) Done(_ context.Context, dag, id string) error {
+	path, err := s.find(dag, id, inFlightExt)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrItemNotFound
+		}
+		return fmt.Errorf("failed to remove the item in flight: %w", err)
+	}
+	return nil
+}
+
+// Requeue puts the item in flight back to its place in the queue.
+func (s *Store) Requeue(_ context.Context, dag, id string) error {
+	path, err := s.find(dag, id, inFlightExt)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(path, strings.TrimSuffix(path, inFlightExt)+queuedExt); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrItemNotFound
+		}
+		return fmt.Errorf("failed to requeue item: %w", err)
+	}
+	return nil
+}
+
+// Cancel removes the item from the queue of the DAG. The items in flight
+// cannot be cancelled.
+func (s *Store) Cancel(_ context.Context, dag, id string) error {
+	path, err := s.find(dag, id, queuedExt)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrItemNotFound
+		}
+		return fmt.Errorf("failed to cancel queued item: %w", err)
+	}
+	return nil
+}
+
+const (
+	queuedExt   = ".json"
+	inFlightExt = ".inflight"
+)
+
+// find returns the file of the item with the extension.
+func (s *Store) find(dag, id, ext string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\*?[`) {
+		return "", ErrItemNotFound
+	}
+	dir, err := s.dagDir(dag)
+	if err != nil {
+		return "", err
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, "*_"+id+ext))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", ErrItemNotFound
+	}
+	return matches[0], nil
+}
+
+// paths returns the files of the items of the DAG with the extension in
+// the order they were queued.
+func (s *Store) paths(dag, ext string) ([]string, error) {
+	dir, err := s.dagDir(dag)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+ext))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// dagDir returns the directory of the queue of the DAG. The name must not
+// point outside the directory of the store.
+func (s *Store) dagDir(dag string) (string, error) {
+	if dag == "" || dag == "." || strings.Contains(dag, "..") || strings.ContainsAny(dag, `/\`) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidDAGName, dag)
+	}
+	return filepath.Join(s.dir, dag), nil
+}
+
+func readItems(paths []string) ([]Item, error) {
+	var ret []Item
+	for _, path := range paths {
+		item, err := readItem(path)
+		if errors.Is(err, os.ErrNotExist) {
+			// Taken out of the queue in the meantime.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, item)
+	}
+	return ret, nil
+}
+
+func readItem(path string) (Item, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Item{}, err
+	}
+	var item Item
+	if err := json.Unmarshal(data, &item); err != nil {
+		return Item{}, fmt.Errorf("failed to unmarshal queued item %s: %w", path, err)
+	}
+	return item, nil
+}
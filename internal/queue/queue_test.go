@@ -0,0 +1,116 @@
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	t.Run("FIFO", func(t *testing.T) {
+		store := New(t.TempDir())
+		ctx := context.Background()
+
+		item, err := store.Dequeue(ctx, "dag")
+		require.NoError(t, err)
+		require.Nil(t, item)
+
+		first, err := store.Enqueue(ctx, Item{DAG: "dag", Trigger: TriggerManual, Params: "x=1"})
+		require.NoError(t, err)
+		require.NotEmpty(t, first.ID)
+		require.False(t, first.EnqueuedAt.IsZero())
+		second, err := store.Enqueue(ctx, Item{DAG: "dag", Trigger: TriggerSchedule})
+		require.NoError(t, err)
+		_, err = store.Enqueue(ctx, Item{DAG: "other", Trigger: TriggerSchedule})
+		require.NoError(t, err)
+
+		items, err := store.List(ctx, "dag")
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		require.Equal(t, first.ID, items[0].ID)
+		require.Equal(t, "x=1", items[0].Params)
+		require.Equal(t, second.ID, items[1].ID)
+
+		item, err = store.Dequeue(ctx, "dag")
+		require.NoError(t, err)
+		require.Equal(t, first.ID, item.ID)
+		item, err = store.Dequeue(ctx, "dag")
+		require.NoError(t, err)
+		require.Equal(t, second.ID, item.ID)
+		item, err = store.Dequeue(ctx, "dag")
+		require.NoError(t, err)
+		require.Nil(t, item)
+
+		items, err = store.List(ctx, "other")
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+	})
+	t.Run("Cancel", func(t *testing.T) {
+		store := New(t.TempDir())
+		ctx := context.Background()
+
+		first, err := store.Enqueue(ctx, Item{DAG: "dag", Trigger: TriggerManual})
+		require.NoError(t, err)
+		second, err := store.Enqueue(ctx, Item{DAG: "dag", Trigger: TriggerManual})
+		require.NoError(t, err)
+
+		require.NoError(t, store.Cancel(ctx, "dag", first.ID))
+		require.ErrorIs(t, store.Cancel(ctx, "dag", first.ID), ErrItemNotFound)
+		require.ErrorIs(t, store.Cancel(ctx, "dag", "*"), ErrItemNotFound)
+
+		item, err := store.Dequeue(ctx, "dag")
+		require.NoError(t, err)
+		require.Equal(t, second.ID, item.ID)
+	})
+	t.Run("InFlight", func(t *testing.T) {
+		store := New(t.TempDir())
+		ctx := context.Background()
+
+		first, err := store.Enqueue(ctx, Item{DAG: "dag", Trigger: TriggerManual})
+		require.NoError(t, err)
+		second, err := store.Enqueue(ctx, Item{DAG: "dag", Trigger: TriggerManual})
+		require.NoError(t, err)
+
+		// The item taken out of the queue is kept in flight.
+		item, err := store.Dequeue(ctx, "dag")
+		require.NoError(t, err)
+		require.Equal(t, first.ID, item.ID)
+		require.False(t, item.DequeuedAt.IsZero())
+		inFlight, err := store.InFlight(ctx, "dag")
+		require.NoError(t, err)
+		require.Len(t, inFlight, 1)
+		require.Equal(t, first.ID, inFlight[0].ID)
+		require.ErrorIs(t, store.Cancel(ctx, "dag", first.ID), ErrItemNotFound)
+
+		// The requeued item is back at the head of the queue.
+		require.NoError(t, store.Requeue(ctx, "dag", first.ID))
+		items, err := store.List(ctx, "dag")
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		require.Equal(t, first.ID, items[0].ID)
+		require.Equal(t, second.ID, items[1].ID)
+
+		item, err = store.Dequeue(ctx, "dag")
+		require.NoError(t, err)
+		require.Equal(t, first.ID, item.ID)
+		require.NoError(t, store.Done(ctx, "dag", first.ID))
+		require.ErrorIs(t, store.Done(ctx, "dag", first.ID), ErrItemNotFound)
+		inFlight, err = store.InFlight(ctx, "dag")
+		require.NoError(t, err)
+		require.Empty(t, inFlight)
+	})
+	t.Run("InvalidDAGName", func(t *testing.T) {
+		store := New(t.TempDir())
+		ctx := context.Background()
+
+		for _, name := range []string{"", "..", "../dag", "dir/dag", `dir\dag`} {
+			_, err := store.Enqueue(ctx, Item{DAG: name, Trigger: TriggerManual})
+			require.Error(t, err, name)
+			_, err = store.List(ctx, name)
+			require.ErrorIs(t, err, ErrInvalidDAGName, name)
+			_, err = store.Dequeue(ctx, name)
+			require.ErrorIs(t, err, ErrInvalidDAGName, name)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+// Package calendar implements the exclusion calendars defined in the config,
+// such as holidays and blackout windows, which the DAGs reference to skip
+// their scheduled runs.
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/config"
+	"github.com/dagu-org/dagu/internal/digraph"
+)
+
+var _ digraph.Calendar = (*Calendar)(nil)
+
+// dateLayout is the layout of the excluded dates.
+const dateLayout = "2006-01-02"
+
+// windowLayouts are the layouts accepted for the start and end of the
+// windows. The times without an offset are in the time zone of the calendar.
+var windowLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04", dateLayout}
+
+// Calendar excludes the days and the windows from the schedules.
+type Calendar struct {
+	Name string
+	// periods are the excluded periods sorted by the start, merged so that
+	// they do not overlap.
+	periods []period
+}
+
+type period struct {
+	start, end time.Time
+}
+
+// New creates a calendar from the config. The dates and the windows are in
+// the time zone of the calendar, or loc if it is not specified.
+func New(name string, cfg config.CalendarConfig, loc *time.Location) (*Calendar, error) {
+	if cfg.Timezone != "" {
+		var err error
+		loc, err = time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("calendar %q: invalid timezone: %w", name, err)
+		}
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+
+	var periods []period
+	for _, date := range cfg.Dates {
+		day, err := time.ParseInLocation(dateLayout, date, loc)
+		if err != nil {
+			return nil, fmt.Errorf("calendar %q: invalid date %q: %w", name, date, err)
+		}
+		// The end of the day is the next midnight, which is not always
+		// 24 hours later on the days of the daylight saving time changes.
+		periods = append(periods, period{start: day, end: day.AddDate(0, 0, 1)})
+	}
+	for _, w := range cfg.Windows {
+		start, err := parseTime(w.Start, loc)
+		if err != nil {
+			return nil, fmt.Errorf("calendar %q: invalid window start %q: %w", name, w.Start, err)
+		}
+		end, err := parseTime(w.End, loc)
+		if err != nil {
+			return nil, fmt.Errorf("calendar %q: invalid window end %q: %w", name, w.End, err)
+		}
+		if !end.After(start) {
+			return nil, fmt.Errorf("calendar %q: window end %q must be after start %q", name, w.End, w.Start)
+		}
+		periods = append(periods, period{start: start, end: end})
+	}
+
+	return &Calendar{Name: name, periods: merge(periods)}, nil
+}
+
+// ExcludedUntil implements digraph.Calendar.
+func (c *Calendar) ExcludedUntil(t time.Time) time.Time {
+	// Find the last period starting at or before t.
+	i := sort.Search(len(c.periods), func(i int) bool {
+		return c.periods[i].start.After(t)
+	}) - 1
+	if i < 0 || !t.Before(c.periods[i].end) {
+		return time.Time{}
+	}
+	return c.periods[i].end
+}
+
+func parseTime(value string, loc *time.Location) (time.Time, error) {
+	var err error
+	for _, layout := range windowLayouts {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// merge sorts the periods and merges the overlapping and adjacent ones, so
+// that a time is in at most one period.
+func merge(periods []period) []period {
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].start.Before(periods[j].start)
+	})
+	var ret []period
+	for _, p := range periods {
+		if n := len(ret); n > 0 && !p.start.After(ret[n-1].end) {
+			if p.end.After(ret[n-1].end) {
+				ret[n-1].end = p.end
+			}
+			continue
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
+
+// Registry holds the calendars defined in the config by name.
+type Registry struct {
+	calendars map[string]*Calendar
+}
+
+// NewRegistry creates the calendars defined in the config. The calendars
+// without a time zone are in loc.
+func NewRegistry(cfgs map[string]config.CalendarConfig, loc *time.Location) (*Registry, error) {
+	r := &Registry{calendars: make(map[string]*Calendar, len(cfgs))}
+	for name, cfg := range cfgs {
+		c, err := New(name, cfg, loc)
+		if err != nil {
+			return nil, err
+		}
+		r.calendars[strings.ToLower(name)] = c
+	}
+	return r, nil
+}
+
+// Resolve returns the calendars of the names, which are case-insensitive
+// like the other keys of the config. It returns an error if a calendar is
+// not defined.
+func (r *Registry) Resolve(names []string) ([]digraph.Calendar, error) {
+	var ret []digraph.Calendar
+	for _, name := range names {
+		var c *Calendar
+		if r != nil {
+			c = r.calendars[strings.ToLower(name)]
+		}
+		if c == nil {
+			return nil, fmt.Errorf("calendar %q is not defined", name)
+		}
+		ret = append(ret, c)
+	}
+	return ret, nil
+}
@@ -0,0 +1,73 @@
+package calendar_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/config"
+	"github.com/dagu-org/dagu/internal/scheduler/calendar"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendar(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	c, err := calendar.New("holidays", config.CalendarConfig{
+		Timezone: "Asia/Tokyo",
+		Dates:    []string{"2025-01-01", "2025-01-02"},
+		Windows: []config.CalendarWindow{
+			{Start: "2025-01-02T20:00", End: "2025-01-03T06:00"},
+			{Start: "2025-02-01T00:00:00Z", End: "2025-02-01T01:00:00Z"},
+		},
+	}, time.UTC)
+	require.NoError(t, err)
+
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2025, month, day, hour, 0, 0, 0, loc)
+	}
+
+	t.Run("Dates", func(t *testing.T) {
+		require.True(t, c.ExcludedUntil(at(time.December, 31, 23)).IsZero())
+		// The consecutive days and the overlapping window are merged.
+		require.Equal(t, at(time.January, 3, 6), c.ExcludedUntil(at(time.January, 1, 0)))
+		require.Equal(t, at(time.January, 3, 6), c.ExcludedUntil(at(time.January, 2, 12)))
+		require.True(t, c.ExcludedUntil(at(time.January, 3, 6)).IsZero())
+	})
+	t.Run("WindowWithOffset", func(t *testing.T) {
+		start := time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
+		require.Equal(t, start.Add(time.Hour), c.ExcludedUntil(start.Add(30*time.Minute)))
+		require.True(t, c.ExcludedUntil(start.Add(-time.Second)).IsZero())
+	})
+	t.Run("InvalidConfig", func(t *testing.T) {
+		_, err := calendar.New("invalid", config.CalendarConfig{Dates: []string{"2025/01/01"}}, time.UTC)
+		require.Error(t, err)
+
+		_, err = calendar.New("invalid", config.CalendarConfig{Timezone: "Invalid/Zone"}, time.UTC)
+		require.Error(t, err)
+
+		_, err = calendar.New("invalid", config.CalendarConfig{
+			Windows: []config.CalendarWindow{{Start: "2025-01-02", End: "2025-01-01"}},
+		}, time.UTC)
+		require.Error(t, err)
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	registry, err := calendar.NewRegistry(map[string]config.CalendarConfig{
+		"holidays": {Dates: []string{"2025-01-01"}},
+	}, time.UTC)
+	require.NoError(t, err)
+
+	calendars, err := registry.Resolve([]string{"Holidays"})
+	require.NoError(t, err)
+	require.Len(t, calendars, 1)
+
+	_, err = registry.Resolve([]string{"undefined"})
+	require.Error(t, err)
+
+	_, err = calendar.NewRegistry(map[string]config.CalendarConfig{
+		"invalid": {Dates: []string{"invalid"}},
+	}, time.UTC)
+	require.Error(t, err)
+}
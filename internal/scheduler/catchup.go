@@ -0,0 +1,159 @@
+package scheduler
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/google/uuid"
+)
+
+// catchupInterval is the minimum interval between the starts of the missed
+// runs of all the DAGs, so that the scheduler does not start a burst of runs
+// after a long downtime.
+var catchupInterval = 10 * time.Second
+
+// maxCatchupRuns is the maximum number of the missed runs of a DAG started
+// on startup.
+const maxCatchupRuns = 100
+
+// maxMissedSlots bounds the scheduled times checked for the missed runs,
+// e.g. about two months of a DAG scheduled every minute.
+const maxMissedSlots = 100000
+
+// missedRun is a scheduled run of a DAG missed while the scheduler was not
+// running.
+type missedRun struct {
+	dag         *digraph.DAG
+	logicalDate time.Time
+}
+
+// catchup starts the runs missed since the last recorded runs of the DAGs
+// with a catch-up policy. The runs of a DAG are started one after another
+// in the order of the scheduled times.
+func (m *dagJobManager) catchup(ctx context.Context, done chan any) {
+	limiter := time.NewTicker(catchupInterval)
+	defer limiter.Stop()
+
+	var wg sync.WaitGroup
+	for _, runs := range m.missedRuns(ctx) {
+		wg.Add(1)
+		go func(runs []missedRun) {
+			defer wg.Done()
+			for _, run := range runs {
+				select {
+				case <-done:
+					return
+				case <-limiter.C:
+				}
+				m.startMissedRun(ctx, done, run)
+			}
+		}(runs)
+	}
+	wg.Wait()
+}
+
+// missedRuns returns the missed runs of the DAGs in the registry to start
+// according to their catch-up policies.
+func (m *dagJobManager) missedRuns(ctx context.Context) [][]missedRun {
+	m.lock.Lock()
+	dags := make([]*digraph.DAG, 0, len(m.registry))
+	for _, dag := range m.registry {
+		if dag.Catchup == digraph.CatchupLatest || dag.Catchup == digraph.CatchupAll {
+			dags = append(dags, dag)
+		}
+	}
+	m.lock.Unlock()
+
+	var ret [][]missedRun
+	for _, dag := range dags {
+		dagName := strings.TrimSuffix(filepath.Base(dag.Location), filepath.Ext(dag.Location))
+		if m.client.IsSuspended(ctx, dagName) {
+			continue
+		}
+		slots := m.missedSlots(ctx, dag)
+		if len(slots) == 0 {
+			continue
+		}
+		if dag.Catchup == digraph.CatchupLatest {
+			slots = slots[len(slots)-1:]
+		}
+		logger.Info(ctx, "Catching up missed runs", "DAG", dag.Name, "policy", dag.Catchup, "runs", len(slots))
+		runs := make([]missedRun, 0, len(slots))
+		for _, slot := range slots {
+			runs = append(runs, missedRun{dag: dag, logicalDate: slot})
+		}
+		ret = append(ret, runs)
+	}
+	return ret
+}
+
+// missedSlots returns the scheduled times of the DAG after its last recorded
+// run and before the current minute, which is run on schedule as usual.
+// A DAG that has never run has no missed slots.
+func (m *dagJobManager) missedSlots(ctx context.Context, dag *digraph.DAG) []time.Time {
+	recent := m.client.GetRecentHistory(ctx, dag, 1)
+	if len(recent) == 0 {
+		return nil
+	}
+	status := recent[0].Status
+	last, err := stringutil.ParseTime(status.LogicalDate)
+	if err != nil || last.IsZero() {
+		last, err = stringutil.ParseTime(status.StartedAt)
+	}
+	if err != nil || last.IsZero() {
+		return nil
+	}
+
+	to := m.startedAt.Truncate(time.Minute).Add(-time.Nanosecond)
+	slots := dag.ScheduledTimes(last, to, maxMissedSlots, m.excludedCalendars(dag)...)
+	if len(slots) > maxCatchupRuns {
+		logger.Warn(ctx, "Too many missed runs; only the latest ones are caught up", "DAG", dag.Name, "missed", len(slots), "max", maxCatchupRuns)
+		slots = slots[len(slots)-maxCatchupRuns:]
+	}
+	return slots
+}
+
+// startMissedRun starts the missed run once the DAG is running fewer runs
+// than it allows. The request ID is derived from the scheduled time, so that a
+// missed run is started only once.
+func (m *dagJobManager) startMissedRun(ctx context.Context, done chan any, run missedRun) {
+	for {
+		if full, err := IsRunningMax(ctx, m.client, run.dag); err != nil || !full {
+			break
+		}
+		select {
+		case <-done:
+			return
+		case <-time.After(catchupInterval):
+		}
+	}
+
+	requestID := ScheduledRequestID(run.dag.Name, run.logicalDate)
+	if _, err := m.client.GetStatusByRequestID(ctx, run.dag, requestID); err == nil {
+		// already started
+		return
+	}
+
+	logger.Info(ctx, "Starting missed run", "DAG", run.dag.Name, "logicalDate", run.logicalDate, "requestID", requestID)
+	if err := m.client.Start(ctx, run.dag, client.StartOptions{
+		Quiet:       true,
+		RequestID:   requestID,
+		LogicalDate: run.logicalDate,
+	}); err != nil {
+		logger.Error(ctx, "Missed run failed", "DAG", run.dag.Name, "logicalDate", run.logicalDate, "err", err)
+	}
+}
+
+// ScheduledRequestID returns the request ID of the run of the DAG for the
+// scheduled time. It is used for the scheduled, caught-up and backfilled
+// runs.
+func ScheduledRequestID(dagName string, logicalDate time.Time) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(dagName+"@"+logicalDate.UTC().Format(time.RFC3339))).String()
+}
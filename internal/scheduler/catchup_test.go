@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence/jsondb"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatchup(t *testing.T) {
+	t.Run("MissedRuns", func(t *testing.T) {
+		th := setupTest(t)
+		ctx := context.Background()
+
+		manager := th.manager.(*dagJobManager)
+		require.NoError(t, manager.initialize(ctx))
+		slot := time.Now().Truncate(time.Hour)
+		manager.startedAt = slot.Add(30 * time.Minute)
+
+		// The last runs were 3 hours ago; three hourly runs are missed.
+		lastRun := slot.Add(-2*time.Hour - 30*time.Minute)
+		historyStore := jsondb.New(th.config.Paths.DataDir)
+		for _, name := range []string{"catchup_all.yaml", "catchup_latest.yaml", "scheduled_job.yaml"} {
+			dag := manager.registry[name]
+			require.NotNil(t, dag)
+			status := model.NewStatusFactory(dag).Create("req-"+dag.Name, scheduler.StatusSuccess, 0, lastRun,
+				model.WithFinishedAt(lastRun))
+			require.NoError(t, historyStore.Open(ctx, dag.Location, lastRun, status.RequestID))
+			require.NoError(t, historyStore.Write(ctx, status))
+			require.NoError(t, historyStore.Close(ctx))
+		}
+
+		missed := make(map[string][]time.Time)
+		for _, runs := range manager.missedRuns(ctx) {
+			for _, run := range runs {
+				missed[run.dag.Name] = append(missed[run.dag.Name], run.logicalDate.UTC())
+			}
+		}
+
+		slot = slot.UTC()
+		require.Equal(t, map[string][]time.Time{
+			"catchup_all":    {slot.Add(-2 * time.Hour), slot.Add(-time.Hour), slot},
+			"catchup_latest": {slot},
+		}, missed)
+	})
+	t.Run("NeverRun", func(t *testing.T) {
+		th := setupTest(t)
+		ctx := context.Background()
+
+		manager := th.manager.(*dagJobManager)
+		require.NoError(t, manager.initialize(ctx))
+		manager.startedAt = time.Now()
+
+		require.Empty(t, manager.missedRuns(ctx))
+	})
+	t.Run("ScheduledRequestID", func(t *testing.T) {
+		logicalDate := time.Date(2025, 1, 1, 2, 0, 0, 0, time.UTC)
+		require.Equal(t,
+			ScheduledRequestID("etl", logicalDate),
+			ScheduledRequestID("etl", logicalDate.In(time.FixedZone("JST", 9*60*60))))
+		require.NotEqual(t,
+			ScheduledRequestID("etl", logicalDate),
+			ScheduledRequestID("etl", logicalDate.Add(time.Hour)))
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
 	"github.com/dagu-org/dagu/internal/logger"
 	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/queue"
 	"github.com/dagu-org/dagu/internal/stringutil"
 	"github.com/robfig/cron/v3"
 )
@@ -21,6 +22,7 @@ var (
 	ErrJobFinished     = errors.New("job already finished")
 	ErrJobSkipped      = errors.New("job skipped")
 	ErrJobSuccess      = errors.New("job already successful")
+	ErrJobQueued       = errors.New("job queued")
 )
 
 var _ Job = (*dagJob)(nil)
@@ -32,6 +34,10 @@ type dagJob struct {
 	Next       time.Time
 	Schedule   cron.Schedule
 	Client     client.Client
+	// Queue is the queue of the starts of the DAG while it is running.
+	Queue *queue.Store
+	// RecordLag records the delay between Next and the start of the run.
+	RecordLag func(ctx context.Context, dag *digraph.DAG, lag time.Duration)
 }
 
 // GetDAG returns the DAG associated with this job.
@@ -41,14 +47,31 @@ func (job *dagJob) GetDAG(_ context.Context) *digraph.DAG {
 
 // Start attempts to run the job if it is not already running and is ready.
 func (job *dagJob) Start(ctx context.Context) error {
-	latestStatus, err := job.Client.GetLatestStatus(ctx, job.DAG)
+	// Guard against running more runs than the DAG allows at a time.
+	full, err := IsRunningMax(ctx, job.Client, job.DAG)
 	if err != nil {
 		return err
 	}
+	if full {
+		switch job.DAG.Concurrency.OnConflict {
+		case digraph.OnConflictQueue:
+			return job.enqueue(ctx)
 
-	// Guard against already running jobs.
-	if latestStatus.Status == scheduler.StatusRunning {
-		return ErrJobRunning
+		case digraph.OnConflictCancelPrevious:
+			logger.Info(ctx, "stopping the previous runs", "job", job.DAG.Name)
+			if err := StopRunning(ctx, job.Client, job.DAG); err != nil {
+				return err
+			}
+
+		default:
+			return ErrJobRunning
+
+		}
+	}
+
+	latestStatus, err := job.Client.GetLatestStatus(ctx, job.DAG)
+	if err != nil {
+		return err
 	}
 
 	// Check if the job is ready to start.
@@ -57,13 +80,50 @@ func (job *dagJob) Start(ctx context.Context) error {
 	}
 
 	// Job is ready; proceed to start.
-	return job.Client.Start(ctx, job.DAG, client.StartOptions{Quiet: true})
+	if job.RecordLag != nil {
+		job.RecordLag(ctx, job.DAG, time.Since(job.Next))
+	}
+	// The run is for the scheduled time like the caught-up and backfilled
+	// runs, so that a backfill skips it.
+	return job.Client.Start(ctx, job.DAG, client.StartOptions{
+		Quiet:       true,
+		RequestID:   ScheduledRequestID(job.DAG.Name, job.Next),
+		LogicalDate: job.Next,
+	})
+}
+
+// IsRunningMax returns true if the DAG is running as many runs as its
+// concurrency allows.
+func IsRunningMax(ctx context.Context, cli client.Client, dag *digraph.DAG) (bool, error) {
+	active, err := cli.GetActiveRuns(ctx, dag)
+	if err != nil {
+		return false, err
+	}
+	return len(active) >= dag.Concurrency.Limit(), nil
+}
+
+// enqueue queues the start until a running run finishes.
+func (job *dagJob) enqueue(ctx context.Context) error {
+	if job.Queue == nil {
+		return ErrJobRunning
+	}
+	item, err := job.Queue.Enqueue(ctx, queue.Item{
+		DAG:         dagName(job.DAG),
+		Trigger:     queue.TriggerSchedule,
+		LogicalDate: job.Next,
+	})
+	if err != nil {
+		return err
+	}
+	logger.Info(ctx, "queued the start while the job is running", "job", job.DAG.Name, "id", item.ID)
+	return ErrJobQueued
 }
 
 // ready checks whether the job can be safely started based on the latest status.
 func (job *dagJob) ready(ctx context.Context, latestStatus model.Status) error {
-	// Prevent starting if it's already running.
-	if latestStatus.Status == scheduler.StatusRunning {
+	// Prevent starting if it's already running, unless the DAG allows the
+	// runs to overlap, which Start checks.
+	if latestStatus.Status.IsActive() && job.DAG.Concurrency.Limit() == 1 {
 		return ErrJobRunning
 	}
 
@@ -75,7 +135,7 @@ func (job *dagJob) ready(ctx context.Context, latestStatus model.Status) error {
 	}
 
 	// Skip if the last successful run time is on or after the next scheduled time.
-	latestStartedAt = latestStartedAt.Truncate(time.Minute)
+	latestStartedAt = latestStartedAt.Truncate(time.Second)
 	if latestStartedAt.After(job.Next) || job.Next.Equal(latestStartedAt) {
 		return ErrJobFinished
 	}
@@ -115,7 +175,7 @@ func (job *dagJob) Stop(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	if latestStatus.Status != scheduler.StatusRunning {
+	if !latestStatus.Status.IsActive() {
 		return ErrJobIsNotRunning
 	}
 	return job.Client.Stop(ctx, job.DAG)
@@ -0,0 +1,155 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/logger"
+)
+
+// Elector acquires and renews the lease on behalf of a scheduler.
+type Elector struct {
+	backend   Backend
+	id        string
+	leaseTime time.Duration
+
+	mu    sync.Mutex
+	token uint64
+	// expiresAt is when the lease expires by the local clock. It is measured
+	// from before the acquisition, so that the elector gives up the
+	// leadership before the other schedulers see the lease expired.
+	expiresAt time.Time
+}
+
+// NewElector creates a new Elector. The ID identifies the scheduler in the
+// lease; it defaults to the hostname and the process ID.
+func NewElector(backend Backend, id string, leaseTime time.Duration) *Elector {
+	if id == "" {
+		id = DefaultID()
+	}
+	return &Elector{backend: backend, id: id, leaseTime: leaseTime}
+}
+
+// DefaultID returns the ID of the scheduler made of the hostname and the
+// process ID.
+func DefaultID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
+}
+
+// ID returns the ID of the scheduler.
+func (e *Elector) ID() string {
+	return e.id
+}
+
+// Run acquires the lease and renews it every third of the lease time until
+// the context is canceled or done is closed. The lease is released when it
+// returns, so that a standby takes over without waiting for the expiry.
+func (e *Elector) Run(ctx context.Context, done <-chan struct{}) {
+	ticker := time.NewTicker(e.leaseTime / 3)
+	defer ticker.Stop()
+
+	for {
+		e.tryAcquire(ctx)
+
+		select {
+		case <-done:
+			e.release(ctx)
+			return
+
+		case <-ctx.Done():
+			e.release(ctx)
+			return
+
+		case <-ticker.C:
+
+		}
+	}
+}
+
+// IsLeader returns true if the scheduler holds the lease by the local clock.
+func (e *Elector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.token != 0 && time.Now().Before(e.expiresAt)
+}
+
+// Token returns the fencing token of the lease held by the scheduler, or
+// zero if it does not hold the lease.
+func (e *Elector) Token() uint64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.token
+}
+
+// Verify checks the lease in the backend to confirm that the scheduler is
+// still the leader. It returns false if the lease has been acquired by
+// another scheduler since, which is detected by the fencing token.
+func (e *Elector) Verify(ctx context.Context) bool {
+	token := e.Token()
+	if token == 0 || !e.IsLeader() {
+		return false
+	}
+	lease, err := e.backend.Get(ctx)
+	if err != nil {
+		logger.Error(ctx, "Failed to read the lease", "err", err)
+		return false
+	}
+	return lease.Holder == e.id && lease.Token == token && lease.Held(time.Now())
+}
+
+func (e *Elector) tryAcquire(ctx context.Context) {
+	start := time.Now()
+	lease, err := e.backend.Acquire(ctx, e.id, e.leaseTime)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	wasLeader := e.token != 0 && start.Before(e.expiresAt)
+	switch {
+	case err != nil:
+		// Keep the leadership until the lease expires by the local clock.
+		logger.Error(ctx, "Failed to acquire the lease", "err", err)
+		return
+
+	case lease.Holder == e.id:
+		if !wasLeader || lease.Token != e.token {
+			logger.Info(ctx, "Became the leader", "id", e.id, "token", lease.Token)
+		}
+		e.token = lease.Token
+		e.expiresAt = start.Add(e.leaseTime)
+
+	default:
+		if wasLeader {
+			logger.Warn(ctx, "Lost the leadership", "id", e.id, "leader", lease.Holder, "token", lease.Token)
+		}
+		e.token = 0
+		e.expiresAt = time.Time{}
+	}
+}
+
+func (e *Elector) release(ctx context.Context) {
+	e.mu.Lock()
+	held := e.token != 0
+	e.token = 0
+	e.expiresAt = time.Time{}
+	e.mu.Unlock()
+
+	if !held {
+		return
+	}
+	// The context may be canceled already.
+	if err := e.backend.Release(context.WithoutCancel(ctx), e.id); err != nil {
+		logger.Error(ctx, "Failed to release the lease", "err", err)
+		return
+	}
+	logger.Info(ctx, "Released the leadership", "id", e.id)
+}
@@ -0,0 +1,69 @@
+package leader
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestElector(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Failover", func(t *testing.T) {
+		backend := NewFileBackend(filepath.Join(t.TempDir(), "leader.json"))
+		const leaseTime = 300 * time.Millisecond
+
+		first := NewElector(backend, "first", leaseTime)
+		firstDone := make(chan struct{})
+		firstStopped := make(chan struct{})
+		go func() {
+			first.Run(ctx, firstDone)
+			close(firstStopped)
+		}()
+		require.Eventually(t, first.IsLeader, time.Second, 10*time.Millisecond)
+		require.True(t, first.Verify(ctx))
+
+		second := NewElector(backend, "second", leaseTime)
+		secondDone := make(chan struct{})
+		secondStopped := make(chan struct{})
+		go func() {
+			second.Run(ctx, secondDone)
+			close(secondStopped)
+		}()
+		defer func() {
+			close(secondDone)
+			<-secondStopped
+		}()
+
+		// Only one of the electors is the leader.
+		time.Sleep(leaseTime)
+		require.True(t, first.IsLeader())
+		require.False(t, second.IsLeader())
+
+		// The standby takes over when the leader stops.
+		close(firstDone)
+		<-firstStopped
+		require.False(t, first.IsLeader())
+		require.Eventually(t, second.IsLeader, 2*leaseTime, 10*time.Millisecond)
+		require.True(t, second.Verify(ctx))
+		require.Greater(t, second.Token(), uint64(1))
+	})
+	t.Run("VerifyFencingToken", func(t *testing.T) {
+		backend := NewFileBackend(filepath.Join(t.TempDir(), "leader.json"))
+
+		elector := NewElector(backend, "a", time.Minute)
+		elector.tryAcquire(ctx)
+		require.True(t, elector.Verify(ctx))
+
+		// Another scheduler with the same ID takes the lease after a release;
+		// the token tells that the leadership has moved.
+		require.NoError(t, backend.Release(ctx, "a"))
+		_, err := backend.Acquire(ctx, "a", time.Minute)
+		require.NoError(t, err)
+		require.True(t, elector.IsLeader())
+		require.False(t, elector.Verify(ctx))
+	})
+}
@@ -0,0 +1,138 @@
+package leader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/dagu-org/dagu/internal/fileutil"
+)
+
+var _ Backend = (*FileBackend)(nil)
+
+// FileBackend stores the lease in a JSON file, e.g. on a storage shared by
+// the hosts running the schedulers. A flock on <file>.lock serializes the
+// operations between the processes.
+type FileBackend struct {
+	file string
+}
+
+// NewFileBackend creates a new FileBackend storing the lease in the file.
+func NewFileBackend(file string) *FileBackend {
+	return &FileBackend{file: file}
+}
+
+// Acquire implements Backend.
+func (b *FileBackend) Acquire(_ context.Context, holder string, leaseTime time.Duration) (Lease, error) {
+	unlock, err := b.lock()
+	if err != nil {
+		return Lease{}, err
+	}
+	defer unlock()
+
+	lease, err := b.read()
+	if err != nil {
+		return Lease{}, err
+	}
+
+	now := time.Now()
+	switch {
+	case lease.Holder == holder && lease.Held(now):
+		lease.RenewedAt = now
+		lease.ExpiresAt = now.Add(leaseTime)
+
+	case !lease.Held(now):
+		lease = Lease{
+			Holder:     holder,
+			Token:      lease.Token + 1,
+			AcquiredAt: now,
+			RenewedAt:  now,
+			ExpiresAt:  now.Add(leaseTime),
+		}
+
+	default:
+		// held by another scheduler
+		return lease, nil
+	}
+
+	if err := b.write(lease); err != nil {
+		return Lease{}, err
+	}
+	return lease, nil
+}
+
+// Release implements Backend.
+func (b *FileBackend) Release(_ context.Context, holder string) error {
+	unlock, err := b.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	lease, err := b.read()
+	if err != nil {
+		return err
+	}
+	if lease.Holder != holder {
+		return nil
+	}
+	// Keep the token so that the next holder gets a greater one.
+	return b.write(Lease{Token: lease.Token})
+}
+
+// Get implements Backend.
+func (b *FileBackend) Get(_ context.Context) (Lease, error) {
+	return b.read()
+}
+
+func (b *FileBackend) read() (Lease, error) {
+	data, err := os.ReadFile(b.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return Lease{}, nil
+	}
+	if err != nil {
+		return Lease{}, fmt.Errorf("failed to read the lease file: %w", err)
+	}
+	var lease Lease
+	if err := json.Unmarshal(data, &lease); err != nil {
+		return Lease{}, fmt.Errorf("failed to unmarshal the lease file %s: %w", b.file, err)
+	}
+	return lease, nil
+}
+
+// write writes the lease atomically so that the readers never see a
+// partially written lease.
+func (b *FileBackend) write(lease Lease) error {
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the lease: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(b.file, data); err != nil {
+		return fmt.Errorf("failed to write the lease file: %w", err)
+	}
+	return nil
+}
+
+func (b *FileBackend) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(b.file), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create the lease directory: %w", err)
+	}
+	file, err := os.OpenFile(b.file+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the lease lock file: %w", err)
+	}
+	if err := unix.Flock(int(file.Fd()), unix.LOCK_EX); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to lock the lease: %w", err)
+	}
+	return func() {
+		_ = unix.Flock(int(file.Fd()), unix.LOCK_UN)
+		_ = file.Close()
+	}, nil
+}
@@ -0,0 +1,72 @@
+package leader
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileBackend(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("AcquireAndRenew", func(t *testing.T) {
+		backend := NewFileBackend(filepath.Join(t.TempDir(), "scheduler", "leader.json"))
+
+		lease, err := backend.Acquire(ctx, "a", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, "a", lease.Holder)
+		require.Equal(t, uint64(1), lease.Token)
+
+		// The other scheduler cannot acquire the lease held by a.
+		lease, err = backend.Acquire(ctx, "b", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, "a", lease.Holder)
+
+		// Renewing keeps the token.
+		renewed, err := backend.Acquire(ctx, "a", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), renewed.Token)
+		require.False(t, renewed.ExpiresAt.Before(lease.ExpiresAt))
+
+		current, err := backend.Get(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "a", current.Holder)
+	})
+	t.Run("TakeOverExpired", func(t *testing.T) {
+		backend := NewFileBackend(filepath.Join(t.TempDir(), "leader.json"))
+
+		_, err := backend.Acquire(ctx, "a", time.Millisecond)
+		require.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+
+		lease, err := backend.Acquire(ctx, "b", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, "b", lease.Holder)
+		require.Equal(t, uint64(2), lease.Token)
+	})
+	t.Run("Release", func(t *testing.T) {
+		backend := NewFileBackend(filepath.Join(t.TempDir(), "leader.json"))
+
+		_, err := backend.Acquire(ctx, "a", time.Minute)
+		require.NoError(t, err)
+
+		// Releasing by the other scheduler does nothing.
+		require.NoError(t, backend.Release(ctx, "b"))
+		lease, err := backend.Get(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "a", lease.Holder)
+
+		require.NoError(t, backend.Release(ctx, "a"))
+		lease, err = backend.Get(ctx)
+		require.NoError(t, err)
+		require.False(t, lease.Held(time.Now()))
+
+		lease, err = backend.Acquire(ctx, "b", time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, "b", lease.Holder)
+		require.Equal(t, uint64(2), lease.Token)
+	})
+}
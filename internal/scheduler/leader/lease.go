@@ -0,0 +1,44 @@
+// Package leader elects one of the scheduler processes sharing the storage
+// as the leader, which is the only one that starts the scheduled runs.
+package leader
+
+import (
+	"context"
+	"time"
+)
+
+// Lease is the leadership of the schedulers. The leader renews the lease
+// before it expires; after that, another scheduler can acquire it.
+type Lease struct {
+	// Holder is the ID of the scheduler holding the lease. It is empty if
+	// the lease is released.
+	Holder string `json:"holder"`
+	// Token is the fencing token of the lease. It is incremented every time
+	// the lease is acquired by a scheduler, so that a former leader can tell
+	// that the leadership has moved even if it missed the expiry.
+	Token uint64 `json:"token"`
+	// AcquiredAt is the time the holder acquired the lease.
+	AcquiredAt time.Time `json:"acquiredAt"`
+	// RenewedAt is the time the holder renewed the lease last.
+	RenewedAt time.Time `json:"renewedAt"`
+	// ExpiresAt is the time the lease expires unless it is renewed.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Held returns true if the lease is held by a scheduler at the time.
+func (l Lease) Held(now time.Time) bool {
+	return l.Holder != "" && now.Before(l.ExpiresAt)
+}
+
+// Backend stores the lease shared by the schedulers. The operations must be
+// atomic across the processes using the same backend.
+type Backend interface {
+	// Acquire acquires the lease for the holder if it is not held, or
+	// renews it if the holder holds it. It returns the lease after the
+	// operation, which is held by another scheduler if it is not acquired.
+	Acquire(ctx context.Context, holder string, leaseTime time.Duration) (Lease, error)
+	// Release releases the lease if the holder holds it.
+	Release(ctx context.Context, holder string) error
+	// Get returns the current lease.
+	Get(ctx context.Context) (Lease, error)
+}
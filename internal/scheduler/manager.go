@@ -3,8 +3,9 @@ package scheduler
 import (
 	"context"
 	"fmt"
-	"os"
+	"io/fs"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -12,11 +13,11 @@ import (
 	"github.com/dagu-org/dagu/internal/client"
 	"github.com/dagu-org/dagu/internal/fileutil"
 	"github.com/dagu-org/dagu/internal/logger"
-	"github.com/dagu-org/dagu/internal/scheduler/filenotify"
+	"github.com/dagu-org/dagu/internal/queue"
+	"github.com/dagu-org/dagu/internal/scheduler/calendar"
 	"github.com/robfig/cron/v3"
 
 	"github.com/dagu-org/dagu/internal/digraph"
-	"github.com/fsnotify/fsnotify"
 )
 
 // JobManager is responsible for managing scheduled Jobs.
@@ -49,18 +50,80 @@ type dagJobManager struct {
 	client     client.Client
 	executable string
 	workDir    string
+	startedAt  time.Time
+	calendars  *calendar.Registry
+	baseConfig string
+	statusFile string
+	// dependencies maps the DAG files to the dotenv files they load.
+	dependencies map[string][]string
+	// loadErrors maps the DAG files failed to load to the errors.
+	loadErrors map[string]LoadError
+	queue      *queue.Store
+	// slaMissHandler fires the notification handlers of the SLA misses.
+	slaMissHandler SLAMissHandler
+	// lag is the delay of the starts of the runs per DAG.
+	lag map[string]*LagMetric
+	// slaMissCounts is the number of the SLA misses per DAG and SLA.
+	slaMissCounts map[slaMissCountKey]int64
+	// recentSLAMisses is the latest SLA misses, the newest first.
+	recentSLAMisses []SLAMiss
+	// triggering is the request IDs of the downstream runs being started.
+	triggering map[string]bool
+}
+
+// ManagerOption is a functional option for the DAG job manager.
+type ManagerOption func(*dagJobManager)
+
+// WithCalendars sets the exclusion calendars referenced by the DAGs.
+func WithCalendars(calendars *calendar.Registry) ManagerOption {
+	return func(m *dagJobManager) {
+		m.calendars = calendars
+	}
+}
+
+// WithBaseConfig sets the base config applied to the DAGs. The DAGs are
+// reloaded when it changes.
+func WithBaseConfig(baseConfig string) ManagerOption {
+	return func(m *dagJobManager) {
+		m.baseConfig = baseConfig
+	}
+}
+
+// WithStatusFile sets the file the manager reports the DAGs failed to load
+// to. See ReadStatus.
+func WithStatusFile(statusFile string) ManagerOption {
+	return func(m *dagJobManager) {
+		m.statusFile = statusFile
+	}
+}
+
+// WithQueue sets the queue of the starts of the DAGs while they are running.
+// The manager starts the queued runs as the running runs finish.
+func WithQueue(q *queue.Store) ManagerOption {
+	return func(m *dagJobManager) {
+		m.queue = q
+	}
 }
 
 // NewDAGJobManager creates a new DAG manager with the given configuration.
-func NewDAGJobManager(dir string, client client.Client, executable, workDir string) JobManager {
-	return &dagJobManager{
-		targetDir:  dir,
-		lock:       sync.Mutex{},
-		registry:   map[string]*digraph.DAG{},
-		client:     client,
-		executable: executable,
-		workDir:    workDir,
+func NewDAGJobManager(dir string, client client.Client, executable, workDir string, opts ...ManagerOption) JobManager {
+	m := &dagJobManager{
+		targetDir:     dir,
+		lock:          sync.Mutex{},
+		registry:      map[string]*digraph.DAG{},
+		client:        client,
+		executable:    executable,
+		workDir:       workDir,
+		dependencies:  map[string][]string{},
+		loadErrors:    map[string]LoadError{},
+		lag:           map[string]*LagMetric{},
+		slaMissCounts: map[slaMissCountKey]int64{},
+		triggering:    map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
+	return m
 }
 
 func (m *dagJobManager) Start(ctx context.Context, done chan any) error {
@@ -68,7 +131,21 @@ func (m *dagJobManager) Start(ctx context.Context, done chan any) error {
 		return fmt.Errorf("failed to initialize DAGs: %w", err)
 	}
 
-	go m.watchDags(ctx, done)
+	watcher, err := m.newWatcher(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to watch DAGs: %w", err)
+	}
+
+	m.startedAt = time.Now()
+
+	go m.recoverLostRuns(ctx, done)
+	go m.watchDags(ctx, watcher, done)
+	go m.watchTriggers(ctx, done)
+	go m.catchup(ctx, done)
+	if m.queue != nil {
+		go m.drainQueue(ctx, done)
+	}
+	go m.monitorSLAs(ctx, done)
 
 	return nil
 }
@@ -85,6 +162,7 @@ func (m *dagJobManager) Next(ctx context.Context, now time.Time) ([]*ScheduledJo
 			continue
 		}
 
+		calendars := m.excludedCalendars(dag)
 		schedules := []struct {
 			items []digraph.Schedule
 			typ   ScheduleType
@@ -96,8 +174,12 @@ func (m *dagJobManager) Next(ctx context.Context, now time.Time) ([]*ScheduledJo
 
 		for _, s := range schedules {
 			for _, schedule := range s.items {
-				next := schedule.Parsed.Next(now)
-				job := NewScheduledJob(next, m.createJob(dag, next, schedule.Parsed), s.typ)
+				parsed := digraph.ExcludeCalendars(schedule.Parsed, calendars)
+				next := parsed.Next(now)
+				if next.IsZero() {
+					continue
+				}
+				job := NewScheduledJob(next, m.createJob(dag, next, parsed), s.typ)
 				jobs = append(jobs, job)
 			}
 		}
@@ -114,86 +196,165 @@ func (m *dagJobManager) createJob(dag *digraph.DAG, next time.Time, schedule cro
 		Next:       next,
 		Schedule:   schedule,
 		Client:     m.client,
+		Queue:      m.queue,
+		RecordLag:  m.recordLag,
+	}
+}
+
+// load loads the metadata of the DAG file for scheduling. The calendars
+// referenced by the DAG must be defined.
+func (m *dagJobManager) load(ctx context.Context, file string) (*digraph.DAG, error) {
+	dag, err := digraph.Load(ctx, file, digraph.WithBaseConfig(m.baseConfig), digraph.OnlyMetadata(), digraph.WithoutEval())
+	if err != nil {
+		return nil, err
 	}
+	if _, err := m.calendars.Resolve(dag.ExcludeCalendars); err != nil {
+		return nil, err
+	}
+	return dag, nil
+}
+
+// excludedCalendars returns the calendars excluded from the schedules of
+// the DAG, which are resolved when the DAG is loaded.
+func (m *dagJobManager) excludedCalendars(dag *digraph.DAG) []digraph.Calendar {
+	calendars, _ := m.calendars.Resolve(dag.ExcludeCalendars)
+	return calendars
 }
 
+// initialize loads the DAGs in the DAGs directory and its subdirectories.
 func (m *dagJobManager) initialize(ctx context.Context) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
+	m.registry = map[string]*digraph.DAG{}
+	m.dependencies = map[string][]string{}
+	m.loadErrors = map[string]LoadError{}
+	m.restoreMetrics()
+
 	logger.Info(ctx, "Loading DAGs", "dir", m.targetDir)
-	fis, err := os.ReadDir(m.targetDir)
+	files, err := m.dagFiles(m.targetDir)
 	if err != nil {
 		return err
 	}
 
 	var dags []string
-	for _, fi := range fis {
-		if fileutil.IsYAMLFile(fi.Name()) {
-			dag, err := digraph.Load(ctx, filepath.Join(m.targetDir, fi.Name()), digraph.OnlyMetadata(), digraph.WithoutEval())
-			if err != nil {
-				logger.Error(ctx, "DAG load failed", "err", err, "name", fi.Name())
-				continue
-			}
-			m.registry[fi.Name()] = dag
-			dags = append(dags, fi.Name())
+	for _, file := range files {
+		if m.loadFile(ctx, file) {
+			dags = append(dags, m.key(file))
 		}
 	}
 
 	logger.Info(ctx, "DAGs loaded", "dags", strings.Join(dags, ","))
+	m.writeStatus(ctx)
 	return nil
 }
 
-func (m *dagJobManager) watchDags(ctx context.Context, done chan any) {
-	watcher, err := filenotify.New(time.Minute)
-	if err != nil {
-		logger.Error(ctx, "Watcher creation failed", "err", err)
-		return
-	}
-
-	defer func() {
-		_ = watcher.Close()
-	}()
-
-	_ = watcher.Add(m.targetDir)
-
-	for {
-		select {
-		case <-done:
-			return
-
-		case event, ok := <-watcher.Events():
-			if !ok {
-				return
+// dagFiles returns the DAG files in the directory and its subdirectories.
+// The hidden directories are skipped.
+func (m *dagJobManager) dagFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
 			}
+			return nil
+		}
+		if fileutil.IsYAMLFile(d.Name()) && !m.isBaseConfig(path) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
 
-			if !fileutil.IsYAMLFile(event.Name) {
-				continue
-			}
+// key returns the key of the DAG file in the registry, which is the path
+// relative to the DAGs directory.
+func (m *dagJobManager) key(file string) string {
+	if rel, err := filepath.Rel(m.targetDir, file); err == nil {
+		return rel
+	}
+	return file
+}
 
-			m.lock.Lock()
-			if event.Op == fsnotify.Create || event.Op == fsnotify.Write {
-				filePath := filepath.Join(m.targetDir, filepath.Base(event.Name))
-				dag, err := digraph.Load(ctx, filePath, digraph.OnlyMetadata(), digraph.WithoutEval())
-				if err != nil {
-					logger.Error(ctx, "DAG load failed", "err", err, "file", event.Name)
-				} else {
-					m.registry[filepath.Base(event.Name)] = dag
-					logger.Info(ctx, "DAG added/updated", "name", filepath.Base(event.Name))
-				}
-			}
-			if event.Op == fsnotify.Rename || event.Op == fsnotify.Remove {
-				delete(m.registry, filepath.Base(event.Name))
-				logger.Info(ctx, "DAG removed", "name", filepath.Base(event.Name))
-			}
-			m.lock.Unlock()
+func (m *dagJobManager) isBaseConfig(file string) bool {
+	return m.baseConfig != "" && filepath.Clean(file) == filepath.Clean(m.baseConfig)
+}
 
-		case err, ok := <-watcher.Errors():
-			if !ok {
-				return
-			}
-			logger.Error(ctx, "Watcher error", "err", err)
+// loadFile loads the DAG file into the registry and records the files it
+// depends on. A DAG that fails to load is removed from the registry, and the
+// error is reported in the status. The lock must be held.
+func (m *dagJobManager) loadFile(ctx context.Context, file string) bool {
+	key := m.key(file)
+	dag, err := m.load(ctx, file)
+	if err != nil {
+		logger.Error(ctx, "DAG load failed", "err", err, "file", key)
+		delete(m.registry, key)
+		delete(m.dependencies, key)
+		m.loadErrors[key] = LoadError{File: key, Error: err.Error(), At: time.Now()}
+		return false
+	}
+	m.registry[key] = dag
+	m.dependencies[key] = dependencies(file, dag)
+	delete(m.loadErrors, key)
+	return true
+}
+
+// removeFile removes the DAGs of the file, or of the files in the directory
+// if it is a directory, from the registry. The lock must be held.
+func (m *dagJobManager) removeFile(ctx context.Context, file string) {
+	key := m.key(file)
+	prefix := key + string(filepath.Separator)
+	for k := range m.registry {
+		if k == key || strings.HasPrefix(k, prefix) {
+			delete(m.registry, k)
+			delete(m.dependencies, k)
+			logger.Info(ctx, "DAG removed", "name", k)
+		}
+	}
+	for k := range m.loadErrors {
+		if k == key || strings.HasPrefix(k, prefix) {
+			delete(m.loadErrors, k)
+		}
+	}
+}
 
+// dependencies returns the absolute paths of the dotenv files of the DAG,
+// which are reloaded with the DAG when they change.
+func dependencies(file string, dag *digraph.DAG) []string {
+	var ret []string
+	for _, dotenv := range dag.Dotenv {
+		if !filepath.IsAbs(dotenv) {
+			dotenv = filepath.Join(filepath.Dir(file), dotenv)
 		}
+		ret = append(ret, filepath.Clean(dotenv))
+	}
+	return ret
+}
+
+// writeStatus writes the DAGs failed to load, the metrics and the latest SLA
+// misses to the status file. The lock must be held.
+func (m *dagJobManager) writeStatus(ctx context.Context) {
+	if m.statusFile == "" {
+		return
+	}
+	status := Status{
+		UpdatedAt:  time.Now(),
+		DAGs:       len(m.registry),
+		LoadErrors: []LoadError{},
+		Metrics:    m.metrics(),
+		SLAMisses:  append([]SLAMiss{}, m.recentSLAMisses...),
+	}
+	for _, e := range m.loadErrors {
+		status.LoadErrors = append(status.LoadErrors, e)
+	}
+	sort.Slice(status.LoadErrors, func(i, j int) bool {
+		return status.LoadErrors[i].File < status.LoadErrors[j].File
+	})
+	if err := WriteStatus(m.statusFile, status); err != nil {
+		logger.Error(ctx, "Failed to write the scheduler status", "err", err)
 	}
 }
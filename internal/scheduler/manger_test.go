@@ -2,9 +2,13 @@ package scheduler
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/dagu-org/dagu/internal/config"
+	"github.com/dagu-org/dagu/internal/scheduler/calendar"
 	"github.com/stretchr/testify/require"
 )
 
@@ -62,6 +66,95 @@ func TestReadEntries(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, len(afterSuspend), len(beforeSuspend)-1, "suspended job should not be returned")
 	})
+	t.Run("ExcludeCalendars", func(t *testing.T) {
+		th := setupTest(t)
+		ctx := context.Background()
+
+		dir := t.TempDir()
+		writeDAG := func(name, calendar string) {
+			data := "schedule: \"0 1 * * *\"\ntimezone: UTC\nexcludeCalendars: [" + calendar + "]\nsteps:\n  - name: \"1\"\n    command: \"true\"\n"
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(data), 0600))
+		}
+		writeDAG("holiday_job.yaml", "Holidays")
+		writeDAG("undefined_calendar_job.yaml", "undefined")
+
+		registry, err := calendar.NewRegistry(map[string]config.CalendarConfig{
+			"holidays": {Dates: []string{"2020-01-01"}},
+		}, time.UTC)
+		require.NoError(t, err)
+
+		done := make(chan any)
+		defer close(done)
+
+		manager := NewDAGJobManager(dir, th.client, "", "", WithCalendars(registry))
+		require.NoError(t, manager.Start(ctx, done))
+
+		// The DAG referencing an undefined calendar is not scheduled, and
+		// the run on the holiday is skipped.
+		jobs, err := manager.Next(ctx, now)
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		require.Equal(t, "holiday_job", jobs[0].Job.(*dagJob).DAG.Name)
+		require.Equal(t, expectedNext.AddDate(0, 0, 1), jobs[0].Next)
+	})
+	t.Run("WatchDAGs", func(t *testing.T) {
+		th := setupTest(t)
+		ctx := context.Background()
+
+		dir := t.TempDir()
+		baseConfig := filepath.Join(t.TempDir(), "base.yaml")
+		statusFile := filepath.Join(t.TempDir(), "status.json")
+		write := func(file, data string) {
+			require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+			require.NoError(t, os.WriteFile(file, []byte(data), 0600))
+		}
+		write(baseConfig, "schedule: \"0 1 * * *\"\ntimezone: UTC\n")
+		write(filepath.Join(dir, "nested", "nested_job.yaml"), "steps:\n  - name: \"1\"\n    command: \"true\"\n")
+		write(filepath.Join(dir, "invalid_job.yaml"), "schedule: \"invalid\"\n")
+
+		done := make(chan any)
+		defer close(done)
+
+		manager := NewDAGJobManager(dir, th.client, "", "", WithBaseConfig(baseConfig), WithStatusFile(statusFile))
+		require.NoError(t, manager.Start(ctx, done))
+
+		// The DAG in the subdirectory is scheduled by the base config, and
+		// the invalid DAG is reported in the status.
+		jobs, err := manager.Next(ctx, now)
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		require.Equal(t, expectedNext, jobs[0].Next)
+
+		status, err := ReadStatus(statusFile)
+		require.NoError(t, err)
+		require.Equal(t, 1, status.DAGs)
+		require.Len(t, status.LoadErrors, 1)
+		require.Equal(t, "invalid_job.yaml", status.LoadErrors[0].File)
+
+		// The fixed DAG is loaded and the error is cleared.
+		write(filepath.Join(dir, "invalid_job.yaml"), "schedule: \"0 2 * * *\"\n")
+		require.Eventually(t, func() bool {
+			status, err := ReadStatus(statusFile)
+			return err == nil && status.DAGs == 2 && len(status.LoadErrors) == 0
+		}, 5*time.Second, 50*time.Millisecond)
+
+		// The DAGs are reloaded when the base config changes.
+		write(baseConfig, "schedule: \"0 3 * * *\"\ntimezone: UTC\n")
+		require.Eventually(t, func() bool {
+			jobs, err := manager.Next(ctx, now)
+			require.NoError(t, err)
+			job := findJobByName(t, jobs, "nested_job")
+			return job.Next.Equal(expectedNext.Add(2 * time.Hour))
+		}, 5*time.Second, 50*time.Millisecond)
+
+		// The DAGs in a removed directory are unscheduled.
+		require.NoError(t, os.RemoveAll(filepath.Join(dir, "nested")))
+		require.Eventually(t, func() bool {
+			jobs, err := manager.Next(ctx, now)
+			require.NoError(t, err)
+			return len(jobs) == 1
+		}, 5*time.Second, 50*time.Millisecond)
+	})
 }
 
 func findJobByName(t *testing.T, jobs []*ScheduledJob, name string) *ScheduledJob {
@@ -0,0 +1,167 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+)
+
+// Metrics are the metrics of the scheduler reported in the status file.
+type Metrics struct {
+	// Lag is the delay between the scheduled times of the DAGs and the
+	// starts of their runs.
+	Lag []LagMetric `json:"lag"`
+	// SLAMisses is the number of the misses of the SLAs of the DAGs.
+	SLAMisses []SLAMissCount `json:"slaMisses"`
+}
+
+// LagMetric is the delay between the scheduled times of a DAG and the
+// starts of its runs.
+type LagMetric struct {
+	// DAG is the name of the DAG file without the extension.
+	DAG string `json:"dag"`
+	// Count is the number of the runs started by the schedule.
+	Count int64 `json:"count"`
+	// Sum is the total delay in seconds.
+	Sum float64 `json:"sumSeconds"`
+	// Max is the longest delay in seconds.
+	Max float64 `json:"maxSeconds"`
+	// Last is the delay of the latest run in seconds.
+	Last float64 `json:"lastSeconds"`
+}
+
+// SLAMissCount is the number of the misses of an SLA of a DAG.
+type SLAMissCount struct {
+	// DAG is the name of the DAG file without the extension.
+	DAG string `json:"dag"`
+	// SLA is the kind of the SLA.
+	SLA SLAKind `json:"sla"`
+	// Count is the number of the misses.
+	Count int64 `json:"count"`
+}
+
+type slaMissCountKey struct {
+	dag string
+	sla SLAKind
+}
+
+// recordLag records the delay between the scheduled time of the DAG and the
+// start of its run by the scheduler.
+func (m *dagJobManager) recordLag(ctx context.Context, dag *digraph.DAG, lag time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	name := dagName(dag)
+	metric, ok := m.lag[name]
+	if !ok {
+		metric = &LagMetric{DAG: name}
+		m.lag[name] = metric
+	}
+	seconds := lag.Seconds()
+	metric.Count++
+	metric.Sum += seconds
+	metric.Last = seconds
+	if seconds > metric.Max {
+		metric.Max = seconds
+	}
+	m.writeStatus(ctx)
+}
+
+// metrics returns the metrics in the order of the DAGs. The lock must be
+// held.
+func (m *dagJobManager) metrics() Metrics {
+	ret := Metrics{Lag: []LagMetric{}, SLAMisses: []SLAMissCount{}}
+	for _, metric := range m.lag {
+		ret.Lag = append(ret.Lag, *metric)
+	}
+	sort.Slice(ret.Lag, func(i, j int) bool {
+		return ret.Lag[i].DAG < ret.Lag[j].DAG
+	})
+	for key, count := range m.slaMissCounts {
+		ret.SLAMisses = append(ret.SLAMisses, SLAMissCount{DAG: key.dag, SLA: key.sla, Count: count})
+	}
+	sort.Slice(ret.SLAMisses, func(i, j int) bool {
+		if ret.SLAMisses[i].DAG != ret.SLAMisses[j].DAG {
+			return ret.SLAMisses[i].DAG < ret.SLAMisses[j].DAG
+		}
+		return ret.SLAMisses[i].SLA < ret.SLAMisses[j].SLA
+	})
+	return ret
+}
+
+// restoreMetrics restores the metrics and the SLA misses reported by the
+// previous scheduler from the status file, so that the counters keep
+// increasing and the misses are not reported again. The lock must be held.
+func (m *dagJobManager) restoreMetrics() {
+	m.lag = map[string]*LagMetric{}
+	m.slaMissCounts = map[slaMissCountKey]int64{}
+	m.recentSLAMisses = nil
+	if m.statusFile == "" {
+		return
+	}
+	status, err := ReadStatus(m.statusFile)
+	if err != nil {
+		return
+	}
+	for _, metric := range status.Metrics.Lag {
+		m.lag[metric.DAG] = &metric
+	}
+	for _, count := range status.Metrics.SLAMisses {
+		m.slaMissCounts[slaMissCountKey{dag: count.DAG, sla: count.SLA}] = count.Count
+	}
+	m.recentSLAMisses = status.SLAMisses
+}
+
+// WriteMetrics writes the metrics in the status of the scheduler in the
+// Prometheus text format.
+func WriteMetrics(w io.Writer, status Status) error {
+	var b strings.Builder
+
+	writeHeader := func(name, typ, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	}
+
+	writeHeader("dagu_scheduler_dags", "gauge", "Number of the DAGs scheduled.")
+	fmt.Fprintf(&b, "dagu_scheduler_dags %d\n", status.DAGs)
+
+	writeHeader("dagu_scheduler_load_errors", "gauge", "Number of the DAG files failing to load.")
+	fmt.Fprintf(&b, "dagu_scheduler_load_errors %d\n", len(status.LoadErrors))
+
+	writeHeader("dagu_scheduler_status_updated_timestamp_seconds", "gauge", "Time the scheduler updated its status.")
+	fmt.Fprintf(&b, "dagu_scheduler_status_updated_timestamp_seconds %d\n", status.UpdatedAt.Unix())
+
+	writeHeader("dagu_scheduler_lag_seconds", "summary", "Delay between the scheduled time and the start of the runs.")
+	for _, metric := range status.Metrics.Lag {
+		fmt.Fprintf(&b, "dagu_scheduler_lag_seconds_sum{dag=\"%s\"} %g\n", escapeLabel(metric.DAG), metric.Sum)
+		fmt.Fprintf(&b, "dagu_scheduler_lag_seconds_count{dag=\"%s\"} %d\n", escapeLabel(metric.DAG), metric.Count)
+	}
+
+	writeHeader("dagu_scheduler_lag_seconds_max", "gauge", "Longest delay between the scheduled time and the start of a run.")
+	for _, metric := range status.Metrics.Lag {
+		fmt.Fprintf(&b, "dagu_scheduler_lag_seconds_max{dag=\"%s\"} %g\n", escapeLabel(metric.DAG), metric.Max)
+	}
+
+	writeHeader("dagu_scheduler_last_lag_seconds", "gauge", "Delay between the scheduled time and the start of the latest run.")
+	for _, metric := range status.Metrics.Lag {
+		fmt.Fprintf(&b, "dagu_scheduler_last_lag_seconds{dag=\"%s\"} %g\n", escapeLabel(metric.DAG), metric.Last)
+	}
+
+	writeHeader("dagu_sla_misses_total", "counter", "Number of the misses of the SLAs of the DAGs.")
+	for _, count := range status.Metrics.SLAMisses {
+		fmt.Fprintf(&b, "dagu_sla_misses_total{dag=\"%s\",sla=\"%s\"} %d\n",
+			escapeLabel(count.DAG), escapeLabel(string(count.SLA)), count.Count)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeLabel escapes the value of a label in the Prometheus text format.
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
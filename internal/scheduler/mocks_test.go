@@ -2,10 +2,16 @@ package scheduler
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/dagu-org/dagu/internal/client"
 	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
 )
 
 var _ JobManager = (*mockJobManager)(nil)
@@ -58,3 +64,61 @@ func (j *mockJob) Restart(_ context.Context) error {
 func (j *mockJob) String() string {
 	return j.Name
 }
+
+// historyRunner writes the runs of the DAGs it starts to the history, or
+// fails to start them while fail is set.
+type historyRunner struct {
+	historyStore persistence.HistoryStore
+
+	mu      sync.Mutex
+	fail    bool
+	started []client.StartOptions
+}
+
+var _ client.Runner = (*historyRunner)(nil)
+
+func (r *historyRunner) Start(ctx context.Context, dag *digraph.DAG, opts client.StartOptions) error {
+	r.mu.Lock()
+	r.started = append(r.started, opts)
+	fail := r.fail
+	r.mu.Unlock()
+	if fail {
+		return errors.New("failed to start")
+	}
+
+	status := model.NewStatusFactory(dag).Create(opts.RequestID, scheduler.StatusSuccess, 0, time.Now(),
+		model.WithFinishedAt(time.Now()), model.WithUpstream(opts.Upstream))
+	if err := r.historyStore.Open(ctx, dag.Location, time.Now(), opts.RequestID); err != nil {
+		return err
+	}
+	if err := r.historyStore.Write(ctx, status); err != nil {
+		return err
+	}
+	return r.historyStore.Close(ctx)
+}
+
+func (r *historyRunner) Restart(_ context.Context, _ *digraph.DAG, _ client.RestartOptions) error {
+	return nil
+}
+
+func (r *historyRunner) Retry(_ context.Context, _ *digraph.DAG, _ string, _ client.RetryOptions) error {
+	return nil
+}
+
+func (r *historyRunner) setFail(fail bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fail = fail
+}
+
+func (r *historyRunner) startCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.started)
+}
+
+func (r *historyRunner) lastStart() client.StartOptions {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.started[len(r.started)-1]
+}
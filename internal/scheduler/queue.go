@@ -0,0 +1,182 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/queue"
+)
+
+var (
+	// queueInterval is the interval to start the queued runs of the DAGs
+	// running fewer runs than they allow.
+	queueInterval = 2 * time.Second
+
+	// stopPollInterval is the interval to check whether a stopped run has
+	// finished.
+	stopPollInterval = 500 * time.Millisecond
+
+	// stopGracePeriod is how long a stopped run is waited for in addition
+	// to the clean up time of the DAG.
+	stopGracePeriod = 10 * time.Second
+
+	// inFlightGracePeriod is how long a queued run taken out of the queue by
+	// another scheduler is given to start before it is recovered.
+	inFlightGracePeriod = time.Minute
+)
+
+// StopRunning stops the running runs of the DAG and waits until they finish.
+func StopRunning(ctx context.Context, cli client.Client, dag *digraph.DAG) error {
+	if err := cli.Stop(ctx, dag); err != nil {
+		return fmt.Errorf("failed to stop the previous run: %w", err)
+	}
+
+	timeout := time.NewTimer(dag.MaxCleanUpTime + stopGracePeriod)
+	defer timeout.Stop()
+	ticker := time.NewTicker(stopPollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := cli.GetLatestStatus(ctx, dag)
+		if err != nil {
+			return err
+		}
+		if !status.Status.IsActive() {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+
+		case <-timeout.C:
+			return fmt.Errorf("the previous run of %q did not stop in time", dag.Name)
+
+		case <-ctx.Done():
+			return ctx.Err()
+
+		}
+	}
+}
+
+// drainQueue starts the queued runs of the DAGs in the registry one at a
+// time, as the running runs finish, until done is closed. A queued run is
+// started while the DAG is running fewer runs than its concurrency allows.
+func (m *dagJobManager) drainQueue(ctx context.Context, done chan any) {
+	ticker := time.NewTicker(queueInterval)
+	defer ticker.Stop()
+
+	// starting holds the DAGs whose queued run has been started by the
+	// manager and has not finished, which is not yet reported as running
+	// right after it is started.
+	var starting sync.Map
+
+	for {
+		select {
+		case <-done:
+			return
+
+		case <-ticker.C:
+			for _, dag := range m.dags() {
+				name := dagName(dag)
+				if _, ok := starting.Load(name); ok || m.client.IsSuspended(ctx, name) {
+					continue
+				}
+				m.recoverInFlight(ctx, dag, name)
+				if items, err := m.queue.List(ctx, name); err != nil || len(items) == 0 {
+					continue
+				}
+				if full, err := IsRunningMax(ctx, m.client, dag); err != nil || full {
+					continue
+				}
+				item, err := m.queue.Dequeue(ctx, name)
+				if err != nil {
+					logger.Error(ctx, "Failed to dequeue", "dag", name, "err", err)
+					continue
+				}
+				if item == nil {
+					continue
+				}
+
+				logger.Info(ctx, "Starting queued run", "dag", name, "id", item.ID, "trigger", item.Trigger)
+				starting.Store(name, true)
+				go func(dag *digraph.DAG, item *queue.Item) {
+					defer starting.Delete(name)
+					// The ID of the item is the request ID of the run, by which
+					// the item is recovered if the scheduler stops before the
+					// item is done.
+					if err := m.client.Start(ctx, dag, client.StartOptions{
+						Params:      item.Params,
+						Steps:       item.Steps,
+						Downstream:  item.Downstream,
+						RequestID:   item.ID,
+						LogicalDate: item.LogicalDate,
+						Quiet:       true,
+					}); err != nil {
+						logger.Error(ctx, "Queued run failed", "dag", name, "id", item.ID, "err", err)
+					}
+					if err := m.queue.Done(ctx, name, item.ID); err != nil {
+						logger.Error(ctx, "Failed to remove the queued run", "dag", name, "id", item.ID, "err", err)
+					}
+				}(dag, item)
+			}
+
+		}
+	}
+}
+
+// recoverInFlight recovers the queued runs of the DAG taken out of the queue
+// by a scheduler that stopped before it was done with them. The items whose
+// run was recorded are removed, and the others are put back to the queue.
+func (m *dagJobManager) recoverInFlight(ctx context.Context, dag *digraph.DAG, name string) {
+	items, err := m.queue.InFlight(ctx, name)
+	if err != nil {
+		logger.Error(ctx, "Failed to list the queued runs in flight", "dag", name, "err", err)
+		return
+	}
+	for _, item := range items {
+		if time.Since(item.DequeuedAt) < inFlightGracePeriod {
+			// The run may be starting.
+			continue
+		}
+		_, err := m.client.GetStatusByRequestID(ctx, dag, item.ID)
+		switch {
+		case err == nil:
+			err = m.queue.Done(ctx, name, item.ID)
+
+		case errors.Is(err, persistence.ErrRequestIDNotFound):
+			logger.Info(ctx, "Requeuing the queued run that did not start", "dag", name, "id", item.ID)
+			err = m.queue.Requeue(ctx, name, item.ID)
+
+		}
+		if err != nil && !errors.Is(err, queue.ErrItemNotFound) {
+			logger.Error(ctx, "Failed to recover the queued run", "dag", name, "id", item.ID, "err", err)
+		}
+	}
+}
+
+// dags returns the DAGs in the registry.
+func (m *dagJobManager) dags() []*digraph.DAG {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var ret []*digraph.DAG
+	for _, dag := range m.registry {
+		ret = append(ret, dag)
+	}
+	return ret
+}
+
+// dagName returns the name of the DAG file without the extension, which
+// identifies the DAG.
+func dagName(dag *digraph.DAG) string {
+	return strings.TrimSuffix(filepath.Base(dag.Location), filepath.Ext(dag.Location))
+}
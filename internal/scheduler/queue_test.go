@@ -0,0 +1,173 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/queue"
+	"github.com/dagu-org/dagu/internal/sock"
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnConflict(t *testing.T) {
+	now := time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	setup := func(t *testing.T, onConflict digraph.OnConflict) (*dagJob, *atomic.Bool) {
+		th := setupTest(t)
+
+		dir := t.TempDir()
+		file := filepath.Join(dir, "on_conflict.yaml")
+		require.NoError(t, os.WriteFile(file, []byte("steps:\n  - name: \"1\"\n    command: \"true\"\n"), 0600))
+		dag := &digraph.DAG{
+			Name:        "on_conflict",
+			Location:    file,
+			Concurrency: digraph.Concurrency{Max: 1, OnConflict: onConflict},
+		}
+
+		// Serve the status of a running run until it is stopped.
+		var stopped atomic.Bool
+		server, err := sock.NewServer(dag.SockAddr(), func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost && r.URL.Path == "/stop" {
+				stopped.Store(true)
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte("OK"))
+				return
+			}
+			status := scheduler.StatusRunning
+			if stopped.Load() {
+				status = scheduler.StatusCancel
+			}
+			data, _ := json.Marshal(model.NewStatusFactory(dag).Create("req", status, 0, now.Add(-time.Hour)))
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(data)
+		})
+		require.NoError(t, err)
+		listen := make(chan error, 1)
+		go func() {
+			_ = server.Serve(context.Background(), listen)
+		}()
+		require.NoError(t, <-listen)
+		t.Cleanup(func() {
+			_ = server.Shutdown(context.Background())
+		})
+
+		schedule, err := cron.ParseStandard("0 * * * *")
+		require.NoError(t, err)
+		return &dagJob{
+			DAG:      dag,
+			Next:     now,
+			Schedule: schedule,
+			Client:   th.client,
+			Queue:    queue.New(filepath.Join(dir, "queue")),
+		}, &stopped
+	}
+
+	t.Run("Skip", func(t *testing.T) {
+		job, _ := setup(t, digraph.OnConflictSkip)
+		require.ErrorIs(t, job.Start(context.Background()), ErrJobRunning)
+	})
+	t.Run("Queue", func(t *testing.T) {
+		job, _ := setup(t, digraph.OnConflictQueue)
+		ctx := context.Background()
+
+		require.ErrorIs(t, job.Start(ctx), ErrJobQueued)
+
+		items, err := job.Queue.List(ctx, "on_conflict")
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		require.Equal(t, queue.TriggerSchedule, items[0].Trigger)
+		require.Equal(t, now, items[0].LogicalDate.UTC())
+	})
+	t.Run("MaxRuns", func(t *testing.T) {
+		job, _ := setup(t, digraph.OnConflictSkip)
+		job.DAG.Concurrency.Max = 2
+
+		// The DAG is started while it is running fewer runs than it allows,
+		// which fails without the executable.
+		require.NotErrorIs(t, job.Start(context.Background()), ErrJobRunning)
+	})
+	t.Run("CancelPrevious", func(t *testing.T) {
+		job, stopped := setup(t, digraph.OnConflictCancelPrevious)
+
+		// The previous run is stopped before the DAG is started, which fails
+		// without the executable.
+		err := job.Start(context.Background())
+		require.True(t, stopped.Load())
+		require.NotErrorIs(t, err, ErrJobRunning)
+	})
+}
+
+func TestDrainQueue(t *testing.T) {
+	origInterval := queueInterval
+	queueInterval = 50 * time.Millisecond
+	t.Cleanup(func() {
+		queueInterval = origInterval
+	})
+
+	th := setupTest(t)
+	ctx := context.Background()
+
+	q := queue.New(t.TempDir())
+	manager := NewDAGJobManager(th.config.Paths.DAGsDir, th.client, "", "", WithQueue(q)).(*dagJobManager)
+	require.NoError(t, manager.initialize(ctx))
+
+	_, err := q.Enqueue(ctx, queue.Item{DAG: "scheduled_job", Trigger: queue.TriggerManual})
+	require.NoError(t, err)
+
+	done := make(chan any)
+	defer close(done)
+	go manager.drainQueue(ctx, done)
+
+	// The queued run is started as the DAG is not running.
+	require.Eventually(t, func() bool {
+		items, err := q.List(ctx, "scheduled_job")
+		return err == nil && len(items) == 0
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestRecoverInFlight(t *testing.T) {
+	origInterval, origGracePeriod := queueInterval, inFlightGracePeriod
+	queueInterval, inFlightGracePeriod = 50*time.Millisecond, 0
+	t.Cleanup(func() {
+		queueInterval, inFlightGracePeriod = origInterval, origGracePeriod
+	})
+
+	th := setupTest(t)
+	ctx := context.Background()
+
+	q := queue.New(t.TempDir())
+	manager := NewDAGJobManager(th.config.Paths.DAGsDir, th.client, "", "", WithQueue(q)).(*dagJobManager)
+	require.NoError(t, manager.initialize(ctx))
+
+	// The item was taken out of the queue by a scheduler that stopped before
+	// it started the run.
+	_, err := q.Enqueue(ctx, queue.Item{DAG: "scheduled_job", Trigger: queue.TriggerManual})
+	require.NoError(t, err)
+	item, err := q.Dequeue(ctx, "scheduled_job")
+	require.NoError(t, err)
+	require.NotNil(t, item)
+
+	done := make(chan any)
+	defer close(done)
+	go manager.drainQueue(ctx, done)
+
+	// The item is put back to the queue and started.
+	require.Eventually(t, func() bool {
+		items, err := q.List(ctx, "scheduled_job")
+		if err != nil || len(items) > 0 {
+			return false
+		}
+		inFlight, err := q.InFlight(ctx, "scheduled_job")
+		return err == nil && len(inFlight) == 0
+	}, 5*time.Second, 50*time.Millisecond)
+}
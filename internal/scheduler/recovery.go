@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/logger"
+)
+
+var (
+	// lostRunCheckInterval is the interval to check the runs for the lost
+	// agents.
+	lostRunCheckInterval = client.LostRunCheckInterval
+)
+
+// recoverLostRuns marks the runs whose agent was lost failed, and retries
+// them according to onAgentLost of the DAGs, on start and then periodically
+// until done is closed. The runs are lost when the host crashes or reboots
+// in the middle of them.
+func (m *dagJobManager) recoverLostRuns(ctx context.Context, done chan any) {
+	ticker := time.NewTicker(lostRunCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.client.RecoverLostRuns(ctx, client.RecoverOptions{Retry: true}); err != nil {
+			logger.Error(ctx, "Failed to recover the lost runs", "err", err)
+		}
+
+		select {
+		case <-done:
+			return
+
+		case <-ticker.C:
+
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/dagu-org/dagu/internal/config"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/scheduler/leader"
 )
 
 // Job is the interface for the actual DAG.
@@ -32,20 +33,41 @@ type Scheduler struct {
 	stopChan chan struct{}
 	running  atomic.Bool
 	location *time.Location
+	elector  *leader.Elector
 }
 
-func New(cfg *config.Config, manager JobManager) *Scheduler {
+// leaderCheckInterval is the interval to check whether the scheduler is the
+// leader, both on standby and while scheduling.
+var leaderCheckInterval = time.Second
+
+// Option is a functional option for Scheduler.
+type Option func(*Scheduler)
+
+// WithElector makes the scheduler schedule the jobs only while it is the
+// leader elected by the elector. Without it, the scheduler always schedules
+// the jobs.
+func WithElector(elector *leader.Elector) Option {
+	return func(s *Scheduler) {
+		s.elector = elector
+	}
+}
+
+func New(cfg *config.Config, manager JobManager, opts ...Option) *Scheduler {
 	timeLoc := cfg.Location
 	if timeLoc == nil {
 		timeLoc = time.Local
 	}
 
-	return &Scheduler{
+	s := &Scheduler{
 		logDir:   cfg.Paths.LogDir,
 		stopChan: make(chan struct{}),
 		location: timeLoc,
 		manager:  manager,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // ScheduleType is the type of schedule (start, stop, restart).
@@ -103,12 +125,8 @@ func (s *ScheduledJob) invoke(ctx context.Context) error {
 func (s *Scheduler) Start(ctx context.Context) error {
 	sig := make(chan os.Signal, 1)
 
-	done := make(chan any)
-	defer close(done)
-
-	if err := s.manager.Start(ctx, done); err != nil {
-		return fmt.Errorf("failed to start manager: %w", err)
-	}
+	finished := make(chan any)
+	defer close(finished)
 
 	signal.Notify(
 		sig, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT,
@@ -116,7 +134,7 @@ func (s *Scheduler) Start(ctx context.Context) error {
 
 	go func() {
 		select {
-		case <-done:
+		case <-finished:
 			return
 
 		case <-sig:
@@ -128,41 +146,123 @@ func (s *Scheduler) Start(ctx context.Context) error {
 		}
 	}()
 
-	logger.Info(ctx, "Scheduler started")
-	s.start(ctx)
+	s.running.Store(true)
+
+	if s.elector != nil {
+		// Wait for the lease to be released on return.
+		electorDone := make(chan struct{})
+		defer func() { <-electorDone }()
+		go func() {
+			defer close(electorDone)
+			s.elector.Run(ctx, s.stopChan)
+		}()
+	}
+
+	for {
+		if !s.waitLeader(ctx) {
+			return nil
+		}
+
+		done := make(chan any)
+		if err := s.manager.Start(ctx, done); err != nil {
+			close(done)
+			s.Stop(ctx)
+			return fmt.Errorf("failed to start manager: %w", err)
+		}
+
+		logger.Info(ctx, "Scheduler started")
+		lost := s.start(ctx)
+		close(done)
+		if !lost {
+			return nil
+		}
+		logger.Warn(ctx, "Scheduler lost the leadership; waiting on standby")
+	}
+}
+
+// waitLeader blocks until the scheduler becomes the leader. It returns
+// false if the scheduler is stopped in the meantime.
+func (s *Scheduler) waitLeader(ctx context.Context) bool {
+	if s.elector == nil {
+		return true
+	}
+
+	ticker := time.NewTicker(leaderCheckInterval)
+	defer ticker.Stop()
 
-	return nil
+	logger.Info(ctx, "Waiting for the leadership", "id", s.elector.ID())
+	for !s.elector.IsLeader() {
+		select {
+		case <-ticker.C:
+
+		case <-s.stopChan:
+			return false
+
+		}
+	}
+	return true
 }
 
-func (s *Scheduler) start(ctx context.Context) {
+// start schedules the jobs until the scheduler is stopped or loses the
+// leadership. It returns true if the leadership is lost.
+func (s *Scheduler) start(ctx context.Context) bool {
 	t := now().Truncate(time.Minute)
 	timer := time.NewTimer(0)
+	defer timer.Stop()
 
-	s.running.Store(true)
+	leaderCheck := time.NewTicker(leaderCheckInterval)
+	defer leaderCheck.Stop()
 
 	for {
 		select {
 		case <-timer.C:
-			s.run(ctx, t)
-			t = s.nextTick(t)
+			// Confirm the leadership with the fencing token just before
+			// invoking the jobs.
+			if s.elector != nil && !s.elector.Verify(ctx) {
+				return true
+			}
+			t = s.wakeUp(t, s.run(ctx, t))
 			_ = timer.Stop()
 			timer.Reset(t.Sub(now()))
 
-		case <-s.stopChan:
-			if !timer.Stop() {
-				<-timer.C
+		case <-leaderCheck.C:
+			if s.elector != nil && !s.elector.IsLeader() {
+				return true
 			}
-			return
+
+		case <-s.stopChan:
+			return false
 
 		}
 	}
 }
 
-func (s *Scheduler) run(ctx context.Context, now time.Time) {
+// wakeUp returns the time to run the jobs next after t. The scheduler wakes
+// up every minute, and at the seconds in between if a job is scheduled with
+// the seconds field. next is the earliest time a job is scheduled after t.
+func (s *Scheduler) wakeUp(t, next time.Time) time.Time {
+	tick := s.nextTick(t)
+	if next.IsZero() || !next.Before(tick) {
+		return tick
+	}
+	// Skip the seconds already passed, e.g. after the scheduler started in
+	// the middle of a minute, rather than running the jobs in a burst.
+	if passed := now().Truncate(time.Second); next.Before(passed) {
+		next = passed
+	}
+	if !next.Before(tick) {
+		return tick
+	}
+	return next
+}
+
+// run invokes the jobs scheduled at the time and returns the earliest time
+// a job is scheduled after it.
+func (s *Scheduler) run(ctx context.Context, now time.Time) time.Time {
 	jobs, err := s.manager.Next(ctx, now.Add(-time.Second).In(s.location))
 	if err != nil {
 		logger.Error(ctx, "failed to get next jobs", "err", err)
-		return
+		return time.Time{}
 	}
 
 	// Sort the jobs by the next scheduled time.
@@ -172,7 +272,7 @@ func (s *Scheduler) run(ctx context.Context, now time.Time) {
 
 	for _, job := range jobs {
 		if job.Next.After(now) {
-			break
+			return job.Next
 		}
 
 		go func(job *ScheduledJob) {
@@ -181,6 +281,8 @@ func (s *Scheduler) run(ctx context.Context, now time.Time) {
 					logger.Info(ctx, "job is already finished", "job", job.Job, "err", err)
 				} else if errors.Is(err, ErrJobRunning) {
 					logger.Info(ctx, "job is already running", "job", job.Job, "err", err)
+				} else if errors.Is(err, ErrJobQueued) {
+					logger.Info(ctx, "job is queued", "job", job.Job, "err", err)
 				} else if errors.Is(err, ErrJobSkipped) {
 					logger.Info(ctx, "job is skipped", "job", job.Job, "err", err)
 				} else {
@@ -189,6 +291,7 @@ func (s *Scheduler) run(ctx context.Context, now time.Time) {
 			}
 		}(job)
 	}
+	return time.Time{}
 }
 
 func (*Scheduler) nextTick(now time.Time) time.Time {
@@ -2,12 +2,17 @@ package scheduler
 
 import (
 	"context"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/dagu-org/dagu/internal/client"
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence/jsondb"
 	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/scheduler/leader"
 	"github.com/dagu-org/dagu/internal/stringutil"
 	"github.com/robfig/cron/v3"
 	"github.com/stretchr/testify/require"
@@ -60,6 +65,45 @@ func TestScheduler(t *testing.T) {
 		time.Sleep(time.Second + time.Millisecond*100)
 		require.Equal(t, int32(1), entryReader.Entries[0].Job.(*mockJob).RestartCount.Load())
 	})
+	t.Run("LeaderElection", func(t *testing.T) {
+		now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		setFixedTime(now)
+
+		th := setupTest(t)
+		backend := leader.NewFileBackend(filepath.Join(t.TempDir(), "leader.json"))
+
+		// Two schedulers share the lease; only the leader invokes the job.
+		var managers []*mockJobManager
+		var schedulers []*Scheduler
+		for _, id := range []string{"a", "b"} {
+			manager := &mockJobManager{
+				Entries: []*ScheduledJob{{Job: &mockJob{}, Next: now}},
+			}
+			elector := leader.NewElector(backend, id, time.Second)
+			managers = append(managers, manager)
+			schedulers = append(schedulers, New(th.config, manager, WithElector(elector)))
+		}
+		var wg sync.WaitGroup
+		for _, s := range schedulers {
+			wg.Add(1)
+			go func(s *Scheduler) {
+				defer wg.Done()
+				_ = s.Start(context.Background())
+			}(s)
+		}
+
+		time.Sleep(time.Second + time.Millisecond*500)
+		for _, s := range schedulers {
+			s.Stop(context.Background())
+		}
+		wg.Wait()
+
+		var runs int32
+		for _, m := range managers {
+			runs += m.Entries[0].Job.(*mockJob).RunCount.Load()
+		}
+		require.Equal(t, int32(1), runs)
+	})
 	t.Run("NextTick", func(t *testing.T) {
 		now := time.Date(2020, 1, 1, 1, 0, 50, 0, time.UTC)
 		setFixedTime(now)
@@ -70,6 +114,23 @@ func TestScheduler(t *testing.T) {
 		next := schedulerInstance.nextTick(now)
 		require.Equal(t, time.Date(2020, 1, 1, 1, 1, 0, 0, time.UTC), next)
 	})
+	t.Run("WakeUp", func(t *testing.T) {
+		now := time.Date(2020, 1, 1, 1, 0, 20, 0, time.UTC)
+		setFixedTime(now)
+
+		th := setupTest(t)
+		schedulerInstance := New(th.config, &mockJobManager{})
+		tick := time.Date(2020, 1, 1, 1, 1, 0, 0, time.UTC)
+
+		// No job is scheduled before the next minute.
+		require.Equal(t, tick, schedulerInstance.wakeUp(now, time.Time{}))
+		require.Equal(t, tick, schedulerInstance.wakeUp(now, tick.Add(time.Second)))
+		// A job is scheduled at a second before the next minute.
+		require.Equal(t, now.Add(10*time.Second), schedulerInstance.wakeUp(now, now.Add(10*time.Second)))
+		// The seconds already passed are skipped.
+		start := time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC)
+		require.Equal(t, now, schedulerInstance.wakeUp(start, start.Add(5*time.Second)))
+	})
 }
 
 func TestFixedTime(t *testing.T) {
@@ -168,6 +229,26 @@ func TestJobReady(t *testing.T) {
 	}
 }
 
+func TestJobStart(t *testing.T) {
+	runner := &historyRunner{}
+	th := setupTest(t, client.WithRunner(runner))
+	runner.historyStore = jsondb.New(th.config.Paths.DataDir)
+
+	manager := th.manager.(*dagJobManager)
+	require.NoError(t, manager.initialize(context.Background()))
+	dag := manager.registry["scheduled_job.yaml"]
+	require.NotNil(t, dag)
+
+	// The run started on time is for the scheduled time.
+	next := time.Now().Truncate(time.Minute)
+	job := &dagJob{DAG: dag, Next: next, Client: th.client}
+	require.NoError(t, job.Start(context.Background()))
+
+	opts := runner.lastStart()
+	require.Equal(t, ScheduledRequestID(dag.Name, next), opts.RequestID)
+	require.Equal(t, next, opts.LogicalDate)
+}
+
 func TestPrevExecTime(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -40,7 +40,7 @@ type testHelper struct {
 	config  *config.Config
 }
 
-func setupTest(t *testing.T) testHelper {
+func setupTest(t *testing.T, opts ...client.Option) testHelper {
 	t.Helper()
 
 	tempDir := fileutil.MustTempDir("test")
@@ -65,7 +65,7 @@ func setupTest(t *testing.T) testHelper {
 	dagStore := local.NewDAGStore(cfg.Paths.DAGsDir)
 	historyStore := jsondb.New(cfg.Paths.DataDir)
 	flagStore := local.NewFlagStore(storage.NewStorage(cfg.Paths.SuspendFlagsDir))
-	cli := client.New(dagStore, historyStore, flagStore, "", cfg.WorkDir)
+	cli := client.New(dagStore, historyStore, flagStore, "", cfg.WorkDir, opts...)
 	jobManager := NewDAGJobManager(testdataDir, cli, "", "")
 
 	return testHelper{
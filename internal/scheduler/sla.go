@@ -0,0 +1,279 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/stringutil"
+)
+
+var (
+	// slaCheckInterval is the interval to check the SLAs of the DAGs.
+	slaCheckInterval = 30 * time.Second
+)
+
+const (
+	// maxRecentSLAMisses is the number of the latest SLA misses kept in the
+	// status, which are not reported again.
+	maxRecentSLAMisses = 100
+
+	// slaHistorySize is the number of the latest runs searched for the run
+	// finished by the deadline of the DAG.
+	slaHistorySize = 20
+)
+
+// SLAKind is the kind of the SLA missed.
+type SLAKind string
+
+const (
+	// SLAMaxDuration is missed by a run taking longer than sla.maxDuration.
+	SLAMaxDuration SLAKind = "maxDuration"
+	// SLAMustFinishBy is missed when no run has finished successfully by
+	// sla.mustFinishBy on a day the DAG is scheduled.
+	SLAMustFinishBy SLAKind = "mustFinishBy"
+)
+
+// SLAMiss is a miss of the SLA of a DAG.
+type SLAMiss struct {
+	// DAG is the name of the DAG file without the extension.
+	DAG string `json:"dag"`
+	// SLA is the kind of the SLA missed.
+	SLA SLAKind `json:"sla"`
+	// RequestID is the run that missed the SLA, if any.
+	RequestID string `json:"requestId,omitempty"`
+	// Due is the time the run had to finish by.
+	Due time.Time `json:"due"`
+	// Reason describes the miss.
+	Reason string `json:"reason"`
+	// At is the time the miss was detected.
+	At time.Time `json:"at"`
+}
+
+// key identifies the miss so that it is reported once.
+func (s SLAMiss) key() string {
+	if s.RequestID != "" {
+		return fmt.Sprintf("%s/%s/%s", s.DAG, s.SLA, s.RequestID)
+	}
+	return fmt.Sprintf("%s/%s/%s", s.DAG, s.SLA, s.Due.UTC().Format(time.RFC3339))
+}
+
+// SLAMissHandler fires the notification handlers of a DAG that missed its
+// SLA.
+type SLAMissHandler interface {
+	HandleSLAMiss(ctx context.Context, dag *digraph.DAG, miss SLAMiss) error
+}
+
+// WithSLAMissHandler sets the handler of the SLA misses. Without it, the
+// misses are only logged and counted in the metrics.
+func WithSLAMissHandler(handler SLAMissHandler) ManagerOption {
+	return func(m *dagJobManager) {
+		m.slaMissHandler = handler
+	}
+}
+
+// monitorSLAs checks the SLAs of the DAGs in the registry until done is
+// closed.
+func (m *dagJobManager) monitorSLAs(ctx context.Context, done chan any) {
+	ticker := time.NewTicker(slaCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+
+		case <-ticker.C:
+			m.checkSLAs(ctx, time.Now())
+
+		}
+	}
+}
+
+// checkSLAs reports the misses of the SLAs of the DAGs not reported yet.
+func (m *dagJobManager) checkSLAs(ctx context.Context, now time.Time) {
+	for _, dag := range m.dags() {
+		if dag.SLA == nil {
+			continue
+		}
+		for _, miss := range m.slaMisses(ctx, dag, now) {
+			if !m.recordSLAMiss(ctx, miss) {
+				continue
+			}
+			logger.Warn(ctx, "SLA missed", "dag", miss.DAG, "sla", miss.SLA, "reason", miss.Reason)
+			if m.slaMissHandler == nil {
+				continue
+			}
+			go func(dag *digraph.DAG, miss SLAMiss) {
+				if err := m.slaMissHandler.HandleSLAMiss(ctx, dag, miss); err != nil {
+					logger.Error(ctx, "SLA miss handler failed", "dag", miss.DAG, "err", err)
+				}
+			}(dag, miss)
+		}
+	}
+}
+
+// slaMisses returns the misses of the SLA of the DAG at the time.
+func (m *dagJobManager) slaMisses(ctx context.Context, dag *digraph.DAG, now time.Time) []SLAMiss {
+	var ret []SLAMiss
+	if dag.SLA.MaxDuration > 0 {
+		// Each of the running runs of the DAG is checked, or the latest run
+		// if none is running.
+		statuses, err := m.client.GetActiveRuns(ctx, dag)
+		if err == nil && len(statuses) == 0 {
+			var status model.Status
+			if status, err = m.client.GetLatestStatus(ctx, dag); err == nil {
+				statuses = append(statuses, status)
+			}
+		}
+		if err != nil {
+			logger.Error(ctx, "Failed to get the latest status", "dag", dag.Name, "err", err)
+		}
+		for _, status := range statuses {
+			if miss, ok := m.maxDurationMiss(dag, status, now); ok {
+				ret = append(ret, miss)
+			}
+		}
+	}
+	if dag.SLA.MustFinishBy != "" {
+		if miss, ok := m.mustFinishByMiss(ctx, dag, now); ok {
+			ret = append(ret, miss)
+		}
+	}
+	return ret
+}
+
+// maxDurationMiss checks whether the run is running or took longer than
+// sla.maxDuration. The runs finished before the scheduler started are not
+// checked.
+func (m *dagJobManager) maxDurationMiss(dag *digraph.DAG, status model.Status, now time.Time) (SLAMiss, bool) {
+	startedAt, err := stringutil.ParseTime(status.StartedAt)
+	if err != nil || startedAt.IsZero() {
+		return SLAMiss{}, false
+	}
+	maxDuration := dag.SLA.MaxDuration
+	miss := SLAMiss{
+		DAG:       dagName(dag),
+		SLA:       SLAMaxDuration,
+		RequestID: status.RequestID,
+		Due:       startedAt.Add(maxDuration),
+		At:        now,
+	}
+
+	if status.Status.IsActive() {
+		if now.After(miss.Due) {
+			miss.Reason = fmt.Sprintf("the run %s has been running for longer than %s", status.RequestID, maxDuration)
+			return miss, true
+		}
+		return SLAMiss{}, false
+	}
+
+	finishedAt, err := stringutil.ParseTime(status.FinishedAt)
+	if err != nil || finishedAt.Before(m.startedAt) {
+		return SLAMiss{}, false
+	}
+	if finishedAt.After(miss.Due) {
+		miss.Reason = fmt.Sprintf("the run %s took %s, longer than %s",
+			status.RequestID, finishedAt.Sub(startedAt).Truncate(time.Second), maxDuration)
+		return miss, true
+	}
+	return SLAMiss{}, false
+}
+
+// mustFinishByMiss checks whether a run has finished successfully by the
+// last deadline of the DAG in the day before it, if the DAG is scheduled in
+// the day. Only the deadlines passed since the scheduler started are
+// checked.
+func (m *dagJobManager) mustFinishByMiss(ctx context.Context, dag *digraph.DAG, now time.Time) (SLAMiss, bool) {
+	due, err := lastDeadline(dag.SLA.MustFinishBy, now.In(dagLocation(dag, now.Location())))
+	if err != nil || due.Before(m.startedAt) {
+		return SLAMiss{}, false
+	}
+	since := due.AddDate(0, 0, -1)
+	if !m.scheduledBetween(dag, since, due) {
+		return SLAMiss{}, false
+	}
+
+	for _, file := range m.client.GetRecentHistory(ctx, dag, slaHistorySize) {
+		if file.Status.Status != scheduler.StatusSuccess {
+			continue
+		}
+		finishedAt, err := stringutil.ParseTime(file.Status.FinishedAt)
+		if err == nil && finishedAt.After(since) && !finishedAt.After(due) {
+			return SLAMiss{}, false
+		}
+	}
+
+	return SLAMiss{
+		DAG:    dagName(dag),
+		SLA:    SLAMustFinishBy,
+		Due:    due,
+		Reason: fmt.Sprintf("no run finished successfully by %s", due.Format("2006-01-02 15:04 MST")),
+		At:     now,
+	}, true
+}
+
+// scheduledBetween returns true if the DAG is scheduled to start in the
+// period. A DAG without a schedule is expected to run every day.
+func (m *dagJobManager) scheduledBetween(dag *digraph.DAG, since, until time.Time) bool {
+	if len(dag.Schedule) == 0 {
+		return true
+	}
+	calendars := m.excludedCalendars(dag)
+	for _, schedule := range dag.Schedule {
+		next := digraph.ExcludeCalendars(schedule.Parsed, calendars).Next(since)
+		if !next.IsZero() && next.Before(until) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastDeadline returns the latest time of the day, HH:MM, at or before now
+// in the location of now.
+func lastDeadline(timeOfDay string, now time.Time) (time.Time, error) {
+	t, err := time.Parse("15:04", timeOfDay)
+	if err != nil {
+		return time.Time{}, err
+	}
+	deadline := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	if deadline.After(now) {
+		deadline = deadline.AddDate(0, 0, -1)
+	}
+	return deadline, nil
+}
+
+// dagLocation returns the location of the timezone of the DAG, or the
+// default if it has none.
+func dagLocation(dag *digraph.DAG, def *time.Location) *time.Location {
+	if dag.Timezone != "" {
+		if loc, err := time.LoadLocation(dag.Timezone); err == nil {
+			return loc
+		}
+	}
+	return def
+}
+
+// recordSLAMiss adds the miss to the status and the metrics. It returns
+// false if the miss has been reported already.
+func (m *dagJobManager) recordSLAMiss(ctx context.Context, miss SLAMiss) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, recent := range m.recentSLAMisses {
+		if recent.key() == miss.key() {
+			return false
+		}
+	}
+	m.recentSLAMisses = append([]SLAMiss{miss}, m.recentSLAMisses...)
+	if len(m.recentSLAMisses) > maxRecentSLAMisses {
+		m.recentSLAMisses = m.recentSLAMisses[:maxRecentSLAMisses]
+	}
+	m.slaMissCounts[slaMissCountKey{dag: miss.DAG, sla: miss.SLA}]++
+	m.writeStatus(ctx)
+	return true
+}
@@ -0,0 +1,199 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastDeadline(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		timeOfDay string
+		now       time.Time
+		want      time.Time
+	}{
+		{
+			name:      "Today",
+			timeOfDay: "06:00",
+			now:       time.Date(2025, 1, 2, 7, 0, 0, 0, loc),
+			want:      time.Date(2025, 1, 2, 6, 0, 0, 0, loc),
+		},
+		{
+			name:      "Yesterday",
+			timeOfDay: "06:00",
+			now:       time.Date(2025, 1, 2, 5, 59, 0, 0, loc),
+			want:      time.Date(2025, 1, 1, 6, 0, 0, 0, loc),
+		},
+		{
+			name:      "Now",
+			timeOfDay: "06:00",
+			now:       time.Date(2025, 1, 2, 6, 0, 0, 0, loc),
+			want:      time.Date(2025, 1, 2, 6, 0, 0, 0, loc),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lastDeadline(tt.timeOfDay, tt.now)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err = lastDeadline("6am", time.Now())
+	require.Error(t, err)
+}
+
+func TestMaxDurationMiss(t *testing.T) {
+	startedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)
+	m := &dagJobManager{startedAt: startedAt}
+	dag := &digraph.DAG{Name: "sla", Location: "/dags/sla.yaml", SLA: &digraph.SLA{MaxDuration: time.Hour}}
+	status := func(status scheduler.Status, startedAt, finishedAt time.Time) model.Status {
+		return model.Status{
+			RequestID:  "request-id",
+			Status:     status,
+			StartedAt:  stringutil.FormatTime(startedAt),
+			FinishedAt: stringutil.FormatTime(finishedAt),
+		}
+	}
+
+	t.Run("Running", func(t *testing.T) {
+		now := startedAt.Add(2 * time.Hour)
+		miss, ok := m.maxDurationMiss(dag, status(scheduler.StatusRunning, startedAt, time.Time{}), now)
+		require.True(t, ok)
+		require.Equal(t, "sla", miss.DAG)
+		require.Equal(t, SLAMaxDuration, miss.SLA)
+		require.Equal(t, "request-id", miss.RequestID)
+		require.True(t, startedAt.Add(time.Hour).Equal(miss.Due))
+
+		_, ok = m.maxDurationMiss(dag, status(scheduler.StatusRunning, now.Add(-time.Minute), time.Time{}), now)
+		require.False(t, ok)
+	})
+	t.Run("Finished", func(t *testing.T) {
+		now := startedAt.Add(3 * time.Hour)
+		_, ok := m.maxDurationMiss(dag, status(scheduler.StatusSuccess, startedAt, startedAt.Add(2*time.Hour)), now)
+		require.True(t, ok)
+
+		_, ok = m.maxDurationMiss(dag, status(scheduler.StatusSuccess, startedAt, startedAt.Add(time.Minute)), now)
+		require.False(t, ok)
+	})
+	t.Run("FinishedBeforeSchedulerStarted", func(t *testing.T) {
+		before := startedAt.Add(-3 * time.Hour)
+		_, ok := m.maxDurationMiss(dag, status(scheduler.StatusError, before, before.Add(2*time.Hour)), startedAt)
+		require.False(t, ok)
+	})
+}
+
+func TestMustFinishByMiss(t *testing.T) {
+	th := setupTest(t)
+	ctx := context.Background()
+
+	// The deadline at 06:00 UTC on 2025-01-02 (Thursday) has passed.
+	now := time.Date(2025, 1, 2, 7, 0, 0, 0, time.UTC)
+	load := func(t *testing.T, spec string) *digraph.DAG {
+		t.Helper()
+		dag, err := digraph.LoadYAMLWithOpts(ctx, []byte(spec), digraph.BuildOpts{OnlyMetadata: true, NoEval: true})
+		require.NoError(t, err)
+		dag.Location = filepath.Join(t.TempDir(), "sla.yaml")
+		return dag
+	}
+	manager := NewDAGJobManager(t.TempDir(), th.client, "", "").(*dagJobManager)
+	manager.startedAt = now.Add(-24 * time.Hour)
+
+	t.Run("NoRun", func(t *testing.T) {
+		dag := load(t, `
+name: sla
+schedule: "0 1 * * *"
+timezone: UTC
+sla:
+  mustFinishBy: "06:00"
+`)
+		miss, ok := manager.mustFinishByMiss(ctx, dag, now)
+		require.True(t, ok)
+		require.Equal(t, SLAMustFinishBy, miss.SLA)
+		require.Equal(t, time.Date(2025, 1, 2, 6, 0, 0, 0, time.UTC), miss.Due.UTC())
+	})
+	t.Run("NotScheduled", func(t *testing.T) {
+		// The DAG runs only on Mondays.
+		dag := load(t, `
+name: sla
+schedule: "0 1 * * 1"
+timezone: UTC
+sla:
+  mustFinishBy: "06:00"
+`)
+		_, ok := manager.mustFinishByMiss(ctx, dag, now)
+		require.False(t, ok)
+	})
+	t.Run("DeadlineBeforeSchedulerStarted", func(t *testing.T) {
+		dag := load(t, `
+name: sla
+timezone: UTC
+sla:
+  mustFinishBy: "06:00"
+`)
+		_, ok := manager.mustFinishByMiss(ctx, dag, now.Add(-24*time.Hour))
+		require.False(t, ok)
+	})
+}
+
+func TestRecordSLAMiss(t *testing.T) {
+	ctx := context.Background()
+	statusFile := filepath.Join(t.TempDir(), "status.json")
+	manager := NewDAGJobManager(t.TempDir(), nil, "", "", WithStatusFile(statusFile)).(*dagJobManager)
+
+	due := time.Date(2025, 1, 2, 6, 0, 0, 0, time.UTC)
+	miss := SLAMiss{DAG: "sla", SLA: SLAMustFinishBy, Due: due, Reason: "missed", At: due}
+	require.True(t, manager.recordSLAMiss(ctx, miss))
+	// The same miss is reported once.
+	require.False(t, manager.recordSLAMiss(ctx, miss))
+	miss.Due = due.AddDate(0, 0, 1)
+	require.True(t, manager.recordSLAMiss(ctx, miss))
+
+	status, err := ReadStatus(statusFile)
+	require.NoError(t, err)
+	require.Len(t, status.SLAMisses, 2)
+	require.Equal(t, due.AddDate(0, 0, 1), status.SLAMisses[0].Due.UTC())
+	require.Equal(t, []SLAMissCount{{DAG: "sla", SLA: SLAMustFinishBy, Count: 2}}, status.Metrics.SLAMisses)
+
+	// A new scheduler restores the misses and the counts.
+	restored := NewDAGJobManager(t.TempDir(), nil, "", "", WithStatusFile(statusFile)).(*dagJobManager)
+	restored.restoreMetrics()
+	require.False(t, restored.recordSLAMiss(ctx, miss))
+	require.Equal(t, int64(2), restored.slaMissCounts[slaMissCountKey{dag: "sla", sla: SLAMustFinishBy}])
+}
+
+func TestWriteMetrics(t *testing.T) {
+	ctx := context.Background()
+	statusFile := filepath.Join(t.TempDir(), "status.json")
+	manager := NewDAGJobManager(t.TempDir(), nil, "", "", WithStatusFile(statusFile)).(*dagJobManager)
+
+	dag := &digraph.DAG{Name: "lag", Location: "/dags/lag.yaml"}
+	manager.recordLag(ctx, dag, 2*time.Second)
+	manager.recordLag(ctx, dag, time.Second)
+	require.True(t, manager.recordSLAMiss(ctx, SLAMiss{DAG: "lag", SLA: SLAMaxDuration, RequestID: "request-id"}))
+
+	status, err := ReadStatus(statusFile)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteMetrics(&buf, status))
+	out := buf.String()
+	require.Contains(t, out, "# TYPE dagu_scheduler_lag_seconds summary\n")
+	require.Contains(t, out, "dagu_scheduler_lag_seconds_sum{dag=\"lag\"} 3\n")
+	require.Contains(t, out, "dagu_scheduler_lag_seconds_count{dag=\"lag\"} 2\n")
+	require.Contains(t, out, "dagu_scheduler_lag_seconds_max{dag=\"lag\"} 2\n")
+	require.Contains(t, out, "dagu_scheduler_last_lag_seconds{dag=\"lag\"} 1\n")
+	require.Contains(t, out, "dagu_sla_misses_total{dag=\"lag\",sla=\"maxDuration\"} 1\n")
+}
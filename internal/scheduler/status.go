@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/fileutil"
+)
+
+// Status is the status of the scheduler reported to the status file, which
+// the web server reads to show it.
+type Status struct {
+	// UpdatedAt is the time the scheduler reloaded the DAGs last.
+	UpdatedAt time.Time `json:"updatedAt"`
+	// DAGs is the number of the DAGs scheduled.
+	DAGs int `json:"dags"`
+	// LoadErrors contains the DAG files that failed to load, which are not
+	// scheduled until they are fixed.
+	LoadErrors []LoadError `json:"loadErrors"`
+	// Metrics are the metrics of the scheduling of the DAGs.
+	Metrics Metrics `json:"metrics"`
+	// SLAMisses is the latest misses of the SLAs of the DAGs, the newest
+	// first.
+	SLAMisses []SLAMiss `json:"slaMisses"`
+}
+
+// LoadError is the error of loading a DAG file.
+type LoadError struct {
+	// File is the path of the file relative to the DAGs directory.
+	File string `json:"file"`
+	// Error is the error message.
+	Error string `json:"error"`
+	// At is the time the file failed to load.
+	At time.Time `json:"at"`
+}
+
+// WriteStatus writes the status to the file. The status is written to a
+// temporary file and renamed so that the readers never see a partial one.
+func WriteStatus(file string, status Status) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the scheduler status: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(file, data); err != nil {
+		return fmt.Errorf("failed to write the scheduler status: %w", err)
+	}
+	return nil
+}
+
+// ReadStatus reads the status from the file. It returns an error wrapping
+// os.ErrNotExist if the scheduler has not reported its status yet.
+func ReadStatus(file string) (Status, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to read the scheduler status: %w", err)
+	}
+	var status Status
+	if err := json.Unmarshal(data, &status); err != nil {
+		return Status{}, fmt.Errorf("failed to unmarshal the scheduler status %s: %w", file, err)
+	}
+	return status, nil
+}
@@ -0,0 +1,227 @@
+package scheduler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/cmdutil"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/google/uuid"
+)
+
+var (
+	// triggerInterval is the interval to look for the finished runs of the
+	// upstream DAGs.
+	triggerInterval = 10 * time.Second
+
+	// triggerLookback is how long before the start of the scheduler the
+	// finished runs of the upstream DAGs still trigger the downstream DAGs.
+	triggerLookback = time.Hour
+)
+
+// triggerHistoryLimit is the number of the recent runs of an upstream DAG
+// checked on each interval.
+const triggerHistoryLimit = 10
+
+// triggerLink is a link from an upstream DAG to a downstream DAG defined by
+// the triggers of the upstream DAG or the dependsOn of the downstream DAG.
+type triggerLink struct {
+	upstream   *digraph.DAG
+	downstream *digraph.DAG
+	trigger    digraph.DAGTrigger
+}
+
+// watchTriggers starts the downstream DAGs of the runs written to the
+// history until done is closed.
+func (m *dagJobManager) watchTriggers(ctx context.Context, done chan any) {
+	ticker := time.NewTicker(triggerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+
+		case <-ticker.C:
+			m.checkTriggers(ctx)
+
+		}
+	}
+}
+
+// checkTriggers starts the downstream DAGs of the finished upstream runs
+// that have not triggered them yet.
+func (m *dagJobManager) checkTriggers(ctx context.Context) {
+	history := make(map[string][]model.StatusFile)
+	for _, link := range m.triggerLinks() {
+		files, ok := history[link.upstream.Location]
+		if !ok {
+			files = m.client.GetRecentHistory(ctx, link.upstream, triggerHistoryLimit)
+			history[link.upstream.Location] = files
+		}
+		for i := range files {
+			m.trigger(ctx, link, &files[i].Status)
+		}
+	}
+}
+
+// triggerLinks returns the links between the DAGs in the registry.
+func (m *dagJobManager) triggerLinks() []triggerLink {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	byName := make(map[string]*digraph.DAG, len(m.registry))
+	for _, dag := range m.registry {
+		byName[dag.Name] = dag
+	}
+
+	var links []triggerLink
+	for _, dag := range m.registry {
+		for _, trigger := range dag.Triggers {
+			if downstream, ok := byName[trigger.DAG]; ok {
+				links = append(links, triggerLink{upstream: dag, downstream: downstream, trigger: trigger})
+			}
+		}
+		for _, trigger := range dag.DependsOn {
+			if upstream, ok := byName[trigger.DAG]; ok {
+				links = append(links, triggerLink{upstream: upstream, downstream: dag, trigger: trigger})
+			}
+		}
+	}
+	return links
+}
+
+// trigger starts the downstream DAG of the link if the upstream run matches
+// the trigger. The request ID of the downstream run is derived from the
+// upstream request ID, so that an upstream run triggers a downstream DAG
+// only once. The downstream run is recorded in the upstream run once it is
+// found in the history; until then, a start that failed is retried on the
+// next check.
+func (m *dagJobManager) trigger(ctx context.Context, link triggerLink, status *model.Status) {
+	if !triggerMatches(link.trigger.On, status.Status) {
+		return
+	}
+	finishedAt, err := stringutil.ParseTime(status.FinishedAt)
+	if err != nil || finishedAt.Before(m.startedAt.Add(-triggerLookback)) {
+		return
+	}
+	if status.HasDownstream(link.downstream.Name) {
+		return
+	}
+
+	requestID := downstreamRequestID(status.RequestID, link.downstream.Name)
+	if _, err := m.client.GetStatusByRequestID(ctx, link.downstream, requestID); err == nil {
+		// The downstream run has started.
+		status.Downstreams = append(status.Downstreams, model.RunRef{Name: link.downstream.Name, RequestID: requestID})
+		if err := m.client.UpdateStatus(ctx, link.upstream, *status); err != nil {
+			logger.Error(ctx, "Failed to record the downstream run", "upstream", link.upstream.Name, "downstream", link.downstream.Name, "err", err)
+			status.Downstreams = status.Downstreams[:len(status.Downstreams)-1]
+		}
+		return
+	}
+
+	params, err := triggerParams(ctx, link, status)
+	if err != nil {
+		logger.Error(ctx, "Failed to evaluate trigger params", "upstream", link.upstream.Name, "downstream", link.downstream.Name, "err", err)
+		return
+	}
+
+	if !m.startTriggering(requestID) {
+		// The downstream run is being started.
+		return
+	}
+	logger.Info(ctx, "Triggering DAG", "upstream", link.upstream.Name, "upstreamRequestID", status.RequestID,
+		"downstream", link.downstream.Name, "requestID", requestID)
+	opts := client.StartOptions{
+		Params:    params,
+		Quiet:     true,
+		RequestID: requestID,
+		Upstream:  &model.RunRef{Name: link.upstream.Name, RequestID: status.RequestID},
+	}
+	go func() {
+		defer m.finishTriggering(requestID)
+		if err := m.client.Start(ctx, link.downstream, opts); err != nil {
+			logger.Error(ctx, "Triggered DAG run failed", "upstream", link.upstream.Name, "downstream", link.downstream.Name, "requestID", requestID, "err", err)
+		}
+	}()
+}
+
+// startTriggering marks the downstream run as being started. It returns
+// false if it is already being started.
+func (m *dagJobManager) startTriggering(requestID string) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.triggering[requestID] {
+		return false
+	}
+	m.triggering[requestID] = true
+	return true
+}
+
+// finishTriggering unmarks the downstream run once the start returned, when
+// the run either finished or failed to start.
+func (m *dagJobManager) finishTriggering(requestID string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.triggering, requestID)
+}
+
+// triggerMatches returns true if the upstream status fires the trigger.
+func triggerMatches(on digraph.TriggerOn, status scheduler.Status) bool {
+	switch on {
+	case digraph.TriggerOnSuccess:
+		return status == scheduler.StatusSuccess
+	case digraph.TriggerOnFailure:
+		return status == scheduler.StatusError
+	case digraph.TriggerOnAlways:
+		return status == scheduler.StatusSuccess || status == scheduler.StatusError || status == scheduler.StatusCancel
+	default:
+		return false
+	}
+}
+
+// downstreamRequestID returns the request ID of the downstream run triggered
+// by the upstream run.
+func downstreamRequestID(upstreamRequestID, downstream string) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(upstreamRequestID+"/"+downstream)).String()
+}
+
+// triggerParams evaluates the params of the trigger with the params and the
+// outputs of the upstream run.
+func triggerParams(ctx context.Context, link triggerLink, status *model.Status) (string, error) {
+	if link.trigger.Params == "" {
+		return "", nil
+	}
+
+	vars := map[string]string{
+		digraph.VarKeyUpstreamName:      link.upstream.Name,
+		digraph.VarKeyUpstreamRequestID: status.RequestID,
+		digraph.VarKeyUpstreamStatus:    status.StatusText,
+	}
+	for _, param := range status.ParamsList {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			vars[key] = value
+		}
+	}
+	for _, node := range status.Nodes {
+		if node.Step.OutputVariables == nil {
+			continue
+		}
+		node.Step.OutputVariables.Range(func(_, value any) bool {
+			if key, value, ok := strings.Cut(value.(string), "="); ok {
+				vars[key] = value
+			}
+			return true
+		})
+	}
+
+	return cmdutil.EvalString(ctx, link.trigger.Params, cmdutil.WithVariables(vars), cmdutil.OnlyReplaceVars())
+}
@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence/jsondb"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggers(t *testing.T) {
+	// setupTrigger writes a finished run of the upstream DAG and returns the
+	// manager starting the DAGs with the runner.
+	setupTrigger := func(t *testing.T, runner *historyRunner) (testHelper, *dagJobManager, *digraph.DAG) {
+		t.Helper()
+
+		th := setupTest(t, client.WithRunner(runner))
+		ctx := context.Background()
+		runner.historyStore = jsondb.New(th.config.Paths.DataDir)
+
+		manager := th.manager.(*dagJobManager)
+		require.NoError(t, manager.initialize(ctx))
+		upstream := manager.registry["trigger_upstream.yaml"]
+		require.NotNil(t, upstream)
+
+		status := model.NewStatusFactory(upstream).Create("upstream-req", scheduler.StatusSuccess, 0, time.Now(),
+			model.WithFinishedAt(time.Now()))
+		require.NoError(t, runner.historyStore.Open(ctx, upstream.Location, time.Now(), status.RequestID))
+		require.NoError(t, runner.historyStore.Write(ctx, status))
+		require.NoError(t, runner.historyStore.Close(ctx))
+		return th, manager, upstream
+	}
+	// check checks the triggers and waits for the starts to return.
+	check := func(t *testing.T, manager *dagJobManager) {
+		t.Helper()
+
+		manager.checkTriggers(context.Background())
+		require.Eventually(t, func() bool {
+			manager.lock.Lock()
+			defer manager.lock.Unlock()
+			return len(manager.triggering) == 0
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+	downstreams := []model.RunRef{
+		{Name: "trigger_downstream", RequestID: downstreamRequestID("upstream-req", "trigger_downstream")},
+	}
+
+	t.Run("TriggerOnce", func(t *testing.T) {
+		runner := &historyRunner{}
+		th, manager, upstream := setupTrigger(t, runner)
+
+		// Check the triggers three times; the downstream DAG is triggered
+		// once and recorded in the upstream run once it has started.
+		check(t, manager)
+		check(t, manager)
+		check(t, manager)
+		require.Equal(t, 1, runner.startCount())
+
+		updated, err := th.client.GetStatusByRequestID(context.Background(), upstream, "upstream-req")
+		require.NoError(t, err)
+		require.Equal(t, downstreams, updated.Downstreams)
+	})
+	t.Run("RetryFailedStart", func(t *testing.T) {
+		runner := &historyRunner{fail: true}
+		th, manager, upstream := setupTrigger(t, runner)
+
+		// The failed start is not recorded in the upstream run.
+		check(t, manager)
+		updated, err := th.client.GetStatusByRequestID(context.Background(), upstream, "upstream-req")
+		require.NoError(t, err)
+		require.Empty(t, updated.Downstreams)
+
+		// The start is retried on the next check.
+		runner.setFail(false)
+		check(t, manager)
+		check(t, manager)
+		require.Equal(t, 2, runner.startCount())
+
+		updated, err = th.client.GetStatusByRequestID(context.Background(), upstream, "upstream-req")
+		require.NoError(t, err)
+		require.Equal(t, downstreams, updated.Downstreams)
+	})
+	t.Run("TriggerMatches", func(t *testing.T) {
+		require.True(t, triggerMatches(digraph.TriggerOnSuccess, scheduler.StatusSuccess))
+		require.False(t, triggerMatches(digraph.TriggerOnSuccess, scheduler.StatusError))
+		require.True(t, triggerMatches(digraph.TriggerOnFailure, scheduler.StatusError))
+		require.False(t, triggerMatches(digraph.TriggerOnFailure, scheduler.StatusCancel))
+		require.True(t, triggerMatches(digraph.TriggerOnAlways, scheduler.StatusCancel))
+		require.False(t, triggerMatches(digraph.TriggerOnAlways, scheduler.StatusRunning))
+	})
+	t.Run("TriggerParams", func(t *testing.T) {
+		link := triggerLink{
+			upstream: &digraph.DAG{Name: "extract"},
+			trigger:  digraph.DAGTrigger{Params: "DATE=${DATE} FILE=${OUT} FROM=${DAG_UPSTREAM_NAME}"},
+		}
+		outputs := &digraph.SyncMap{}
+		outputs.Store("OUT", "OUT=/tmp/out.csv")
+		status := &model.Status{
+			RequestID:  "req",
+			ParamsList: []string{"DATE=2025-01-02"},
+			Nodes:      []*model.Node{{Step: digraph.Step{OutputVariables: outputs}}},
+		}
+
+		params, err := triggerParams(context.Background(), link, status)
+		require.NoError(t, err)
+		require.Equal(t, "DATE=2025-01-02 FILE=/tmp/out.csv FROM=extract", params)
+	})
+}
@@ -0,0 +1,160 @@
+package scheduler
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/robfig/cron/v3"
+)
+
+// MaxUpcomingRuns is the maximum number of the upcoming runs previewed.
+const MaxUpcomingRuns = 1000
+
+// durationSamples is the number of the recent runs searched for the
+// duration of a run.
+const durationSamples = 10
+
+// UpcomingRun is a start, stop or restart of a DAG by the scheduler.
+type UpcomingRun struct {
+	// Time is the scheduled time in the time zone of the DAG.
+	Time time.Time
+	Type ScheduleType
+	// Overlaps is true if the run starts while the previous run is expected
+	// to be running, judging by the duration of the last successful run.
+	// The start of a DAG that is running is handled by its concurrency
+	// policy.
+	Overlaps bool
+}
+
+// Upcoming is the preview of the upcoming runs of a DAG.
+type Upcoming struct {
+	// Suspended is true if the DAG is suspended. The scheduler does not run
+	// a suspended DAG, so it has no upcoming runs.
+	Suspended bool
+	// Duration is the duration of the last successful run, used to find
+	// the overlapping runs. It is zero if the DAG has not succeeded.
+	Duration time.Duration
+	Runs     []UpcomingRun
+}
+
+// PreviewRuns returns the upcoming runs of the DAG after the time, at most
+// count of them, as the scheduler would run them.
+func PreviewRuns(ctx context.Context, cli client.Client, dag *digraph.DAG, calendars []digraph.Calendar, from time.Time, count int) Upcoming {
+	dagName := strings.TrimSuffix(filepath.Base(dag.Location), filepath.Ext(dag.Location))
+	if cli.IsSuspended(ctx, dagName) {
+		return Upcoming{Suspended: true}
+	}
+	duration := lastDuration(ctx, cli, dag)
+	return Upcoming{
+		Duration: duration,
+		Runs:     UpcomingRuns(dag, calendars, from, count, duration),
+	}
+}
+
+// UpcomingRuns returns the starts, stops and restarts of the DAG after the
+// time in ascending order, at most count of them. A start is marked as
+// overlapping if the previous start or restart is less than duration ago
+// and the DAG has not been stopped since.
+func UpcomingRuns(dag *digraph.DAG, calendars []digraph.Calendar, from time.Time, count int, duration time.Duration) []UpcomingRun {
+	type entry struct {
+		schedule cron.Schedule
+		typ      ScheduleType
+		next     time.Time
+	}
+	var entries []*entry
+	for _, s := range []struct {
+		items []digraph.Schedule
+		typ   ScheduleType
+	}{
+		{dag.Schedule, ScheduleTypeStart},
+		{dag.StopSchedule, ScheduleTypeStop},
+		{dag.RestartSchedule, ScheduleTypeRestart},
+	} {
+		for _, item := range s.items {
+			schedule := digraph.ExcludeCalendars(item.Parsed, calendars)
+			entries = append(entries, &entry{schedule: schedule, typ: s.typ, next: schedule.Next(from)})
+		}
+	}
+
+	loc := from.Location()
+	if dag.Timezone != "" {
+		if l, err := time.LoadLocation(dag.Timezone); err == nil {
+			loc = l
+		}
+	}
+
+	var ret []UpcomingRun
+	var lastStart time.Time
+	for len(ret) < count {
+		// Take the earliest of the next times of the schedules.
+		var e *entry
+		for _, c := range entries {
+			if c.next.IsZero() {
+				continue
+			}
+			if e == nil || c.next.Before(e.next) || (c.next.Equal(e.next) && c.typ < e.typ) {
+				e = c
+			}
+		}
+		if e == nil {
+			break
+		}
+		t := e.next
+		e.next = e.schedule.Next(t)
+
+		// Schedules of the same type at the same time run once.
+		if n := len(ret); n > 0 && ret[n-1].Time.Equal(t) && ret[n-1].Type == e.typ {
+			continue
+		}
+
+		run := UpcomingRun{Time: t.In(loc), Type: e.typ}
+		switch e.typ {
+		case ScheduleTypeStart:
+			run.Overlaps = !lastStart.IsZero() && t.Before(lastStart.Add(duration))
+			if !run.Overlaps {
+				lastStart = t
+			}
+		case ScheduleTypeRestart:
+			lastStart = t
+		case ScheduleTypeStop:
+			lastStart = time.Time{}
+		}
+		ret = append(ret, run)
+	}
+	return ret
+}
+
+// NextStart returns the next time the DAG is scheduled to start after the
+// time, or the zero time if it is not scheduled.
+func NextStart(dag *digraph.DAG, calendars []digraph.Calendar, from time.Time) time.Time {
+	runs := UpcomingRuns(&digraph.DAG{Schedule: dag.Schedule, Timezone: dag.Timezone}, calendars, from, 1, 0)
+	if len(runs) == 0 {
+		return time.Time{}
+	}
+	return runs[0].Time
+}
+
+// lastDuration returns the duration of the last successful run of the DAG.
+func lastDuration(ctx context.Context, cli client.Client, dag *digraph.DAG) time.Duration {
+	for _, r := range cli.GetRecentHistory(ctx, dag, durationSamples) {
+		if r.Status.Status != scheduler.StatusSuccess {
+			continue
+		}
+		startedAt, err := stringutil.ParseTime(r.Status.StartedAt)
+		if err != nil || startedAt.IsZero() {
+			continue
+		}
+		finishedAt, err := stringutil.ParseTime(r.Status.FinishedAt)
+		if err != nil || finishedAt.IsZero() {
+			continue
+		}
+		return finishedAt.Sub(startedAt)
+	}
+	return 0
+}
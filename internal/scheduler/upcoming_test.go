@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpcomingRuns(t *testing.T) {
+	load := func(t *testing.T, spec string) *digraph.DAG {
+		t.Helper()
+		dag, err := digraph.LoadYAMLWithOpts(context.Background(), []byte(spec), digraph.BuildOpts{OnlyMetadata: true, NoEval: true})
+		require.NoError(t, err)
+		return dag
+	}
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("StartStopRestart", func(t *testing.T) {
+		dag := load(t, `
+schedule:
+  start: ["0 1 * * *", "0 1 * * *"]
+  stop: "0 2 * * *"
+  restart: "0 12 * * *"
+timezone: UTC
+`)
+		runs := UpcomingRuns(dag, nil, from, 4, 0)
+		require.Equal(t, []UpcomingRun{
+			{Time: from.Add(time.Hour), Type: ScheduleTypeStart},
+			{Time: from.Add(2 * time.Hour), Type: ScheduleTypeStop},
+			{Time: from.Add(12 * time.Hour), Type: ScheduleTypeRestart},
+			{Time: from.Add(25 * time.Hour), Type: ScheduleTypeStart},
+		}, runs)
+	})
+	t.Run("Timezone", func(t *testing.T) {
+		dag := load(t, `
+schedule: "0 9 * * *"
+timezone: Asia/Tokyo
+`)
+		runs := UpcomingRuns(dag, nil, from, 1, 0)
+		require.Len(t, runs, 1)
+		// 2025-01-01T09:00+09:00 is the time from, which is excluded.
+		require.Equal(t, "2025-01-02T09:00:00+09:00", runs[0].Time.Format(time.RFC3339))
+	})
+	t.Run("Overlaps", func(t *testing.T) {
+		dag := load(t, `
+schedule:
+  start: "*/10 * * * *"
+  stop: "25 * * * *"
+timezone: UTC
+`)
+		// The runs take 15 minutes, so the run at 00:10 overlaps the one at
+		// 00:00, and the run at 00:30 starts after the stop at 00:25.
+		runs := UpcomingRuns(dag, nil, from.Add(-time.Second), 6, 15*time.Minute)
+		var overlaps []bool
+		for _, run := range runs {
+			overlaps = append(overlaps, run.Overlaps)
+		}
+		require.Equal(t, []bool{false, true, false, false, false, true}, overlaps)
+		require.Equal(t, ScheduleTypeStop, runs[3].Type)
+	})
+	t.Run("NextStart", func(t *testing.T) {
+		dag := load(t, `
+schedule:
+  start: "0 1 * * *"
+  stop: "0 0 * * *"
+timezone: UTC
+`)
+		require.Equal(t, from.Add(time.Hour), NextStart(dag, nil, from))
+		require.True(t, NextStart(&digraph.DAG{}, nil, from).IsZero())
+	})
+}
@@ -0,0 +1,204 @@
+package scheduler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/fileutil"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/scheduler/filenotify"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchPollInterval is the interval of the polling watcher, which is used
+// where the file system events are not available.
+var watchPollInterval = 5 * time.Second
+
+// reloadDelay is the time the changes of the files are collected before
+// they are reloaded, so that a file is loaded once its writes settle.
+var reloadDelay = 300 * time.Millisecond
+
+// dagWatcher watches the directories of the files the DAGs are loaded from.
+type dagWatcher struct {
+	watcher filenotify.FileWatcher
+	// watched is the set of the directories watched.
+	watched map[string]bool
+}
+
+// newWatcher starts watching the files the DAGs are loaded from. It watches
+// them before returning so that no change after the DAGs are loaded is
+// missed.
+func (m *dagJobManager) newWatcher(ctx context.Context) (*dagWatcher, error) {
+	watcher, err := filenotify.New(watchPollInterval)
+	if err != nil {
+		return nil, err
+	}
+	w := &dagWatcher{watcher: watcher, watched: map[string]bool{}}
+	m.watchDirs(ctx, w)
+	return w, nil
+}
+
+// watchDags reloads the DAGs when the files in the DAGs directory and its
+// subdirectories, the base config or the dotenv files of the DAGs change.
+func (m *dagJobManager) watchDags(ctx context.Context, w *dagWatcher, done chan any) {
+	watcher := w.watcher
+	defer func() {
+		_ = watcher.Close()
+	}()
+
+	var reload <-chan time.Time
+	changed := map[string]bool{}
+
+	for {
+		select {
+		case <-done:
+			return
+
+		case event, ok := <-watcher.Events():
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			changed[filepath.Clean(event.Name)] = true
+			if reload == nil {
+				reload = time.After(reloadDelay)
+			}
+
+		case <-reload:
+			reload = nil
+			m.reload(ctx, changed)
+			changed = map[string]bool{}
+			m.watchDirs(ctx, w)
+
+		case err, ok := <-watcher.Errors():
+			if !ok {
+				return
+			}
+			logger.Error(ctx, "Watcher error", "err", err)
+
+		}
+	}
+}
+
+// watchDirs adds the directories to watch that are not watched yet: the DAGs
+// directory and its subdirectories, and the directories of the base config
+// and the dotenv files. The directories removed are unwatched.
+func (m *dagJobManager) watchDirs(ctx context.Context, w *dagWatcher) {
+	for dir := range w.watched {
+		if _, err := os.Stat(dir); err != nil {
+			_ = w.watcher.Remove(dir)
+			delete(w.watched, dir)
+		}
+	}
+
+	var dirs []string
+	_ = filepath.WalkDir(m.targetDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if path != m.targetDir && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		dirs = append(dirs, path)
+		return nil
+	})
+	if m.baseConfig != "" {
+		dirs = append(dirs, filepath.Dir(m.baseConfig))
+	}
+	m.lock.Lock()
+	for _, deps := range m.dependencies {
+		for _, dep := range deps {
+			dirs = append(dirs, filepath.Dir(dep))
+		}
+	}
+	m.lock.Unlock()
+
+	for _, dir := range dirs {
+		dir = filepath.Clean(dir)
+		if w.watched[dir] {
+			continue
+		}
+		if _, err := os.Stat(dir); err != nil {
+			// It is watched once it is created.
+			continue
+		}
+		if err := w.watcher.Add(dir); err != nil {
+			logger.Error(ctx, "Failed to watch directory", "dir", dir, "err", err)
+			continue
+		}
+		w.watched[dir] = true
+	}
+}
+
+// reload reloads the DAGs affected by the changed files.
+func (m *dagJobManager) reload(ctx context.Context, changed map[string]bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	files := map[string]bool{}
+	for path := range changed {
+		if m.isBaseConfig(path) {
+			logger.Info(ctx, "Base config changed; reloading all DAGs", "file", path)
+			all, err := m.dagFiles(m.targetDir)
+			if err != nil {
+				logger.Error(ctx, "Failed to list DAGs", "dir", m.targetDir, "err", err)
+			}
+			for _, file := range all {
+				files[file] = true
+			}
+			continue
+		}
+
+		if m.inTargetDir(path) {
+			info, err := os.Stat(path)
+			switch {
+			case err != nil:
+				// removed or renamed
+				m.removeFile(ctx, path)
+
+			case info.IsDir():
+				added, err := m.dagFiles(path)
+				if err != nil {
+					logger.Error(ctx, "Failed to list DAGs", "dir", path, "err", err)
+				}
+				for _, file := range added {
+					files[file] = true
+				}
+
+			case fileutil.IsYAMLFile(path):
+				files[path] = true
+
+			}
+		}
+
+		for key, deps := range m.dependencies {
+			if slices.Contains(deps, path) {
+				files[filepath.Join(m.targetDir, key)] = true
+			}
+		}
+	}
+
+	for file := range files {
+		if _, err := os.Stat(file); err != nil {
+			m.removeFile(ctx, file)
+			continue
+		}
+		if m.loadFile(ctx, file) {
+			logger.Info(ctx, "DAG added/updated", "name", m.key(file))
+		}
+	}
+
+	m.writeStatus(ctx)
+}
+
+// inTargetDir returns true if the path is in the DAGs directory.
+func (m *dagJobManager) inTargetDir(path string) bool {
+	rel, err := filepath.Rel(m.targetDir, path)
+	return err == nil && rel != "." && !strings.HasPrefix(rel, "..")
+}
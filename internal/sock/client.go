@@ -6,12 +6,14 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 )
 
 var (
 	ErrTimeout           = fmt.Errorf("unix socket timeout")
 	ErrConnectionRefused = fmt.Errorf("unix socket connection failed")
+	ErrRequestFailed     = fmt.Errorf("unix socket request failed")
 )
 
 // Client is a unix socket client that can send requests
@@ -68,5 +70,74 @@ func (cl *Client) Request(method, url string) (string, error) {
 		return "", fmt.Errorf("read body failed: %w", err)
 	}
 
+	if response.StatusCode >= http.StatusBadRequest {
+		return string(body), fmt.Errorf("%w: %s", ErrRequestFailed, strings.TrimSpace(string(body)))
+	}
+
 	return string(body), nil
 }
+
+// Stream sends the request and returns the body of the response streamed by
+// the server. The caller must close the body.
+func (cl *Client) Stream(method, url string) (io.ReadCloser, error) {
+	conn, err := net.DialTimeout("unix", cl.addr, defaultTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("dial failed: %w", err)
+	}
+
+	response, err := cl.readStreamResponse(conn, method, url)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return &streamBody{ReadCloser: response.Body, conn: conn}, nil
+}
+
+func (cl *Client) readStreamResponse(conn net.Conn, method, url string) (*http.Response, error) {
+	// The deadline applies until the response starts.
+	if err := conn.SetDeadline((time.Now().Add(defaultTimeout))); err != nil {
+		return nil, fmt.Errorf("set deadline failed: %w", err)
+	}
+
+	request, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+
+	if err := request.Write(conn); err != nil {
+		return nil, fmt.Errorf("write request failed: %w", err)
+	}
+
+	response, err := http.ReadResponse(bufio.NewReader(conn), request)
+	if err != nil {
+		if err, ok := err.(net.Error); ok && err.Timeout() {
+			return nil, fmt.Errorf("request timeout: %w", ErrTimeout)
+		}
+		return nil, fmt.Errorf("read response failed: %w", err)
+	}
+
+	if response.StatusCode >= http.StatusBadRequest {
+		defer func() {
+			_ = response.Body.Close()
+		}()
+		body, _ := io.ReadAll(response.Body)
+		return nil, fmt.Errorf("%w: %s", ErrRequestFailed, strings.TrimSpace(string(body)))
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		_ = response.Body.Close()
+		return nil, fmt.Errorf("set deadline failed: %w", err)
+	}
+	return response, nil
+}
+
+// streamBody closes the connection with the body of the response.
+type streamBody struct {
+	io.ReadCloser
+	conn net.Conn
+}
+
+func (b *streamBody) Close() error {
+	_ = b.ReadCloser.Close()
+	return b.conn.Close()
+}
@@ -3,6 +3,7 @@ package sock_test
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"os"
 	"testing"
@@ -53,3 +54,72 @@ func TestDialTimeout(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, errors.Is(err, sock.ErrTimeout))
 }
+
+func TestRequestFailed(t *testing.T) {
+	f, err := os.CreateTemp("", "sock_client_test")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+
+	srv, err := sock.NewServer(
+		f.Name(),
+		func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "step is required", http.StatusBadRequest)
+		},
+	)
+	require.NoError(t, err)
+
+	go func() {
+		_ = srv.Serve(context.Background(), nil)
+	}()
+
+	time.Sleep(time.Millisecond * 500)
+
+	client := sock.NewClient(f.Name())
+	_, err = client.Request("POST", "/approve")
+	require.ErrorIs(t, err, sock.ErrRequestFailed)
+	require.Contains(t, err.Error(), "step is required")
+}
+
+func TestStream(t *testing.T) {
+	f, err := os.CreateTemp("", "sock_client_test")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+
+	srv, err := sock.NewServer(
+		f.Name(),
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/logs" {
+				http.Error(w, "not found", http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			_, _ = w.Write([]byte("first\n"))
+			time.Sleep(time.Millisecond * 100)
+			_, _ = w.Write([]byte("second\n"))
+		},
+	)
+	require.NoError(t, err)
+
+	go func() {
+		_ = srv.Serve(context.Background(), nil)
+	}()
+
+	time.Sleep(time.Millisecond * 500)
+
+	client := sock.NewClient(f.Name())
+	body, err := client.Stream("GET", "/logs")
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.Equal(t, "first\nsecond\n", string(data))
+
+	_, err = client.Stream("GET", "/unknown")
+	require.ErrorIs(t, err, sock.ErrRequestFailed)
+	require.Contains(t, err.Error(), "not found")
+}
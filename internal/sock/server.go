@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -98,12 +99,17 @@ func (srv *Server) Shutdown(ctx context.Context) error {
 	return nil
 }
 
-var _ http.ResponseWriter = (*httpResponseWriter)(nil)
+var (
+	_ http.ResponseWriter = (*httpResponseWriter)(nil)
+	_ http.Flusher        = (*httpResponseWriter)(nil)
+)
 
 type httpResponseWriter struct {
 	conn       *net.Conn
 	header     http.Header
 	statusCode int
+	// streaming is true after the status and the headers are sent by Flush.
+	streaming bool
 }
 
 func newHTTPResponseWriter(conn *net.Conn) http.ResponseWriter {
@@ -115,6 +121,9 @@ func newHTTPResponseWriter(conn *net.Conn) http.ResponseWriter {
 }
 
 func (w *httpResponseWriter) Write(data []byte) (int, error) {
+	if w.streaming {
+		return (*w.conn).Write(data)
+	}
 	response := http.Response{
 		StatusCode: w.statusCode,
 		ProtoMajor: 1,
@@ -133,3 +142,15 @@ func (w *httpResponseWriter) Header() http.Header {
 func (w *httpResponseWriter) WriteHeader(statusCode int) {
 	w.statusCode = statusCode
 }
+
+// Flush sends the status and the headers. The body written after it is
+// streamed to the connection until the handler returns.
+func (w *httpResponseWriter) Flush() {
+	if w.streaming {
+		return
+	}
+	w.streaming = true
+	_, _ = fmt.Fprintf(*w.conn, "HTTP/1.0 %d %s\r\n", w.statusCode, http.StatusText(w.statusCode))
+	_ = w.header.Write(*w.conn)
+	_, _ = io.WriteString(*w.conn, "\r\n")
+}
@@ -0,0 +1,240 @@
+package stepcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/fileutil"
+)
+
+var _ scheduler.StepCache = (*Store)(nil)
+
+// Store is a step cache that keeps the results under a directory shared by
+// all the agent processes.
+//
+// The standard output of a step is stored once per content in
+// <dir>/objects/<sha256>, and each cache key has an entry file in
+// <dir>/entries/<key>.json that refers to the output and holds the output
+// variables. A flock on <dir>/.lock serializes the writes and the
+// eviction between the processes.
+type Store struct {
+	dir     string
+	maxAge  time.Duration
+	maxSize int64
+}
+
+// Option is a functional option for Store.
+type Option func(*Store)
+
+// WithMaxAge sets the time after which a cached result expires.
+// Zero means that the results do not expire.
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(s *Store) {
+		s.maxAge = maxAge
+	}
+}
+
+// WithMaxSize sets the total size of the stored outputs in bytes.
+// Zero means that the size is not limited.
+func WithMaxSize(maxSize int64) Option {
+	return func(s *Store) {
+		s.maxSize = maxSize
+	}
+}
+
+// New creates a new Store in the given directory.
+func New(dir string, opts ...Option) *Store {
+	s := &Store{dir: dir}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+type entry struct {
+	Key             string            `json:"key"`
+	Stdout          string            `json:"stdout"`
+	OutputVariables map[string]string `json:"outputVariables,omitempty"`
+	CreatedAt       time.Time         `json:"createdAt"`
+}
+
+// Load returns the result stored with the key, or nil if the result is not
+// found or has expired.
+func (s *Store) Load(_ context.Context, key string) (*scheduler.CachedResult, error) {
+	unlock, err := s.lock(unix.LOCK_SH)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	e, err := s.readEntry(s.entryPath(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if s.isExpired(e, time.Now()) {
+		return nil, nil
+	}
+
+	stdout, err := os.ReadFile(s.objectPath(e.Stdout))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached stdout: %w", err)
+	}
+
+	return &scheduler.CachedResult{
+		Stdout:          stdout,
+		OutputVariables: e.OutputVariables,
+	}, nil
+}
+
+// Save stores the result with the key and evicts the expired results and
+// the oldest results exceeding the size limit.
+func (s *Store) Save(_ context.Context, key string, result scheduler.CachedResult) error {
+	if err := os.MkdirAll(filepath.Join(s.dir, "entries"), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	unlock, err := s.lock(unix.LOCK_EX)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	sum := sha256.Sum256(result.Stdout)
+	digest := hex.EncodeToString(sum[:])
+	objectPath := s.objectPath(digest)
+	if _, err := os.Stat(objectPath); errors.Is(err, os.ErrNotExist) {
+		if err := fileutil.WriteFileAtomic(objectPath, result.Stdout); err != nil {
+			return fmt.Errorf("failed to write cached stdout: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(entry{
+		Key:             key,
+		Stdout:          digest,
+		OutputVariables: result.OutputVariables,
+		CreatedAt:       time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+	if err := fileutil.WriteFileAtomic(s.entryPath(key), data); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return s.evict()
+}
+
+// evict removes the expired entries and then the oldest entries until the
+// total size of the outputs fits in the limit. The outputs that are no
+// longer referenced are removed. The caller must hold the exclusive lock.
+func (s *Store) evict() error {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "entries", "*.json"))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var entries []*entry
+	for _, path := range paths {
+		e, err := s.readEntry(path)
+		if err != nil || s.isExpired(e, now) {
+			_ = os.Remove(path)
+			continue
+		}
+		entries = append(entries, e)
+	}
+
+	// newest first
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+
+	var size int64
+	referenced := make(map[string]bool)
+	for _, e := range entries {
+		if !referenced[e.Stdout] {
+			info, err := os.Stat(s.objectPath(e.Stdout))
+			if err != nil {
+				_ = os.Remove(s.entryPath(e.Key))
+				continue
+			}
+			if s.maxSize > 0 && size+info.Size() > s.maxSize {
+				_ = os.Remove(s.entryPath(e.Key))
+				continue
+			}
+			size += info.Size()
+		}
+		referenced[e.Stdout] = true
+	}
+
+	objects, err := filepath.Glob(filepath.Join(s.dir, "objects", "*", "*"))
+	if err != nil {
+		return err
+	}
+	for _, path := range objects {
+		if !referenced[filepath.Base(path)] {
+			_ = os.Remove(path)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) isExpired(e *entry, now time.Time) bool {
+	return s.maxAge > 0 && now.Sub(e.CreatedAt) > s.maxAge
+}
+
+func (s *Store) readEntry(path string) (*entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cache entry %s: %w", path, err)
+	}
+	return &e, nil
+}
+
+func (s *Store) entryPath(key string) string {
+	return filepath.Join(s.dir, "entries", key+".json")
+}
+
+func (s *Store) objectPath(digest string) string {
+	return filepath.Join(s.dir, "objects", digest[:2], digest)
+}
+
+func (s *Store) lock(how int) (func(), error) {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	file, err := os.OpenFile(filepath.Join(s.dir, ".lock"), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache lock file: %w", err)
+	}
+	if err := unix.Flock(int(file.Fd()), how); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to lock the cache: %w", err)
+	}
+	return func() {
+		_ = unix.Flock(int(file.Fd()), unix.LOCK_UN)
+		_ = file.Close()
+	}, nil
+}
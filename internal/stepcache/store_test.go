@@ -0,0 +1,80 @@
+package stepcache
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	t.Run("SaveAndLoad", func(t *testing.T) {
+		store := New(t.TempDir())
+		ctx := context.Background()
+
+		result, err := store.Load(ctx, "key1")
+		require.NoError(t, err)
+		require.Nil(t, result)
+
+		err = store.Save(ctx, "key1", scheduler.CachedResult{
+			Stdout:          []byte("hello\n"),
+			OutputVariables: map[string]string{"OUT": "hello"},
+		})
+		require.NoError(t, err)
+
+		result, err = store.Load(ctx, "key1")
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		require.Equal(t, "hello\n", string(result.Stdout))
+		require.Equal(t, map[string]string{"OUT": "hello"}, result.OutputVariables)
+	})
+	t.Run("ContentAddressed", func(t *testing.T) {
+		dir := t.TempDir()
+		store := New(dir)
+		ctx := context.Background()
+
+		// The same output is stored once for different keys.
+		require.NoError(t, store.Save(ctx, "key1", scheduler.CachedResult{Stdout: []byte("same")}))
+		require.NoError(t, store.Save(ctx, "key2", scheduler.CachedResult{Stdout: []byte("same")}))
+
+		objects, err := filepath.Glob(filepath.Join(dir, "objects", "*", "*"))
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+	})
+	t.Run("Expired", func(t *testing.T) {
+		store := New(t.TempDir(), WithMaxAge(50*time.Millisecond))
+		ctx := context.Background()
+
+		require.NoError(t, store.Save(ctx, "key1", scheduler.CachedResult{Stdout: []byte("old")}))
+		time.Sleep(100 * time.Millisecond)
+
+		result, err := store.Load(ctx, "key1")
+		require.NoError(t, err)
+		require.Nil(t, result)
+	})
+	t.Run("EvictOldestBySize", func(t *testing.T) {
+		dir := t.TempDir()
+		store := New(dir, WithMaxSize(15))
+		ctx := context.Background()
+
+		require.NoError(t, store.Save(ctx, "key1", scheduler.CachedResult{Stdout: []byte(strings.Repeat("a", 10))}))
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, store.Save(ctx, "key2", scheduler.CachedResult{Stdout: []byte(strings.Repeat("b", 10))}))
+
+		result, err := store.Load(ctx, "key1")
+		require.NoError(t, err)
+		require.Nil(t, result)
+
+		result, err = store.Load(ctx, "key2")
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		objects, err := filepath.Glob(filepath.Join(dir, "objects", "*", "*"))
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+	})
+}
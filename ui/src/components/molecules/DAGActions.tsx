@@ -1,10 +1,16 @@
-import { Box, Stack } from '@mui/material';
+import { Box, Checkbox, FormControlLabel, Stack } from '@mui/material';
 import React from 'react';
-import { DAG, SchedulerStatus, Status } from '../../models';
+import { DAG, isActiveStatus, SchedulerStatus, Status } from '../../models';
 import ActionButton from '../atoms/ActionButton';
 import { useNavigate } from 'react-router-dom';
 import { FontAwesomeIcon } from '@fortawesome/react-fontawesome';
-import { faPlay, faStop, faReply } from '@fortawesome/free-solid-svg-icons';
+import {
+  faPlay,
+  faStop,
+  faReply,
+  faPause,
+  faForward,
+} from '@fortawesome/free-solid-svg-icons';
 import VisuallyHidden from '../atoms/VisuallyHidden';
 import StartDAGModal from './StartDAGModal';
 import ConfirmModal from './ConfirmModal';
@@ -45,6 +51,8 @@ function DAGActions({
   const [isStartModal, setIsStartModal] = React.useState(false);
   const [isStopModal, setIsStopModal] = React.useState(false);
   const [isRetryModal, setIsRetryModal] = React.useState(false);
+  const [isPauseModal, setIsPauseModal] = React.useState(false);
+  const [suspendSteps, setSuspendSteps] = React.useState(false);
 
   const onSubmit = React.useCallback(
     async (params: {
@@ -52,6 +60,7 @@ function DAGActions({
       action: string;
       requestId?: string;
       params?: string;
+      suspendSteps?: boolean;
     }) => {
       const url = `${getConfig().apiURL}/dags/${params.name}?remoteNode=${
         appBarContext.selectedRemoteNode || 'local'
@@ -78,10 +87,12 @@ function DAGActions({
     [refresh]
   );
 
+  const isPaused = status?.Status == SchedulerStatus.Paused;
   const buttonState = {
-    start: status?.Status != SchedulerStatus.Running,
-    stop: status?.Status == SchedulerStatus.Running,
-    retry: status?.Status != SchedulerStatus.Running && status?.RequestId != '',
+    start: !isActiveStatus(status?.Status),
+    stop: isActiveStatus(status?.Status),
+    retry: !isActiveStatus(status?.Status) && status?.RequestId != '',
+    pause: isActiveStatus(status?.Status),
   };
   return (
     <Stack direction="row" spacing={2}>
@@ -115,6 +126,27 @@ function DAGActions({
       >
         {label && 'Stop'}
       </ActionButton>
+      <ActionButton
+        label={label}
+        icon={
+          <>
+            <Label show={false}>{isPaused ? 'Resume' : 'Pause'}</Label>
+            <span className="icon">
+              <FontAwesomeIcon icon={isPaused ? faForward : faPause} />
+            </span>
+          </>
+        }
+        disabled={!buttonState['pause']}
+        onClick={() => {
+          if (isPaused) {
+            onSubmit({ name: name, action: 'resume' });
+          } else {
+            setIsPauseModal(true);
+          }
+        }}
+      >
+        {label && (isPaused ? 'Resume' : 'Pause')}
+      </ActionButton>
       <ActionButton
         label={label}
         icon={
@@ -142,6 +174,36 @@ function DAGActions({
       >
         <Box>Do you really want to cancel the DAG?</Box>
       </ConfirmModal>
+      <ConfirmModal
+        title="Confirmation"
+        buttonText="Pause"
+        visible={isPauseModal}
+        dismissModal={() => setIsPauseModal(false)}
+        onSubmit={() => {
+          setIsPauseModal(false);
+          onSubmit({
+            name: name,
+            action: 'pause',
+            suspendSteps: suspendSteps,
+          });
+        }}
+      >
+        <Stack direction="column">
+          <Box>
+            Do you really want to pause the DAG? No new step starts until it
+            is resumed.
+          </Box>
+          <FormControlLabel
+            control={
+              <Checkbox
+                checked={suspendSteps}
+                onChange={(e) => setSuspendSteps(e.target.checked)}
+              />
+            }
+            label="Suspend the running steps"
+          />
+        </Stack>
+      </ConfirmModal>
       <ConfirmModal
         title="Confirmation"
         buttonText="Rerun"
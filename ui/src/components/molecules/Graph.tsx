@@ -72,6 +72,12 @@ const Graph: React.FC<Props> = ({
       "<i class='fas fa-check-circle' style='color: #16a34a'></i>",
     [NodeStatus.Skipped]:
       "<i class='fas fa-forward' style='color: #64748b'></i>",
+    [NodeStatus.Queued]:
+      "<i class='fas fa-hourglass-half' style='color: #ca8a04'></i>",
+    [NodeStatus.Cached]:
+      "<i class='fas fa-database' style='color: #0d9488'></i>",
+    [NodeStatus.Waiting]:
+      "<i class='fas fa-user-check' style='color: #ea580c'></i>",
   };
   if (!animate) {
     // Remove animations if disabled
@@ -164,6 +170,15 @@ const Graph: React.FC<Props> = ({
     dat.push(
       'classDef skipped fill:#f8fafc,stroke:#cbd5e1,color:#475569,stroke-width:1.2px,white-space:nowrap'
     );
+    dat.push(
+      'classDef queued fill:#fefce8,stroke:#fde047,color:#854d0e,stroke-width:1.2px,white-space:nowrap'
+    );
+    dat.push(
+      'classDef cached fill:#f0fdfa,stroke:#5eead4,color:#115e59,stroke-width:1.2px,white-space:nowrap'
+    );
+    dat.push(
+      'classDef waiting fill:#fff7ed,stroke:#fdba74,color:#9a3412,stroke-width:1.2px,white-space:nowrap'
+    );
 
     // Add custom link styles
     dat.push(...linkStyles);
@@ -240,4 +255,7 @@ const graphStatusMap = {
   [NodeStatus.Cancel]: ':::cancel',
   [NodeStatus.Success]: ':::done',
   [NodeStatus.Skipped]: ':::skipped',
+  [NodeStatus.Queued]: ':::queued',
+  [NodeStatus.Cached]: ':::cached',
+  [NodeStatus.Waiting]: ':::waiting',
 };
@@ -2,7 +2,7 @@ import React, { CSSProperties } from 'react';
 import { stepTabColStyles } from '../../consts';
 import { useDAGPostAPI } from '../../hooks/useDAGPostAPI';
 import { Node } from '../../models';
-import { SchedulerStatus, Status } from '../../models';
+import { isActiveStatus, SchedulerStatus, Status } from '../../models';
 import { Step } from '../../models';
 import NodeStatusTableRow from './NodeStatusTableRow';
 import StatusUpdateModal from './StatusUpdateModal';
@@ -33,7 +33,7 @@ function NodeStatusTable({ nodes, status, name, refresh, file = '' }: Props) {
   });
   const requireModal = (step: Step) => {
     if (
-      status?.Status != SchedulerStatus.Running &&
+      !isActiveStatus(status?.Status) &&
       status?.Status != SchedulerStatus.None
     ) {
       setCurrent(step);
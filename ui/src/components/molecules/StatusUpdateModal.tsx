@@ -6,6 +6,7 @@ type Props = {
   visible: boolean;
   dismissModal: () => void;
   step?: Step;
+  waiting?: boolean;
   onSubmit: (step: Step, action: string) => void;
 };
 
@@ -21,7 +22,13 @@ const style = {
   p: 4,
 };
 
-function StatusUpdateModal({ visible, dismissModal, step, onSubmit }: Props) {
+function StatusUpdateModal({
+  visible,
+  dismissModal,
+  step,
+  waiting,
+  onSubmit,
+}: Props) {
   React.useEffect(() => {
     const callback = (event: KeyboardEvent) => {
       const e = event || window.event;
@@ -41,7 +48,9 @@ function StatusUpdateModal({ visible, dismissModal, step, onSubmit }: Props) {
     <Modal open={visible} onClose={dismissModal}>
       <Box sx={style}>
         <Stack direction="row" alignContent="center" justifyContent="center">
-          <Typography variant="h6">Update status of "{step.Name}"</Typography>
+          <Typography variant="h6">
+            {waiting ? 'Approve' : 'Update status of'} "{step.Name}"
+          </Typography>
         </Stack>
         <Stack
           direction="column"
@@ -56,18 +65,37 @@ function StatusUpdateModal({ visible, dismissModal, step, onSubmit }: Props) {
             justifyContent="center"
             spacing={2}
           >
-            <Button
-              variant="outlined"
-              onClick={() => onSubmit(step, 'mark-success')}
-            >
-              Mark Success
-            </Button>
-            <Button
-              variant="outlined"
-              onClick={() => onSubmit(step, 'mark-failed')}
-            >
-              Mark Failed
-            </Button>
+            {waiting ? (
+              <React.Fragment>
+                <Button
+                  variant="outlined"
+                  onClick={() => onSubmit(step, 'approve')}
+                >
+                  Approve
+                </Button>
+                <Button
+                  variant="outlined"
+                  onClick={() => onSubmit(step, 'reject')}
+                >
+                  Reject
+                </Button>
+              </React.Fragment>
+            ) : (
+              <React.Fragment>
+                <Button
+                  variant="outlined"
+                  onClick={() => onSubmit(step, 'mark-success')}
+                >
+                  Mark Success
+                </Button>
+                <Button
+                  variant="outlined"
+                  onClick={() => onSubmit(step, 'mark-failed')}
+                >
+                  Mark Failed
+                </Button>
+              </React.Fragment>
+            )}
           </Stack>
           <Stack direction="row" alignContent="center" justifyContent="center">
             <Button variant="outlined" color="error" onClick={dismissModal}>
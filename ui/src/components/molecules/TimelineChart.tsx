@@ -1,6 +1,6 @@
 import moment from 'moment';
 import React from 'react';
-import { SchedulerStatus, Status } from '../../models';
+import { isActiveStatus, SchedulerStatus, Status } from '../../models';
 import Mermaid from '../atoms/Mermaid';
 
 type Props = {
@@ -12,7 +12,7 @@ const timeFormat = 'YYYY-MM-DD HH:mm:ss';
 function TimelineChart({ status }: Props) {
   if (
     status.Status == SchedulerStatus.None ||
-    status.Status == SchedulerStatus.Running
+    isActiveStatus(status.Status)
   ) {
     return null;
   }
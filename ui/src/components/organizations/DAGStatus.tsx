@@ -1,7 +1,12 @@
 import React from 'react';
 import { DAGContext } from '../../contexts/DAGContext';
 import { DAGStatus } from '../../models';
-import { Handlers, SchedulerStatus } from '../../models';
+import {
+  Handlers,
+  isActiveStatus,
+  NodeStatus,
+  SchedulerStatus,
+} from '../../models';
 import Graph, { FlowchartType } from '../molecules/Graph';
 import NodeStatusTable from '../molecules/NodeStatusTable';
 import DAGStatusOverview from '../molecules/DAGStatusOverview';
@@ -29,6 +34,7 @@ function DAGStatus({ DAG, name, refresh }: Props) {
   const [selectedStep, setSelectedStep] = React.useState<Step | undefined>(
     undefined
   );
+  const [waiting, setWaiting] = React.useState(false);
   const { doPost } = useDAGPostAPI({
     name,
     onSuccess: refresh,
@@ -42,17 +48,25 @@ function DAGStatus({ DAG, name, refresh }: Props) {
   const onSelectStepOnGraph = React.useCallback(
     async (id: string) => {
       const status = DAG.Status?.Status;
-      if (status == SchedulerStatus.Running || status == SchedulerStatus.None) {
-        return;
-      }
       // find the clicked step
       const n = DAG.Status?.Nodes.find(
         (n) => n.Step.Name.replace(/\s/g, '_') == id
       );
-      if (n) {
-        setSelectedStep(n.Step);
-        setModal(true);
+      if (!n) {
+        return;
+      }
+      // a running DAG only accepts the approval of the waiting steps
+      const isWaiting =
+        isActiveStatus(status) && n.Status == NodeStatus.Waiting;
+      if (
+        !isWaiting &&
+        (isActiveStatus(status) || status == SchedulerStatus.None)
+      ) {
+        return;
       }
+      setSelectedStep(n.Step);
+      setWaiting(isWaiting);
+      setModal(true);
     },
     [DAG]
   );
@@ -173,6 +187,7 @@ function DAGStatus({ DAG, name, refresh }: Props) {
       <StatusUpdateModal
         visible={modal}
         step={selectedStep}
+        waiting={waiting}
         dismissModal={dismissModal}
         onSubmit={onUpdateStatus}
       />
@@ -1,5 +1,7 @@
 import { Box, Stack } from '@mui/material';
 import React from 'react';
+import { useConfig } from '../../contexts/ConfigContext';
+import { NodeStatus } from '../../models';
 import { LogFile } from '../../models/api';
 import BorderedBox from '../atoms/BorderedBox';
 import LabeledItem from '../atoms/LabeledItem';
@@ -8,6 +10,8 @@ import NodeStatusChip from '../molecules/NodeStatusChip';
 
 type Props = {
   log?: LogFile;
+  // dagName is set to follow the log of the running step.
+  dagName?: string;
 };
 
 // Credit: https://github.com/chalk/ansi-regex/commit/02fa893d619d3da85411acc8fd4e2eea0e95a9d9 under MIT license
@@ -16,11 +20,39 @@ const ANSI_CODES_REGEX = [
   '(?:(?:\\d{1,4}(?:;\\d{0,4})*)?[\\dA-PR-TZcf-nq-uy=><~]))',
 ].join('|');
 
-function ExecutionLog({ log }: Props) {
+function ExecutionLog({ log, dagName }: Props) {
+  const config = useConfig();
+  const [liveContent, setLiveContent] = React.useState<string | undefined>();
+  const stepName = log?.Step?.Step.Name;
+  const running = log?.Step?.Status == NodeStatus.Running;
+
+  // Follow the log of the running step streamed from the agent.
+  React.useEffect(() => {
+    setLiveContent(undefined);
+    if (!dagName || !stepName || !running) {
+      return;
+    }
+    const source = new EventSource(
+      `${config.apiURL}/dags/${encodeURIComponent(
+        dagName
+      )}/logs/stream?step=${encodeURIComponent(stepName)}`
+    );
+    const lines: string[] = [];
+    source.onmessage = (event) => {
+      lines.push(event.data);
+      setLiveContent(lines.join('\n'));
+    };
+    source.addEventListener('end', () => source.close());
+    source.onerror = () => source.close();
+    return () => source.close();
+  }, [config.apiURL, dagName, stepName, running]);
+
   if (!log) {
     return <LoadingIndicator />;
   }
-  log.Content = log.Content.replace(new RegExp(ANSI_CODES_REGEX, 'g'), '');
+  const content =
+    running && liveContent !== undefined ? liveContent : log.Content;
+  log.Content = content.replace(new RegExp(ANSI_CODES_REGEX, 'g'), '');
   return (
     <Box>
       <Stack spacing={1} direction="column" sx={{ width: '100%' }}>
@@ -12,6 +12,7 @@ export const statusColorMapping: statusColorMapping = {
   [SchedulerStatus.Cancel]: { backgroundColor: 'pink' },
   [SchedulerStatus.Success]: { backgroundColor: 'green', color: 'white' },
   [SchedulerStatus.Skipped_Unused]: { backgroundColor: 'gray', color: 'white' },
+  [SchedulerStatus.Paused]: { backgroundColor: 'gold' },
 };
 
 export const nodeStatusColorMapping = {
@@ -21,6 +22,9 @@ export const nodeStatusColorMapping = {
   [NodeStatus.Cancel]: statusColorMapping[SchedulerStatus.Cancel],
   [NodeStatus.Success]: statusColorMapping[SchedulerStatus.Success],
   [NodeStatus.Skipped]: statusColorMapping[SchedulerStatus.Skipped_Unused],
+  [NodeStatus.Queued]: { backgroundColor: 'khaki' },
+  [NodeStatus.Cached]: { backgroundColor: 'lightseagreen', color: 'white' },
+  [NodeStatus.Waiting]: { backgroundColor: 'orange' },
 };
 
 export const stepTabColStyles = [
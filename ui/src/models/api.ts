@@ -68,6 +68,7 @@ export type WorkflowListItem = {
   Suspended: boolean;
   Error: string;
   DAG: Workflow;
+  NextRun?: string;
 };
 
 export type Workflow = {
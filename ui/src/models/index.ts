@@ -9,6 +9,13 @@ export enum SchedulerStatus {
   Cancel,
   Success,
   Skipped_Unused,
+  Paused,
+}
+
+// isActiveStatus returns true if the run has not finished, i.e. it is
+// running or paused.
+export function isActiveStatus(status?: SchedulerStatus): boolean {
+  return status == SchedulerStatus.Running || status == SchedulerStatus.Paused;
 }
 
 export type Status = {
@@ -26,6 +33,13 @@ export type Status = {
   FinishedAt: string;
   Log: string;
   Params: string;
+  Upstream?: RunRef;
+  Downstreams?: RunRef[];
+};
+
+export type RunRef = {
+  Name: string;
+  RequestId: string;
 };
 
 export function Handlers(s: Status) {
@@ -151,6 +165,11 @@ export function getNextSchedule(data: WorkflowListItem): number {
   if (!schedules || schedules.length == 0 || data.Suspended) {
     return Number.MAX_SAFE_INTEGER;
   }
+  // The server computes the next run with the timezone and the calendars
+  // of the DAG.
+  if (data.NextRun) {
+    return moment(data.NextRun).unix();
+  }
   const tz = getConfig().tz || moment.tz.guess();
   const datesToRun = schedules.map((s) => {
     const cronTzMatch = s.Expression.match(/(?<=CRON_TZ=)[^\s]+/);
@@ -186,6 +205,9 @@ export enum NodeStatus {
   Cancel,
   Success,
   Skipped,
+  Queued,
+  Cached,
+  Waiting,
 }
 
 export type Node = {
@@ -198,6 +220,8 @@ export type Node = {
   DoneCount: number;
   Error: string;
   StatusText: string;
+  Approver?: string;
+  ApprovalComment?: string;
 };
 
 export type StatusFile = {
@@ -212,7 +212,9 @@ function DAGDetails() {
               />
             ) : null}
             {tab == 'scheduler-log' ? <ExecutionLog log={data.ScLog} /> : null}
-            {tab == 'log' ? <ExecutionLog log={data.StepLog} /> : null}
+            {tab == 'log' ? (
+              <ExecutionLog log={data.StepLog} dagName={params.name} />
+            ) : null}
           </Box>
         </Stack>
       </DAGStatusContext.Provider>